@@ -18,9 +18,7 @@ package main
 
 import (
 	"context"
-	"errors"
 	"os"
-	"strings"
 
 	// load credential helpers
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -29,32 +27,18 @@ import (
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/riff/commands"
-	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 func main() {
 	ctx := context.Background()
 	c := cli.Initialize()
 	cmd := commands.NewRootCommand(ctx, c)
+	commands.AddPluginCommand(ctx, c, cmd, os.Args[1:])
 
 	cmd.SilenceErrors = true
+	c.InitErrorFormat(cmd, os.Args[1:])
 	if err := cmd.Execute(); err != nil {
-		// silent errors should not log, but still exit with an error code
-		// typically the command has already been logged with more detail
-		if !errors.Is(err, cli.SilentError) {
-			c.Errorf("Error executing command:\n")
-
-			if aggregate, ok := err.(utilerrors.Aggregate); ok {
-				for _, err := range aggregate.Errors() {
-					c.Errorf("  %s\n", err.Error())
-				}
-			} else {
-				// errors can be multiple lines, indent each line
-				for _, line := range strings.Split(err.Error(), "\n") {
-					c.Errorf("  %s\n", line)
-				}
-			}
-		}
-		os.Exit(1)
+		c.PrintError(err)
+		os.Exit(cli.ExitCode(err))
 	}
 }
@@ -10,9 +10,11 @@ require (
 	github.com/fatih/color v1.9.0
 	github.com/ghodss/yaml v1.0.0
 	github.com/google/go-cmp v0.5.4
+	github.com/mattn/go-isatty v0.0.12
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/projectriff/system v0.0.0-20200626145103-1fcdb7a09056
-	github.com/spf13/cobra v0.0.7
+	github.com/spf13/cobra v1.1.3
+	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.7.1
 	github.com/stretchr/testify v1.6.1
 	github.com/vmware-labs/reconciler-runtime v0.0.0-20200625194853-966cffdf5cfc
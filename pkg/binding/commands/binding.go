@@ -18,10 +18,16 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/validation"
+	bindingsv1alpha1 "github.com/projectriff/system/pkg/apis/bindings/v1alpha1"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func NewBindingCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -34,6 +40,107 @@ func NewBindingCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	}
 
 	cmd.AddCommand(NewImageCommand(ctx, c))
+	cmd.AddCommand(NewServiceCommand(ctx, c))
 
 	return cmd
 }
+
+// validateObjectRef checks a reference is in the form "<resource>:<name>" or
+// "<resource>:<namespace>/<name>"
+func validateObjectRef(ref, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	chunks := strings.Split(ref, ":")
+	if len(chunks) != 2 || chunks[0] == "" {
+		return errs.Also(cli.ErrInvalidValue(ref, field))
+	}
+	if parts := strings.Split(chunks[1], "/"); len(parts) > 2 {
+		errs = errs.Also(cli.ErrInvalidValue(ref, field))
+	} else {
+		for _, part := range parts {
+			if len(validation.K8sName(part, field)) != 0 {
+				errs = errs.Also(cli.ErrInvalidValue(ref, field))
+				break
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateObjectRefFilter checks a filter is a resource, optionally followed by the name of an
+// object, in the form "<resource>[:[<namespace>/]<name>]"
+func validateObjectRefFilter(filter, field string) cli.FieldErrors {
+	if !strings.Contains(filter, ":") {
+		return cli.FieldErrors{}
+	}
+	return validateObjectRef(filter, field)
+}
+
+// objectRefMatcher returns a predicate matching references to the resource and optional name of
+// the filter
+func objectRefMatcher(resources []*metav1.APIResourceList, filter, defaultNamespace string) (func(ref *bindingsv1alpha1.Reference) bool, error) {
+	chunks := strings.SplitN(filter, ":", 2)
+	resource, err := k8s.ResolveResource(resources, chunks[0])
+	if err != nil {
+		return nil, err
+	}
+	var namespace, name string
+	if len(chunks) == 2 {
+		namespace, name = parseObjectRefName(chunks[1], defaultNamespace)
+	}
+	return func(ref *bindingsv1alpha1.Reference) bool {
+		if ref == nil || ref.Kind != resource.Kind {
+			return false
+		}
+		if gv, err := schema.ParseGroupVersion(ref.APIVersion); err != nil || gv.Group != resource.Group {
+			return false
+		}
+		if name != "" && (ref.Name != name || ref.Namespace != namespace) {
+			return false
+		}
+		return true
+	}, nil
+}
+
+// resolveObjectRef resolves a reference in the form "<resource>:<name>" or
+// "<resource>:<namespace>/<name>" against the server's resources. Unqualified names are in the
+// default namespace.
+func resolveObjectRef(resources []*metav1.APIResourceList, ref, defaultNamespace string) (*bindingsv1alpha1.Reference, error) {
+	chunks := strings.Split(ref, ":")
+
+	resource, err := k8s.ResolveResource(resources, chunks[0])
+	if err != nil {
+		return nil, err
+	}
+	namespace, name := parseObjectRefName(chunks[1], defaultNamespace)
+
+	return &bindingsv1alpha1.Reference{
+		APIVersion: schema.GroupVersion{Group: resource.Group, Version: resource.Version}.String(),
+		Kind:       resource.Kind,
+		Namespace:  namespace,
+		Name:       name,
+	}, nil
+}
+
+// formatObjectRef formats a reference for display as "<kind>s.<group>:<name>"
+func formatObjectRef(ref *bindingsv1alpha1.Reference) string {
+	if ref == nil {
+		return ""
+	}
+	// TODO use discovery client to get resource names for group/kind
+	resource := fmt.Sprintf("%ss.%s", strings.ToLower(ref.Kind), strings.Split(ref.APIVersion, "/")[0])
+	if ref.Name == "" && ref.Selector != nil {
+		return fmt.Sprintf("%s[%s]", resource, metav1.FormatLabelSelector(ref.Selector))
+	}
+	return fmt.Sprintf("%s:%s", resource, ref.Name)
+}
+
+// parseObjectRefName splits the optional namespace from the name of a reference, defaulting to
+// the given namespace
+func parseObjectRefName(str, defaultNamespace string) (string, string) {
+	if i := strings.Index(str, "/"); i != -1 {
+		return str[:i], str[i+1:]
+	}
+	return defaultNamespace, str
+}
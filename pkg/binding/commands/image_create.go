@@ -25,31 +25,17 @@ import (
 	"github.com/projectriff/cli/pkg/cli/options"
 	bindingsv1alpha1 "github.com/projectriff/system/pkg/apis/bindings/v1alpha1"
 	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// this should go away once we can properly resolve shortnames and partial names
-// from the server
-var resourceShortNames = map[string]string{
-	"deployment":  "deployments.apps",
-	"deployments": "deployments.apps",
-	"kservice":    "services.serving.knative.dev",
-	"ksvc":        "services.serving.knative.dev",
-	"image":       "images.kpack.io",
-	"img":         "images.kpack.io",
-	"imgs":        "images.kpack.io",
-	"function":    "functions.build.projectriff.io",
-	"application": "applications.build.projectriff.io",
-	"container":   "containers.build.projectriff.io",
-	// add more, but only if we expect it to resolve via `kubectl get foo`
-}
-
 type ImageCreateOptions struct {
 	options.ResourceOptions
 
-	Subject       string
-	Provider      string
-	ContainerName string
+	Subjects        []string
+	SubjectSelector string
+	Provider        string
+	ContainerName   string
 
 	DryRun bool
 }
@@ -65,13 +51,29 @@ func (opts *ImageCreateOptions) Validate(ctx context.Context) cli.FieldErrors {
 
 	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
 
-	if chunks := strings.Split(opts.Subject, ":"); len(chunks) != 2 {
-		errs = errs.Also(cli.ErrInvalidValue(opts.Subject, cli.SubjectFlagName))
+	if len(opts.Subjects) == 0 && opts.SubjectSelector == "" {
+		errs = errs.Also(cli.ErrMissingOneOf(cli.SubjectFlagName, cli.SubjectSelectorFlagName))
+	} else if len(opts.Subjects) != 0 && opts.SubjectSelector != "" {
+		errs = errs.Also(cli.ErrMultipleOneOf(cli.SubjectFlagName, cli.SubjectSelectorFlagName))
 	}
-
-	if chunks := strings.Split(opts.Provider, ":"); len(chunks) != 2 {
-		errs = errs.Also(cli.ErrInvalidValue(opts.Provider, cli.ProviderFlagName))
+	names := map[string]bool{}
+	for i, subject := range opts.Subjects {
+		errs = errs.Also(validateObjectRef(subject, cli.CurrentField).ViaFieldIndex(cli.SubjectFlagName, i))
+		if chunks := strings.SplitN(subject, ":", 2); len(chunks) == 2 {
+			// subjects sharing a namespace and name would share a binding name
+			name := opts.subjectBindingName(parseObjectRefName(chunks[1], opts.Namespace))
+			if names[name] {
+				errs = errs.Also(cli.ErrInvalidArrayValue(subject, cli.SubjectFlagName, i))
+			}
+			names[name] = true
+		}
+	}
+	if opts.SubjectSelector != "" {
+		if _, err := metav1.ParseToLabelSelector(opts.SubjectSelector); err != nil {
+			errs = errs.Also(cli.ErrInvalidValue(opts.SubjectSelector, cli.SubjectSelectorFlagName))
+		}
 	}
+	errs = errs.Also(validateObjectRef(opts.Provider, cli.ProviderFlagName))
 
 	if opts.ContainerName == "" {
 		errs = errs.Also(cli.ErrInvalidValue(opts.ContainerName, cli.ContainerNameFlagName))
@@ -90,64 +92,75 @@ func (opts *ImageCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
 	if err != nil {
 		return err
 	}
-	subject, err := opts.ResolveObjectRef(resources, opts.Subject)
-	if err != nil {
-		return err
-	}
-
-	image := &bindingsv1alpha1.ImageBinding{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: opts.Namespace,
-			Name:      opts.Name,
-		},
-		Spec: bindingsv1alpha1.ImageBindingSpec{
-			ContainerName: opts.ContainerName,
-			Provider:      provider,
-			Subject:       subject,
-		},
+	subjects := []*bindingsv1alpha1.Reference{}
+	for _, ref := range opts.Subjects {
+		subject, err := opts.ResolveObjectRef(resources, ref)
+		if err != nil {
+			return err
+		}
+		subjects = append(subjects, subject)
 	}
-
-	if opts.DryRun {
-		cli.DryRunResource(ctx, image, bindingsv1alpha1.GroupVersion.WithKind("ImageBinding"))
-	} else {
-		var err error
-		image, err = c.Bindings().ImageBindings(opts.Namespace).Create(image)
+	if opts.SubjectSelector != "" {
+		selector, err := metav1.ParseToLabelSelector(opts.SubjectSelector)
 		if err != nil {
 			return err
 		}
+		// the selector is resolved as Deployments come and go
+		subjects = append(subjects, &bindingsv1alpha1.Reference{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+			Namespace:  opts.Namespace,
+			Selector:   selector,
+		})
 	}
-	c.Successf("Created image binding %q\n", image.Name)
-	return nil
-}
-
-func (opts *ImageCreateOptions) ResolveObjectRef(resources []*metav1.APIResourceList, ref string) (*bindingsv1alpha1.Reference, error) {
-	chunks := strings.Split(ref, ":")
 
-	resource := chunks[0]
-	name := chunks[1]
-
-	// TODO replace static lookup by resolving short names from the resources metadata
-	if r, ok := resourceShortNames[resource]; ok {
-		resource = r
-	}
+	for i, subject := range subjects {
+		name := opts.Name
+		if len(subjects) != 1 {
+			name = opts.subjectBindingName(subject.Namespace, subject.Name)
+		}
+		image := &bindingsv1alpha1.ImageBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: opts.Namespace,
+				Name:      name,
+			},
+			Spec: bindingsv1alpha1.ImageBindingSpec{
+				ContainerName: opts.ContainerName,
+				Provider:      provider,
+				Subject:       subject,
+			},
+		}
 
-	// tease out apiVersion and kind
-	targetPrefix := fmt.Sprintf("%s/", resource)
-	for _, rl := range resources {
-		for _, r := range rl.APIResources {
-			fullname := fmt.Sprintf("%s.%s", r.Name, rl.GroupVersion)
-			if strings.HasPrefix(fullname, targetPrefix) {
-				return &bindingsv1alpha1.Reference{
-					APIVersion: rl.GroupVersion,
-					Kind:       r.Kind,
-					Namespace:  opts.Namespace,
-					Name:       name,
-				}, nil
+		if opts.DryRun {
+			cli.DryRunResource(ctx, image, bindingsv1alpha1.GroupVersion.WithKind("ImageBinding"))
+		} else {
+			image, err = c.Bindings().ImageBindings(opts.Namespace).Create(image)
+			if err != nil {
+				if i != 0 {
+					c.Errorf("Created %d of %d image bindings, unable to create %q\n", i, len(subjects), name)
+				}
+				return err
 			}
 		}
+		c.Successf("Created image binding %q\n", image.Name)
 	}
+	return nil
+}
 
-	return nil, fmt.Errorf("the server doesn't have a resource type %q", resource)
+// subjectBindingName names the binding for one of several subjects, named for the subject and
+// qualified by the subject's namespace when it is not the binding's namespace
+func (opts *ImageCreateOptions) subjectBindingName(namespace, name string) string {
+	if namespace != opts.Namespace {
+		return fmt.Sprintf("%s-%s-%s", opts.Name, namespace, name)
+	}
+	return fmt.Sprintf("%s-%s", opts.Name, name)
+}
+
+// ResolveObjectRef resolves a reference in the form "<resource>:<name>" or
+// "<resource>:<namespace>/<name>" against the server's resources. Unqualified names are in the
+// options namespace.
+func (opts *ImageCreateOptions) ResolveObjectRef(resources []*metav1.APIResourceList, ref string) (*bindingsv1alpha1.Reference, error) {
+	return resolveObjectRef(resources, ref, opts.Namespace)
 }
 
 func (opts *ImageCreateOptions) IsDryRun() bool {
@@ -163,10 +176,22 @@ func NewImageCreateCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 		Long: strings.TrimSpace(`
 Create an image binding.
 
-<todo>
+The subject and provider are object references in the form
+"<resource>:<name>". The resource is resolved from the server's API resources
+and may be the plural, singular, short name or kind of the resource, optionally
+qualified by its group, like "deployments.apps" or
+"Function.build.projectriff.io". Objects in another namespace are referenced as
+"<resource>:<namespace>/<name>".
+
+A provider is bound to several subjects by repeating ` + cli.SubjectFlagName + `, a binding
+is created for each subject named "<name>-<subject-name>", or
+"<name>-<subject-namespace>-<subject-name>" for subjects in another namespace.
+A provider is bound to every Deployment in the namespace matching a label
+selector with ` + cli.SubjectSelectorFlagName + `, including Deployments created later.
 `),
 		Example: strings.Join([]string{
-			fmt.Sprintf("%s binding image create my-image-binding", c.Name),
+			fmt.Sprintf("%s binding image create my-image-binding %s deployment:my-deployment %s function:my-function %s user-container", c.Name, cli.SubjectFlagName, cli.ProviderFlagName, cli.ContainerNameFlagName),
+			fmt.Sprintf("%s binding image create my-image-binding %s app=my-app %s function:my-function %s user-container", c.Name, cli.SubjectSelectorFlagName, cli.ProviderFlagName, cli.ContainerNameFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -177,8 +202,9 @@ Create an image binding.
 	)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
-	cmd.Flags().StringVar(&opts.Subject, cli.StripDash(cli.SubjectFlagName), "", "subject `object reference` to inject images into")
-	cmd.Flags().StringVar(&opts.Provider, cli.StripDash(cli.ProviderFlagName), "", "provider `object reference` to get images from")
+	cmd.Flags().StringArrayVar(&opts.Subjects, cli.StripDash(cli.SubjectFlagName), []string{}, "subject `object reference` to inject images into, in the form <resource>:[<namespace>/]<name> (may be set multiple times)")
+	cmd.Flags().StringVar(&opts.SubjectSelector, cli.StripDash(cli.SubjectSelectorFlagName), "", "label `selector` for Deployments to inject images into")
+	cmd.Flags().StringVar(&opts.Provider, cli.StripDash(cli.ProviderFlagName), "", "provider `object reference` to get images from, in the form <resource>:[<namespace>/]<name>")
 	cmd.Flags().StringVar(&opts.ContainerName, cli.StripDash(cli.ContainerNameFlagName), "", "`container` in the subject to inject into")
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
 
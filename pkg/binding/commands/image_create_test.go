@@ -37,7 +37,7 @@ func TestImageBindingCreateOptions(t *testing.T) {
 				ResourceOptions: rifftesting.InvalidResourceOptions,
 			},
 			ExpectFieldErrors: rifftesting.InvalidResourceOptionsFieldError.Also(
-				cli.ErrInvalidValue("", cli.SubjectFlagName),
+				cli.ErrMissingOneOf(cli.SubjectFlagName, cli.SubjectSelectorFlagName),
 				cli.ErrInvalidValue("", cli.ProviderFlagName),
 				cli.ErrInvalidValue("", cli.ContainerNameFlagName),
 			),
@@ -46,7 +46,7 @@ func TestImageBindingCreateOptions(t *testing.T) {
 			Name: "valid resource",
 			Options: &commands.ImageCreateOptions{
 				ResourceOptions: rifftesting.ValidResourceOptions,
-				Subject:         "deployment:my-deployment",
+				Subjects:        []string{"deployment:my-deployment"},
 				Provider:        "function:my-function",
 				ContainerName:   "user-container",
 			},
@@ -56,17 +56,98 @@ func TestImageBindingCreateOptions(t *testing.T) {
 			Name: "invalid subject",
 			Options: &commands.ImageCreateOptions{
 				ResourceOptions: rifftesting.ValidResourceOptions,
-				Subject:         "foo",
+				Subjects:        []string{"foo"},
 				Provider:        "function:my-function",
 				ContainerName:   "user-container",
 			},
-			ExpectFieldErrors: cli.ErrInvalidValue("foo", cli.SubjectFlagName),
+			ExpectFieldErrors: cli.ErrInvalidValue("foo", cli.CurrentField).ViaFieldIndex(cli.SubjectFlagName, 0),
+		},
+		{
+			Name: "cross namespace references",
+			Options: &commands.ImageCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Subjects:        []string{"deployment:other-namespace/my-deployment"},
+				Provider:        "function:other-namespace/my-function",
+				ContainerName:   "user-container",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid subject name",
+			Options: &commands.ImageCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Subjects:        []string{"deployment:a/b/c"},
+				Provider:        "function:my-function",
+				ContainerName:   "user-container",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("deployment:a/b/c", cli.CurrentField).ViaFieldIndex(cli.SubjectFlagName, 0),
+		},
+		{
+			Name: "multiple subjects",
+			Options: &commands.ImageCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Subjects:        []string{"deployment:my-deployment", "ksvc:my-service"},
+				Provider:        "function:my-function",
+				ContainerName:   "user-container",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "subjects in different namespaces",
+			Options: &commands.ImageCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Subjects:        []string{"deployment:my-deployment", "deployment:other/my-deployment"},
+				Provider:        "function:my-function",
+				ContainerName:   "user-container",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "duplicate subject names",
+			Options: &commands.ImageCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Subjects:        []string{"deployment:my-deployment", "ksvc:my-deployment"},
+				Provider:        "function:my-function",
+				ContainerName:   "user-container",
+			},
+			ExpectFieldErrors: cli.ErrInvalidArrayValue("ksvc:my-deployment", cli.SubjectFlagName, 1),
+		},
+		{
+			Name: "subject selector",
+			Options: &commands.ImageCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				SubjectSelector: "app=my-app",
+				Provider:        "function:my-function",
+				ContainerName:   "user-container",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid subject selector",
+			Options: &commands.ImageCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				SubjectSelector: "app in (",
+				Provider:        "function:my-function",
+				ContainerName:   "user-container",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("app in (", cli.SubjectSelectorFlagName),
+		},
+		{
+			Name: "subject and subject selector",
+			Options: &commands.ImageCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Subjects:        []string{"deployment:my-deployment"},
+				SubjectSelector: "app=my-app",
+				Provider:        "function:my-function",
+				ContainerName:   "user-container",
+			},
+			ExpectFieldErrors: cli.ErrMultipleOneOf(cli.SubjectFlagName, cli.SubjectSelectorFlagName),
 		},
 		{
 			Name: "invalid providers",
 			Options: &commands.ImageCreateOptions{
 				ResourceOptions: rifftesting.ValidResourceOptions,
-				Subject:         "deployment:my-deployment",
+				Subjects:        []string{"deployment:my-deployment"},
 				Provider:        "foo",
 				ContainerName:   "user-container",
 			},
@@ -171,6 +252,282 @@ Created image binding "my-image-binding"
 Created image binding "my-image-binding"
 `,
 		},
+		{
+			Name: "create, kind and group",
+			Args: []string{imageBindingName, cli.SubjectFlagName, "Service.serving.knative.dev:my-service", cli.ProviderFlagName, "function.build.projectriff.io:my-function", cli.ContainerNameFlagName, containerName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				addTestDiscoveryResources(discovery)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config) error {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{}
+				return nil
+			},
+			ExpectCreates: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      imageBindingName,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "serving.knative.dev/v1",
+							Kind:       "Service",
+							Namespace:  defaultNamespace,
+							Name:       serviceName,
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Namespace:  defaultNamespace,
+							Name:       functionName,
+						},
+						ContainerName: containerName,
+					},
+				},
+			},
+			ExpectOutput: `
+Created image binding "my-image-binding"
+`,
+		},
+		{
+			Name: "create, cross namespace provider",
+			Args: []string{imageBindingName, cli.SubjectFlagName, "deploy:my-deployment", cli.ProviderFlagName, "function:builds/my-function", cli.ContainerNameFlagName, containerName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				addTestDiscoveryResources(discovery)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config) error {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{}
+				return nil
+			},
+			ExpectCreates: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      imageBindingName,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Namespace:  defaultNamespace,
+							Name:       deploymentName,
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Namespace:  "builds",
+							Name:       functionName,
+						},
+						ContainerName: containerName,
+					},
+				},
+			},
+			ExpectOutput: `
+Created image binding "my-image-binding"
+`,
+		},
+		{
+			Name: "create, ambiguous subject",
+			Args: []string{imageBindingName, cli.SubjectFlagName, "mysqldatabase:my-database", cli.ProviderFlagName, "function:my-function", cli.ContainerNameFlagName, containerName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				addTestDiscoveryResources(discovery)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config) error {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{}
+				return nil
+			},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				expected := `resource type "mysqldatabase" is ambiguous, use one of: mysqldatabases.db.example.com, mysqldatabases.db.example.org`
+				if err == nil || err.Error() != expected {
+					t.Errorf("expected error %q, actual %v", expected, err)
+				}
+			},
+		},
+		{
+			Name: "create, multiple subjects",
+			Args: []string{imageBindingName, cli.SubjectFlagName, "deployment:my-deployment", cli.SubjectFlagName, "ksvc:my-service", cli.ProviderFlagName, "function:my-function", cli.ContainerNameFlagName, containerName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				addTestDiscoveryResources(discovery)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config) error {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{}
+				return nil
+			},
+			ExpectCreates: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "my-image-binding-my-deployment",
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       deploymentName,
+							Namespace:  defaultNamespace,
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Namespace:  defaultNamespace,
+							Name:       functionName,
+						},
+						ContainerName: containerName,
+					},
+				},
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "my-image-binding-my-service",
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "serving.knative.dev/v1",
+							Kind:       "Service",
+							Name:       serviceName,
+							Namespace:  defaultNamespace,
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Namespace:  defaultNamespace,
+							Name:       functionName,
+						},
+						ContainerName: containerName,
+					},
+				},
+			},
+			ExpectOutput: `
+Created image binding "my-image-binding-my-deployment"
+Created image binding "my-image-binding-my-service"
+`,
+		},
+		{
+			Name: "create, subject selector",
+			Args: []string{imageBindingName, cli.SubjectSelectorFlagName, "app=my-app", cli.ProviderFlagName, "function:my-function", cli.ContainerNameFlagName, containerName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				addTestDiscoveryResources(discovery)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config) error {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{}
+				return nil
+			},
+			ExpectCreates: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      imageBindingName,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Namespace:  defaultNamespace,
+							Selector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"app": "my-app"},
+							},
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Namespace:  defaultNamespace,
+							Name:       functionName,
+						},
+						ContainerName: containerName,
+					},
+				},
+			},
+			ExpectOutput: `
+Created image binding "my-image-binding"
+`,
+		},
+		{
+			Name: "create, multiple subjects in different namespaces",
+			Args: []string{imageBindingName, cli.SubjectFlagName, "deployment:my-deployment", cli.SubjectFlagName, "deployment:other/my-deployment", cli.ProviderFlagName, "function:my-function", cli.ContainerNameFlagName, containerName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				addTestDiscoveryResources(discovery)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config) error {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{}
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "my-image-binding-other-my-deployment",
+					},
+				},
+			},
+			ExpectCreates: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "my-image-binding-my-deployment",
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Namespace:  defaultNamespace,
+							Name:       deploymentName,
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Namespace:  defaultNamespace,
+							Name:       functionName,
+						},
+						ContainerName: containerName,
+					},
+				},
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "my-image-binding-other-my-deployment",
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Namespace:  "other",
+							Name:       deploymentName,
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Namespace:  defaultNamespace,
+							Name:       functionName,
+						},
+						ContainerName: containerName,
+					},
+				},
+			},
+			ExpectOutput: `
+Created image binding "my-image-binding-my-deployment"
+Created 1 of 2 image bindings, unable to create "my-image-binding-other-my-deployment"
+`,
+			ShouldError: true,
+		},
 		{
 			Name: "create, dry run",
 			Args: []string{imageBindingName, cli.SubjectFlagName, "deployments.apps:my-deployment", cli.ProviderFlagName, "functions.build.projectriff.io:my-function", cli.ContainerNameFlagName, containerName, cli.DryRunFlagName},
@@ -318,11 +675,28 @@ Created image binding "my-image-binding"
 
 func addTestDiscoveryResources(discovery *fakediscovery.FakeDiscovery) {
 	discovery.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{
+					Name:         "services",
+					SingularName: "service",
+					ShortNames:   []string{"svc"},
+					Kind:         "Service",
+				},
+			},
+		},
 		{
 			GroupVersion: "apps/v1",
 			APIResources: []metav1.APIResource{
 				{
-					Name: "deployments",
+					Name:         "deployments",
+					SingularName: "deployment",
+					ShortNames:   []string{"deploy"},
+					Kind:         "Deployment",
+				},
+				{
+					Name: "deployments/status",
 					Kind: "Deployment",
 				},
 			},
@@ -331,8 +705,29 @@ func addTestDiscoveryResources(discovery *fakediscovery.FakeDiscovery) {
 			GroupVersion: "build.projectriff.io/v1alpha1",
 			APIResources: []metav1.APIResource{
 				{
-					Name: "functions",
-					Kind: "Function",
+					Name:         "functions",
+					SingularName: "function",
+					Kind:         "Function",
+				},
+			},
+		},
+		{
+			GroupVersion: "db.example.com/v1alpha1",
+			APIResources: []metav1.APIResource{
+				{
+					Name:         "mysqldatabases",
+					SingularName: "mysqldatabase",
+					Kind:         "MySQLDatabase",
+				},
+			},
+		},
+		{
+			GroupVersion: "db.example.org/v1alpha1",
+			APIResources: []metav1.APIResource{
+				{
+					Name:         "mysqldatabases",
+					SingularName: "mysqldatabase",
+					Kind:         "MySQLDatabase",
 				},
 			},
 		},
@@ -340,8 +735,10 @@ func addTestDiscoveryResources(discovery *fakediscovery.FakeDiscovery) {
 			GroupVersion: "serving.knative.dev/v1",
 			APIResources: []metav1.APIResource{
 				{
-					Name: "services",
-					Kind: "Service",
+					Name:         "services",
+					SingularName: "service",
+					ShortNames:   []string{"kservice", "ksvc"},
+					Kind:         "Service",
 				},
 			},
 		},
@@ -86,6 +86,7 @@ namespace.
 	cli.Args(cmd,
 		cli.NamesArg(&opts.Names),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNamesArg(c, cli.ListImageBindings)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(cli.AllFlagName), false, "delete all image bindings within the namespace")
@@ -35,6 +35,9 @@ import (
 
 type ImageListOptions struct {
 	options.ListOptions
+
+	Subject  string
+	Provider string
 }
 
 var (
@@ -47,6 +50,13 @@ func (opts *ImageListOptions) Validate(ctx context.Context) cli.FieldErrors {
 
 	errs = errs.Also(opts.ListOptions.Validate(ctx))
 
+	if opts.Subject != "" {
+		errs = errs.Also(validateObjectRefFilter(opts.Subject, cli.SubjectFlagName))
+	}
+	if opts.Provider != "" {
+		errs = errs.Also(validateObjectRefFilter(opts.Provider, cli.ProviderFlagName))
+	}
+
 	return errs
 }
 
@@ -56,6 +66,43 @@ func (opts *ImageListOptions) Exec(ctx context.Context, c *cli.Config) error {
 		return err
 	}
 
+	if opts.Subject != "" || opts.Provider != "" {
+		resources, err := c.Discovery().ServerResources()
+		if err != nil {
+			return err
+		}
+		filters := []func(image *bindingsv1alpha1.ImageBinding) bool{}
+		if opts.Subject != "" {
+			matches, err := objectRefMatcher(resources, opts.Subject, opts.Namespace)
+			if err != nil {
+				return err
+			}
+			filters = append(filters, func(image *bindingsv1alpha1.ImageBinding) bool {
+				return matches(image.Spec.Subject)
+			})
+		}
+		if opts.Provider != "" {
+			matches, err := objectRefMatcher(resources, opts.Provider, opts.Namespace)
+			if err != nil {
+				return err
+			}
+			filters = append(filters, func(image *bindingsv1alpha1.ImageBinding) bool {
+				return matches(image.Spec.Provider)
+			})
+		}
+		items := []bindingsv1alpha1.ImageBinding{}
+		for i := range images.Items {
+			matches := true
+			for _, filter := range filters {
+				matches = matches && filter(&images.Items[i])
+			}
+			if matches {
+				items = append(items, images.Items[i])
+			}
+		}
+		images.Items = items
+	}
+
 	if len(images.Items) == 0 {
 		c.Infof("No image bindings found.\n")
 		return nil
@@ -84,6 +131,10 @@ func NewImageListCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 		Long: strings.TrimSpace(`
 List image bindings in a namespace or across all namespaces.
 
+Image bindings are filtered by subject or provider with ` + cli.SubjectFlagName + ` and
+` + cli.ProviderFlagName + `. A filter is a resource, like "deployments.apps", optionally
+followed by the name of an object, like "deployment:my-deployment".
+
 For detail regarding the status of a single image, run:
 
     ` + c.Name + ` binding image status <image-binding-name>
@@ -91,12 +142,16 @@ For detail regarding the status of a single image, run:
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s binding image list", c.Name),
 			fmt.Sprintf("%s binding image list %s", c.Name, cli.AllNamespacesFlagName),
+			fmt.Sprintf("%s binding image list %s deployment:my-deployment", c.Name, cli.SubjectFlagName),
+			fmt.Sprintf("%s binding image list %s function", c.Name, cli.ProviderFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.AllNamespacesFlag(cmd, c, &opts.Namespace, &opts.AllNamespaces)
+	cmd.Flags().StringVar(&opts.Subject, cli.StripDash(cli.SubjectFlagName), "", "only list bindings for subjects matching the `filter`, in the form <resource>[:[<namespace>/]<name>]")
+	cmd.Flags().StringVar(&opts.Provider, cli.StripDash(cli.ProviderFlagName), "", "only list bindings for providers matching the `filter`, in the form <resource>[:[<namespace>/]<name>]")
 
 	return cmd
 }
@@ -122,18 +177,10 @@ func (opts *ImageListOptions) print(image *bindingsv1alpha1.ImageBinding, _ prin
 	if condition == nil {
 		condition = &apis.Condition{}
 	}
-	// TODO use discovery client to get resource names for group/kind
-	var subject, provider string
-	if image.Spec.Subject != nil {
-		subject = fmt.Sprintf("%ss.%s:%s", strings.ToLower(image.Spec.Subject.Kind), strings.Split(image.Spec.Subject.APIVersion, "/")[0], image.Spec.Subject.Name)
-	}
-	if image.Spec.Provider != nil {
-		provider = fmt.Sprintf("%ss.%s:%s", strings.ToLower(image.Spec.Provider.Kind), strings.Split(image.Spec.Provider.APIVersion, "/")[0], image.Spec.Provider.Name)
-	}
 	row.Cells = append(row.Cells,
 		image.Name,
-		cli.FormatEmptyString(subject),
-		cli.FormatEmptyString(provider),
+		cli.FormatEmptyString(formatObjectRef(image.Spec.Subject)),
+		cli.FormatEmptyString(formatObjectRef(image.Spec.Provider)),
 		cli.FormatEmptyString(image.Spec.ContainerName),
 		cli.FormatConditionStatus(&apis.Condition{
 			Type:   apis.ConditionReady,
@@ -27,6 +27,7 @@ import (
 	"github.com/vmware-labs/reconciler-runtime/apis"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
 )
 
 func TestImageBindingListOptions(t *testing.T) {
@@ -45,6 +46,27 @@ func TestImageBindingListOptions(t *testing.T) {
 			},
 			ShouldValidate: true,
 		},
+		{
+			Name: "valid filters",
+			Options: &commands.ImageListOptions{
+				ListOptions: rifftesting.ValidListOptions,
+				Subject:     "deployment:my-deployment",
+				Provider:    "function",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid filters",
+			Options: &commands.ImageListOptions{
+				ListOptions: rifftesting.ValidListOptions,
+				Subject:     "deployment:My_Deployment",
+				Provider:    ":my-function",
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidValue("deployment:My_Deployment", cli.SubjectFlagName),
+				cli.ErrInvalidValue(":my-function", cli.ProviderFlagName),
+			),
+		},
 	}
 
 	table.Run(t)
@@ -166,6 +188,452 @@ NAME                 SUBJECT                          PROVIDER
 test-image-binding   deployments.apps:my-deployment   functions.build.projectriff.io:my-function   user-container   Ready    <unknown>
 `,
 		},
+		{
+			Name: "subject selector",
+			Args: []string{},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Namespace:  "default",
+							Selector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"app": "my-app"},
+							},
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Namespace:  "default",
+							Name:       "my-function",
+						},
+						ContainerName: "user-container",
+					},
+				},
+			},
+			ExpectOutput: `
+NAME                 SUBJECT                        PROVIDER                                     CONTAINER NAME   STATUS      AGE
+test-image-binding   deployments.apps[app=my-app]   functions.build.projectriff.io:my-function   user-container   <unknown>   <unknown>
+`,
+		},
+		{
+			Name: "filters by subject resource",
+			Args: []string{cli.SubjectFlagName, "deployments.apps"},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				addTestDiscoveryResources(discovery)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config) error {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{}
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Name:       "my-function",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingOtherName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "serving.knative.dev/v1",
+							Kind:       "Service",
+							Name:       "my-service",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Name:       "my-function",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-application-image-binding",
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Application",
+							Name:       "my-application",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+			},
+			ExpectOutput: `
+NAME                             SUBJECT                          PROVIDER                                           CONTAINER NAME   STATUS      AGE
+test-application-image-binding   deployments.apps:my-deployment   applications.build.projectriff.io:my-application   user-container   <unknown>   <unknown>
+test-image-binding               deployments.apps:my-deployment   functions.build.projectriff.io:my-function         user-container   <unknown>   <unknown>
+`,
+		},
+		{
+			Name: "filters by subject and provider",
+			Args: []string{cli.SubjectFlagName, "deploy:my-deployment", cli.ProviderFlagName, "function:default/my-function"},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				addTestDiscoveryResources(discovery)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config) error {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{}
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Name:       "my-function",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingOtherName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "serving.knative.dev/v1",
+							Kind:       "Service",
+							Name:       "my-service",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Name:       "my-function",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-application-image-binding",
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Application",
+							Name:       "my-application",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+			},
+			ExpectOutput: `
+NAME                 SUBJECT                          PROVIDER                                     CONTAINER NAME   STATUS      AGE
+test-image-binding   deployments.apps:my-deployment   functions.build.projectriff.io:my-function   user-container   <unknown>   <unknown>
+`,
+		},
+		{
+			Name: "filters by provider name",
+			Args: []string{cli.ProviderFlagName, "function:my-function"},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				addTestDiscoveryResources(discovery)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config) error {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{}
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Name:       "my-function",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingOtherName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "serving.knative.dev/v1",
+							Kind:       "Service",
+							Name:       "my-service",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Name:       "my-function",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-application-image-binding",
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Application",
+							Name:       "my-application",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+			},
+			ExpectOutput: `
+NAME                       SUBJECT                                   PROVIDER                                     CONTAINER NAME   STATUS      AGE
+test-image-binding         deployments.apps:my-deployment            functions.build.projectriff.io:my-function   user-container   <unknown>   <unknown>
+test-other-image-binding   services.serving.knative.dev:my-service   functions.build.projectriff.io:my-function   user-container   <unknown>   <unknown>
+`,
+		},
+		{
+			Name: "filters everything",
+			Args: []string{cli.SubjectFlagName, "ksvc:my-other-service"},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				addTestDiscoveryResources(discovery)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config) error {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{}
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Name:       "my-function",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingOtherName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "serving.knative.dev/v1",
+							Kind:       "Service",
+							Name:       "my-service",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Name:       "my-function",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-application-image-binding",
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Application",
+							Name:       "my-application",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+			},
+			ExpectOutput: `
+No image bindings found.
+`,
+		},
+		{
+			Name: "filters by unknown resource",
+			Args: []string{cli.SubjectFlagName, "foo"},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				addTestDiscoveryResources(discovery)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config) error {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{}
+				return nil
+			},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Name:       "my-function",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingOtherName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "serving.knative.dev/v1",
+							Kind:       "Service",
+							Name:       "my-service",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Name:       "my-function",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-application-image-binding",
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  "default",
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Application",
+							Name:       "my-application",
+							Namespace:  "default",
+						},
+						ContainerName: "user-container",
+					},
+				},
+			},
+			ShouldError: true,
+		},
 		{
 			Name: "list error",
 			Args: []string{},
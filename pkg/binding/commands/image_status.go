@@ -21,10 +21,16 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	bindingsv1alpha1 "github.com/projectriff/system/pkg/apis/bindings/v1alpha1"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	servingv1 "github.com/projectriff/system/pkg/apis/thirdparty/knative/serving/v1"
 	"github.com/spf13/cobra"
 	"github.com/vmware-labs/reconciler-runtime/apis"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -67,9 +73,110 @@ func (opts *ImageStatusOptions) Exec(ctx context.Context, c *cli.Config) error {
 		},
 	})
 
+	if image.Spec.Provider == nil || image.Spec.Subject == nil {
+		return nil
+	}
+	status := imageBindingImages{}
+	if status.Provider, err = providerImage(c, image.Spec.Provider); err != nil {
+		return err
+	}
+	if status.Subject, err = subjectImage(c, image.Spec.Subject, image.Spec.ContainerName); err != nil {
+		return err
+	}
+	if status.Provider != "" && status.Subject != "" {
+		inSync := status.Provider == status.Subject
+		status.InSync = &inSync
+	}
+	s, err := yaml.Marshal(status)
+	if err != nil {
+		return err
+	}
+	c.Printf("# images\n")
+	c.Printf("---\n")
+	c.Printf("%s", string(s))
+
 	return nil
 }
 
+// imageBindingImages compares the provider's latest image with the image of the subject's
+// container
+type imageBindingImages struct {
+	Provider string `json:"provider,omitempty"`
+	Subject  string `json:"subject,omitempty"`
+	InSync   *bool  `json:"inSync,omitempty"`
+}
+
+// providerImage returns the latest image of a riff build resource. Other providers and missing
+// resources have no image.
+func providerImage(c *cli.Config, ref *bindingsv1alpha1.Reference) (string, error) {
+	if !strings.HasPrefix(ref.APIVersion, buildv1alpha1.GroupVersion.Group+"/") {
+		return "", nil
+	}
+	var image string
+	var err error
+	switch ref.Kind {
+	case "Application":
+		var application *buildv1alpha1.Application
+		if application, err = c.Build().Applications(ref.Namespace).Get(ref.Name, metav1.GetOptions{}); err == nil {
+			image = application.Status.LatestImage
+		}
+	case "Container":
+		var container *buildv1alpha1.Container
+		if container, err = c.Build().Containers(ref.Namespace).Get(ref.Name, metav1.GetOptions{}); err == nil {
+			image = container.Status.LatestImage
+		}
+	case "Function":
+		var function *buildv1alpha1.Function
+		if function, err = c.Build().Functions(ref.Namespace).Get(ref.Name, metav1.GetOptions{}); err == nil {
+			image = function.Status.LatestImage
+		}
+	}
+	if err != nil && !apierrs.IsNotFound(err) {
+		return "", err
+	}
+	return image, nil
+}
+
+// subjectImage returns the image of the named container for Deployments and Knative Services and
+// Configurations. Other subjects, subjects selected by label and missing resources have no image.
+func subjectImage(c *cli.Config, ref *bindingsv1alpha1.Reference, containerName string) (string, error) {
+	if ref.Name == "" {
+		return "", nil
+	}
+	var containers []corev1.Container
+	var err error
+	switch {
+	case ref.APIVersion == appsv1.SchemeGroupVersion.String() && ref.Kind == "Deployment":
+		var deployment *appsv1.Deployment
+		if deployment, err = c.Apps().Deployments(ref.Namespace).Get(ref.Name, metav1.GetOptions{}); err == nil {
+			containers = deployment.Spec.Template.Spec.Containers
+		}
+	case ref.APIVersion == servingv1.GroupVersion.String() && ref.Kind == "Service":
+		var service *servingv1.Service
+		if service, err = c.KnativeServing().Services(ref.Namespace).Get(ref.Name, metav1.GetOptions{}); err == nil {
+			containers = service.Spec.Template.Spec.Containers
+		}
+	case ref.APIVersion == servingv1.GroupVersion.String() && ref.Kind == "Configuration":
+		var configuration *servingv1.Configuration
+		if configuration, err = c.KnativeServing().Configurations(ref.Namespace).Get(ref.Name, metav1.GetOptions{}); err == nil {
+			containers = configuration.Spec.Template.Spec.Containers
+		}
+	}
+	if err != nil && !apierrs.IsNotFound(err) {
+		return "", err
+	}
+	for _, container := range containers {
+		if container.Name == containerName {
+			return container.Image, nil
+		}
+	}
+	if len(containers) == 1 && containers[0].Name == "" {
+		// Knative defaults the name of a single container
+		return containers[0].Image, nil
+	}
+	return "", nil
+}
+
 func NewImageStatusCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	opts := &ImageStatusOptions{}
 
@@ -83,6 +190,9 @@ The Ready condition is shown which should include a reason code and a
 descriptive message when the status is not "True". The status for the condition
 may be: "True", "False" or "Unknown". An "Unknown" status is common while the
 image roll out is processed.
+
+The latest image from a riff build provider is compared with the image of the
+subject's container for Deployments and Knative Services and Configurations.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s binding image status my-imagebinding", c.Name),
@@ -94,6 +204,7 @@ image roll out is processed.
 	cli.Args(cmd,
 		cli.NameArg(&opts.Name),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListImageBindings)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 
@@ -23,7 +23,10 @@ import (
 	"github.com/projectriff/cli/pkg/binding/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	bindingsv1alpha1 "github.com/projectriff/system/pkg/apis/bindings/v1alpha1"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	servingv1 "github.com/projectriff/system/pkg/apis/thirdparty/knative/serving/v1"
 	"github.com/vmware-labs/reconciler-runtime/apis"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -98,6 +101,394 @@ status: "False"
 type: Ready
 `,
 		},
+		{
+			Name: "show images in sync",
+			Args: []string{imageBindingName},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  defaultNamespace,
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Namespace:  defaultNamespace,
+							Name:       "my-function",
+						},
+						ContainerName: "user-container",
+					},
+					Status: bindingsv1alpha1.ImageBindingStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-function",
+						Namespace: defaultNamespace,
+					},
+					Status: buildv1alpha1.FunctionStatus{
+						BuildStatus: buildv1alpha1.BuildStatus{
+							LatestImage: "example.com/my-function@sha256:abc",
+						},
+					},
+				},
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-deployment",
+						Namespace: defaultNamespace,
+					},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{Name: "sidecar", Image: "example.com/sidecar"},
+									{Name: "user-container", Image: "example.com/my-function@sha256:abc"},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+# my-image-binding: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+# images
+---
+inSync: true
+provider: example.com/my-function@sha256:abc
+subject: example.com/my-function@sha256:abc
+`,
+		},
+		{
+			Name: "show images out of sync",
+			Args: []string{imageBindingName},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "serving.knative.dev/v1",
+							Kind:       "Service",
+							Name:       "my-service",
+							Namespace:  defaultNamespace,
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Namespace:  defaultNamespace,
+							Name:       "my-function",
+						},
+						ContainerName: "user-container",
+					},
+					Status: bindingsv1alpha1.ImageBindingStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-function",
+						Namespace: defaultNamespace,
+					},
+					Status: buildv1alpha1.FunctionStatus{
+						BuildStatus: buildv1alpha1.BuildStatus{
+							LatestImage: "example.com/my-function@sha256:abc",
+						},
+					},
+				},
+				&servingv1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-service",
+						Namespace: defaultNamespace,
+					},
+					Spec: servingv1.ServiceSpec{
+						ConfigurationSpec: servingv1.ConfigurationSpec{
+							Template: servingv1.RevisionTemplateSpec{
+								Spec: servingv1.RevisionSpec{
+									PodSpec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{Image: "example.com/my-function@sha256:def"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+# my-image-binding: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+# images
+---
+inSync: false
+provider: example.com/my-function@sha256:abc
+subject: example.com/my-function@sha256:def
+`,
+		},
+		{
+			Name: "show images, missing subject",
+			Args: []string{imageBindingName},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  defaultNamespace,
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Namespace:  defaultNamespace,
+							Name:       "my-function",
+						},
+						ContainerName: "user-container",
+					},
+					Status: bindingsv1alpha1.ImageBindingStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-function",
+						Namespace: defaultNamespace,
+					},
+					Status: buildv1alpha1.FunctionStatus{
+						BuildStatus: buildv1alpha1.BuildStatus{
+							LatestImage: "example.com/my-function@sha256:abc",
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+# my-image-binding: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+# images
+---
+provider: example.com/my-function@sha256:abc
+`,
+		},
+		{
+			Name: "get provider error",
+			Args: []string{imageBindingName},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  defaultNamespace,
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Namespace:  defaultNamespace,
+							Name:       "my-function",
+						},
+						ContainerName: "user-container",
+					},
+					Status: bindingsv1alpha1.ImageBindingStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-function",
+						Namespace: defaultNamespace,
+					},
+					Status: buildv1alpha1.FunctionStatus{
+						BuildStatus: buildv1alpha1.BuildStatus{
+							LatestImage: "example.com/my-function@sha256:abc",
+						},
+					},
+				},
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-deployment",
+						Namespace: defaultNamespace,
+					},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{Name: "sidecar", Image: "example.com/sidecar"},
+									{Name: "user-container", Image: "example.com/my-function@sha256:abc"},
+								},
+							},
+						},
+					},
+				},
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "functions"),
+			},
+			ExpectOutput: `
+# my-image-binding: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+`,
+			ShouldError: true,
+		},
+		{
+			Name: "get subject error",
+			Args: []string{imageBindingName},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ImageBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      imageBindingName,
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ImageBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "my-deployment",
+							Namespace:  defaultNamespace,
+						},
+						Provider: &bindingsv1alpha1.Reference{
+							APIVersion: "build.projectriff.io/v1alpha1",
+							Kind:       "Function",
+							Namespace:  defaultNamespace,
+							Name:       "my-function",
+						},
+						ContainerName: "user-container",
+					},
+					Status: bindingsv1alpha1.ImageBindingStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-function",
+						Namespace: defaultNamespace,
+					},
+					Status: buildv1alpha1.FunctionStatus{
+						BuildStatus: buildv1alpha1.BuildStatus{
+							LatestImage: "example.com/my-function@sha256:abc",
+						},
+					},
+				},
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-deployment",
+						Namespace: defaultNamespace,
+					},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{Name: "sidecar", Image: "example.com/sidecar"},
+									{Name: "user-container", Image: "example.com/my-function@sha256:abc"},
+								},
+							},
+						},
+					},
+				},
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "deployments"),
+			},
+			ExpectOutput: `
+# my-image-binding: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+`,
+			ShouldError: true,
+		},
 		{
 			Name: "not found",
 			Args: []string{imageBindingName},
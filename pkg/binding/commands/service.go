@@ -0,0 +1,47 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewServiceCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "service",
+		Short: "bind service credentials into workloads",
+		Long: strings.TrimSpace(`
+Service bindings inject the connection details for a backing service, like a
+database or message broker, into a workload.
+
+Connection details are read from a secret in the binding's namespace. Each
+entry of the secret is projected into the subject's containers.
+`),
+		Aliases: []string{"services"},
+	}
+
+	cmd.AddCommand(NewServiceListCommand(ctx, c))
+	cmd.AddCommand(NewServiceCreateCommand(ctx, c))
+	cmd.AddCommand(NewServiceDeleteCommand(ctx, c))
+	cmd.AddCommand(NewServiceStatusCommand(ctx, c))
+
+	return cmd
+}
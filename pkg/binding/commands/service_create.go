@@ -0,0 +1,175 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/validation"
+	bindingsv1alpha1 "github.com/projectriff/system/pkg/apis/bindings/v1alpha1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ServiceCreateOptions struct {
+	options.ResourceOptions
+
+	Subject       string
+	Secret        string
+	Metadata      string
+	ContainerName string
+	BindingMode   string
+
+	DryRun bool
+}
+
+var (
+	_ cli.Validatable = (*ServiceCreateOptions)(nil)
+	_ cli.Executable  = (*ServiceCreateOptions)(nil)
+	_ cli.DryRunable  = (*ServiceCreateOptions)(nil)
+)
+
+func (opts *ServiceCreateOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+
+	if opts.Subject == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.SubjectFlagName))
+	} else {
+		errs = errs.Also(validateObjectRef(opts.Subject, cli.SubjectFlagName))
+	}
+
+	if opts.Secret == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.SecretFlagName))
+	} else {
+		errs = errs.Also(validation.K8sName(opts.Secret, cli.SecretFlagName))
+	}
+
+	if opts.Metadata != "" {
+		errs = errs.Also(validation.K8sName(opts.Metadata, cli.MetadataFlagName))
+	}
+
+	switch bindingsv1alpha1.ServiceBindingMode(opts.BindingMode) {
+	case bindingsv1alpha1.SecretServiceBinding:
+	case bindingsv1alpha1.MetadataServiceBinding:
+		// the metadata mode only projects the metadata, which must be provided
+		if opts.Metadata == "" {
+			errs = errs.Also(cli.ErrMissingField(cli.MetadataFlagName))
+		}
+	default:
+		errs = errs.Also(cli.ErrInvalidValue(opts.BindingMode, cli.BindingModeFlagName))
+	}
+
+	return errs
+}
+
+func (opts *ServiceCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
+	resources, err := c.Discovery().ServerResources()
+	if err != nil {
+		return err
+	}
+
+	subject, err := resolveObjectRef(resources, opts.Subject, opts.Namespace)
+	if err != nil {
+		return err
+	}
+
+	binding := &bindingsv1alpha1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: opts.Namespace,
+			Name:      opts.Name,
+		},
+		Spec: bindingsv1alpha1.ServiceBindingSpec{
+			Subject: subject,
+			Providers: []bindingsv1alpha1.ServiceCredentialProvider{
+				{
+					Name:          opts.Name,
+					ContainerName: opts.ContainerName,
+					BindingMode:   bindingsv1alpha1.ServiceBindingMode(opts.BindingMode),
+					Ref: bindingsv1alpha1.ServiceCredentialReference{
+						Metadata: corev1.LocalObjectReference{Name: opts.Metadata},
+						Secret:   corev1.LocalObjectReference{Name: opts.Secret},
+					},
+				},
+			},
+		},
+	}
+
+	if opts.DryRun {
+		cli.DryRunResource(ctx, binding, bindingsv1alpha1.GroupVersion.WithKind("ServiceBinding"))
+	} else {
+		binding, err = c.Bindings().ServiceBindings(opts.Namespace).Create(binding)
+		if err != nil {
+			return err
+		}
+	}
+	c.Successf("Created service binding %q\n", binding.Name)
+	return nil
+}
+
+func (opts *ServiceCreateOptions) IsDryRun() bool {
+	return opts.DryRun
+}
+
+func NewServiceCreateCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &ServiceCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "bind service credentials into a workload",
+		Long: strings.TrimSpace(`
+Create a service binding.
+
+The connection details for the service are read from the secret named by
+` + cli.SecretFlagName + `, which must be in the binding's namespace. Additional metadata
+describing the service may be provided by a config map with ` + cli.MetadataFlagName + `.
+
+The subject is an object reference in the form "<resource>:<name>", like
+"deployment:my-deployment". The resource is resolved from the server's API
+resources and may be qualified by its group.
+
+A binding mode of "Metadata" projects only the metadata, and requires
+` + cli.MetadataFlagName + `.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s binding service create my-service-binding %s deployment:my-deployment %s my-database-credentials", c.Name, cli.SubjectFlagName, cli.SecretFlagName),
+			fmt.Sprintf("%s binding service create my-service-binding %s deployment:my-deployment %s my-database-credentials %s my-database-metadata", c.Name, cli.SubjectFlagName, cli.SecretFlagName, cli.MetadataFlagName),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.NameArg(&opts.Name),
+	)
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cmd.Flags().StringVar(&opts.Subject, cli.StripDash(cli.SubjectFlagName), "", "subject `object reference` to bind the service into, in the form <resource>:[<namespace>/]<name>")
+	cmd.Flags().StringVar(&opts.Secret, cli.StripDash(cli.SecretFlagName), "", "`name` of the secret holding the service's connection details")
+	cmd.Flags().StringVar(&opts.Metadata, cli.StripDash(cli.MetadataFlagName), "", "`name` of the config map holding metadata describing the service")
+	cmd.Flags().StringVar(&opts.ContainerName, cli.StripDash(cli.ContainerNameFlagName), "", "`container` in the subject to bind into, defaults to all containers")
+	cmd.Flags().StringVar(&opts.BindingMode, cli.StripDash(cli.BindingModeFlagName), string(bindingsv1alpha1.SecretServiceBinding), "`mode` for the binding, one of \"Secret\" or \"Metadata\", which requires "+cli.MetadataFlagName)
+	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+
+	return cmd
+}
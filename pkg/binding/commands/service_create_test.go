@@ -0,0 +1,277 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/binding/commands"
+	"github.com/projectriff/cli/pkg/cli"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	bindingsv1alpha1 "github.com/projectriff/system/pkg/apis/bindings/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+)
+
+func TestServiceBindingCreateOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "empty resource",
+			Options: &commands.ServiceCreateOptions{
+				ResourceOptions: rifftesting.InvalidResourceOptions,
+			},
+			ExpectFieldErrors: rifftesting.InvalidResourceOptionsFieldError.Also(
+				cli.ErrMissingField(cli.SubjectFlagName),
+				cli.ErrMissingField(cli.SecretFlagName),
+				cli.ErrInvalidValue("", cli.BindingModeFlagName),
+			),
+		},
+		{
+			Name: "valid secret",
+			Options: &commands.ServiceCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Subject:         "deployment:my-deployment",
+				Secret:          "my-secret",
+				BindingMode:     "Secret",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "valid metadata",
+			Options: &commands.ServiceCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Subject:         "deployment:my-deployment",
+				Secret:          "my-secret",
+				Metadata:        "my-metadata",
+				ContainerName:   "user-container",
+				BindingMode:     "Metadata",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid subject",
+			Options: &commands.ServiceCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Subject:         "foo",
+				Secret:          "my-secret",
+				BindingMode:     "Secret",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("foo", cli.SubjectFlagName),
+		},
+		{
+			Name: "metadata mode without metadata",
+			Options: &commands.ServiceCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Subject:         "deployment:my-deployment",
+				Secret:          "my-secret",
+				BindingMode:     "Metadata",
+			},
+			ExpectFieldErrors: cli.ErrMissingField(cli.MetadataFlagName),
+		},
+		{
+			Name: "invalid names",
+			Options: &commands.ServiceCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Subject:         "deployment:my-deployment",
+				Secret:          "my.secret",
+				Metadata:        "my.metadata",
+				BindingMode:     "Secret",
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidValue("my.secret", cli.SecretFlagName),
+				cli.ErrInvalidValue("my.metadata", cli.MetadataFlagName),
+			),
+		},
+		{
+			Name: "invalid binding mode",
+			Options: &commands.ServiceCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Subject:         "deployment:my-deployment",
+				Secret:          "my-secret",
+				BindingMode:     "Everything",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("Everything", cli.BindingModeFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestServiceBindingCreateCommand(t *testing.T) {
+	defaultNamespace := "default"
+	serviceBindingName := "my-service-binding"
+	secretName := "my-secret"
+
+	prepareDiscovery := func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+		discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+		addTestDiscoveryResources(discovery)
+		return ctx, nil
+	}
+	cleanUpDiscovery := func(t *testing.T, ctx context.Context, config *cli.Config) error {
+		discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+		discovery.Resources = []*metav1.APIResourceList{}
+		return nil
+	}
+	subject := &bindingsv1alpha1.Reference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Namespace:  defaultNamespace,
+		Name:       "my-deployment",
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name:    "create from secret",
+			Args:    []string{serviceBindingName, cli.SubjectFlagName, "deployment:my-deployment", cli.SecretFlagName, secretName},
+			Prepare: prepareDiscovery,
+			CleanUp: cleanUpDiscovery,
+			ExpectCreates: []runtime.Object{
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      serviceBindingName,
+					},
+					Spec: bindingsv1alpha1.ServiceBindingSpec{
+						Subject: subject,
+						Providers: []bindingsv1alpha1.ServiceCredentialProvider{
+							{
+								Name:        serviceBindingName,
+								BindingMode: bindingsv1alpha1.SecretServiceBinding,
+								Ref: bindingsv1alpha1.ServiceCredentialReference{
+									Secret: corev1.LocalObjectReference{Name: secretName},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created service binding "my-service-binding"
+`,
+		},
+		{
+			Name: "create with metadata",
+			Args: []string{serviceBindingName, cli.SubjectFlagName, "deployment:my-deployment", cli.SecretFlagName, secretName,
+				cli.MetadataFlagName, "my-metadata", cli.ContainerNameFlagName, "user-container", cli.BindingModeFlagName, "Metadata"},
+			Prepare: prepareDiscovery,
+			CleanUp: cleanUpDiscovery,
+			ExpectCreates: []runtime.Object{
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      serviceBindingName,
+					},
+					Spec: bindingsv1alpha1.ServiceBindingSpec{
+						Subject: subject,
+						Providers: []bindingsv1alpha1.ServiceCredentialProvider{
+							{
+								Name:          serviceBindingName,
+								ContainerName: "user-container",
+								BindingMode:   bindingsv1alpha1.MetadataServiceBinding,
+								Ref: bindingsv1alpha1.ServiceCredentialReference{
+									Metadata: corev1.LocalObjectReference{Name: "my-metadata"},
+									Secret:   corev1.LocalObjectReference{Name: secretName},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created service binding "my-service-binding"
+`,
+		},
+		{
+			Name:        "unknown subject",
+			Args:        []string{serviceBindingName, cli.SubjectFlagName, "foo:my-foo", cli.SecretFlagName, secretName},
+			Prepare:     prepareDiscovery,
+			CleanUp:     cleanUpDiscovery,
+			ShouldError: true,
+		},
+		{
+			Name:    "create, dry run",
+			Args:    []string{serviceBindingName, cli.SubjectFlagName, "deployment:my-deployment", cli.SecretFlagName, secretName, cli.DryRunFlagName},
+			Prepare: prepareDiscovery,
+			CleanUp: cleanUpDiscovery,
+			ExpectOutput: `
+---
+apiVersion: bindings.projectriff.io/v1alpha1
+kind: ServiceBinding
+metadata:
+  creationTimestamp: null
+  name: my-service-binding
+  namespace: default
+spec:
+  providers:
+  - bindingMode: Secret
+    name: my-service-binding
+    ref:
+      metadata: {}
+      secret:
+        name: my-secret
+  subject:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: my-deployment
+    namespace: default
+status: {}
+
+Created service binding "my-service-binding"
+`,
+		},
+		{
+			Name:    "error creating",
+			Args:    []string{serviceBindingName, cli.SubjectFlagName, "deployment:my-deployment", cli.SecretFlagName, secretName},
+			Prepare: prepareDiscovery,
+			CleanUp: cleanUpDiscovery,
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("create", "servicebindings"),
+			},
+			ExpectCreates: []runtime.Object{
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      serviceBindingName,
+					},
+					Spec: bindingsv1alpha1.ServiceBindingSpec{
+						Subject: subject,
+						Providers: []bindingsv1alpha1.ServiceCredentialProvider{
+							{
+								Name:        serviceBindingName,
+								BindingMode: bindingsv1alpha1.SecretServiceBinding,
+								Ref: bindingsv1alpha1.ServiceCredentialReference{
+									Secret: corev1.LocalObjectReference{Name: secretName},
+								},
+							},
+						},
+					},
+				},
+			},
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewServiceCreateCommand)
+}
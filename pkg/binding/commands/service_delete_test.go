@@ -0,0 +1,193 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/projectriff/cli/pkg/binding/commands"
+	"github.com/projectriff/cli/pkg/cli"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	bindingsv1alpha1 "github.com/projectriff/system/pkg/apis/bindings/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestServiceBindingDeleteOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "invalid delete",
+			Options: &commands.ServiceDeleteOptions{
+				DeleteOptions: rifftesting.InvalidDeleteOptions,
+			},
+			ExpectFieldErrors: rifftesting.InvalidDeleteOptionsFieldError,
+		},
+		{
+			Name: "valid delete",
+			Options: &commands.ServiceDeleteOptions{
+				DeleteOptions: rifftesting.ValidDeleteOptions,
+			},
+			ShouldValidate: true,
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestServiceBindingDeleteCommand(t *testing.T) {
+	serviceBindingName := "test-service-binding"
+	serviceBindingOtherName := "test-other-service-binding"
+	defaultNamespace := "default"
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name: "delete all service bindings",
+			Args: []string{cli.AllFlagName},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceBindingName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectDeleteCollections: []rifftesting.DeleteCollectionRef{{
+				Group:     "bindings.projectriff.io",
+				Resource:  "servicebindings",
+				Namespace: defaultNamespace,
+			}},
+			ExpectOutput: `
+Deleted service bindings in namespace "default"
+`,
+		},
+		{
+			Name: "delete all service bindings error",
+			Args: []string{cli.AllFlagName},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceBindingName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("delete-collection", "servicebindings"),
+			},
+			ExpectDeleteCollections: []rifftesting.DeleteCollectionRef{{
+				Group:     "bindings.projectriff.io",
+				Resource:  "servicebindings",
+				Namespace: defaultNamespace,
+			}},
+			ShouldError: true,
+		},
+		{
+			Name: "delete service bindings",
+			Args: []string{serviceBindingName},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceBindingName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectDeletes: []rifftesting.DeleteRef{{
+				Group:     "bindings.projectriff.io",
+				Resource:  "servicebindings",
+				Namespace: defaultNamespace,
+				Name:      serviceBindingName,
+			}},
+			ExpectOutput: `
+Deleted service binding "test-service-binding"
+`,
+		},
+		{
+			Name: "delete service bindings",
+			Args: []string{serviceBindingName, serviceBindingOtherName},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceBindingName,
+						Namespace: defaultNamespace,
+					},
+				},
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceBindingOtherName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectDeletes: []rifftesting.DeleteRef{{
+				Group:     "bindings.projectriff.io",
+				Resource:  "servicebindings",
+				Namespace: defaultNamespace,
+				Name:      serviceBindingName,
+			}, {
+				Group:     "bindings.projectriff.io",
+				Resource:  "servicebindings",
+				Namespace: defaultNamespace,
+				Name:      serviceBindingOtherName,
+			}},
+			ExpectOutput: `
+Deleted service binding "test-service-binding"
+Deleted service binding "test-other-service-binding"
+`,
+		},
+		{
+			Name: "service binding does not exist",
+			Args: []string{serviceBindingName},
+			ExpectDeletes: []rifftesting.DeleteRef{{
+				Group:     "bindings.projectriff.io",
+				Resource:  "servicebindings",
+				Namespace: defaultNamespace,
+				Name:      serviceBindingName,
+			}},
+			ShouldError: true,
+		},
+		{
+			Name: "delete error",
+			Args: []string{serviceBindingName},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceBindingName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("delete", "servicebindings"),
+			},
+			ExpectDeletes: []rifftesting.DeleteRef{{
+				Group:     "bindings.projectriff.io",
+				Resource:  "servicebindings",
+				Namespace: defaultNamespace,
+				Name:      serviceBindingName,
+			}},
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewServiceDeleteCommand)
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	bindingsv1alpha1 "github.com/projectriff/system/pkg/apis/bindings/v1alpha1"
+	"github.com/spf13/cobra"
+	"github.com/vmware-labs/reconciler-runtime/apis"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type ServiceListOptions struct {
+	options.ListOptions
+
+	Subject string
+	Secret  string
+}
+
+var (
+	_ cli.Validatable = (*ServiceListOptions)(nil)
+	_ cli.Executable  = (*ServiceListOptions)(nil)
+)
+
+func (opts *ServiceListOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(opts.ListOptions.Validate(ctx))
+
+	if opts.Subject != "" {
+		errs = errs.Also(validateObjectRefFilter(opts.Subject, cli.SubjectFlagName))
+	}
+
+	return errs
+}
+
+func (opts *ServiceListOptions) Exec(ctx context.Context, c *cli.Config) error {
+	bindings, err := c.Bindings().ServiceBindings(opts.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	if opts.Subject != "" || opts.Secret != "" {
+		matchesSubject := func(ref *bindingsv1alpha1.Reference) bool { return true }
+		if opts.Subject != "" {
+			resources, err := c.Discovery().ServerResources()
+			if err != nil {
+				return err
+			}
+			matchesSubject, err = objectRefMatcher(resources, opts.Subject, opts.Namespace)
+			if err != nil {
+				return err
+			}
+		}
+		items := []bindingsv1alpha1.ServiceBinding{}
+		for i := range bindings.Items {
+			matches := matchesSubject(bindings.Items[i].Spec.Subject)
+			if opts.Secret != "" {
+				matchesSecret := false
+				for _, secret := range serviceBindingSecrets(&bindings.Items[i]) {
+					matchesSecret = matchesSecret || secret == opts.Secret
+				}
+				matches = matches && matchesSecret
+			}
+			if matches {
+				items = append(items, bindings.Items[i])
+			}
+		}
+		bindings.Items = items
+	}
+
+	if len(bindings.Items) == 0 {
+		c.Infof("No service bindings found.\n")
+		return nil
+	}
+
+	tablePrinter := printers.NewTablePrinter(printers.PrintOptions{
+		WithNamespace: opts.AllNamespaces,
+	}).With(func(h printers.PrintHandler) {
+		columns := opts.printColumns()
+		h.TableHandler(columns, opts.printList)
+		h.TableHandler(columns, opts.print)
+	})
+
+	bindings = bindings.DeepCopy()
+	cli.SortByNamespaceAndName(bindings.Items)
+
+	return tablePrinter.PrintObj(bindings, c.Stdout)
+}
+
+// serviceBindingSecrets returns the names of the secrets bound by each provider
+func serviceBindingSecrets(binding *bindingsv1alpha1.ServiceBinding) []string {
+	secrets := []string{}
+	for _, provider := range binding.Spec.Providers {
+		if provider.Ref.Secret.Name != "" {
+			secrets = append(secrets, provider.Ref.Secret.Name)
+		}
+	}
+	return secrets
+}
+
+func NewServiceListCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &ServiceListOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "table listing of service bindings",
+		Long: strings.TrimSpace(`
+List service bindings in a namespace or across all namespaces.
+
+Service bindings are filtered by subject with ` + cli.SubjectFlagName + `, a resource like
+"deployments.apps" optionally followed by the name of an object, or by the name
+of the bound secret with ` + cli.SecretFlagName + `.
+
+For detail regarding the status of a single service binding, run:
+
+    ` + c.Name + ` binding service status <service-binding-name>
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s binding service list", c.Name),
+			fmt.Sprintf("%s binding service list %s", c.Name, cli.AllNamespacesFlagName),
+			fmt.Sprintf("%s binding service list %s deployment:my-deployment", c.Name, cli.SubjectFlagName),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.AllNamespacesFlag(cmd, c, &opts.Namespace, &opts.AllNamespaces)
+	cmd.Flags().StringVar(&opts.Subject, cli.StripDash(cli.SubjectFlagName), "", "only list bindings for subjects matching the `filter`, in the form <resource>[:[<namespace>/]<name>]")
+	cmd.Flags().StringVar(&opts.Secret, cli.StripDash(cli.SecretFlagName), "", "only list bindings for the secret `name`")
+
+	return cmd
+}
+
+func (opts *ServiceListOptions) printList(bindings *bindingsv1alpha1.ServiceBindingList, printOpts printers.PrintOptions) ([]metav1beta1.TableRow, error) {
+	rows := make([]metav1beta1.TableRow, 0, len(bindings.Items))
+	for i := range bindings.Items {
+		r, err := opts.print(&bindings.Items[i], printOpts)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, r...)
+	}
+	return rows, nil
+}
+
+func (opts *ServiceListOptions) print(binding *bindingsv1alpha1.ServiceBinding, _ printers.PrintOptions) ([]metav1beta1.TableRow, error) {
+	now := time.Now()
+	row := metav1beta1.TableRow{
+		Object: runtime.RawExtension{Object: binding},
+	}
+	condition := binding.Status.GetCondition(apis.ConditionReady)
+	if condition == nil {
+		condition = &apis.Condition{}
+	}
+	row.Cells = append(row.Cells,
+		binding.Name,
+		cli.FormatEmptyString(formatObjectRef(binding.Spec.Subject)),
+		cli.FormatEmptyString(strings.Join(serviceBindingSecrets(binding), ",")),
+		cli.FormatConditionStatus(&apis.Condition{
+			Type:   apis.ConditionReady,
+			Reason: condition.Reason,
+			Status: condition.Status,
+		}),
+		cli.FormatTimestampSince(binding.CreationTimestamp, now),
+	)
+	return []metav1beta1.TableRow{row}, nil
+}
+
+func (opts *ServiceListOptions) printColumns() []metav1beta1.TableColumnDefinition {
+	return []metav1beta1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Subject", Type: "string"},
+		{Name: "Secret", Type: "string"},
+		{Name: "Status", Type: "string"},
+		{Name: "Age", Type: "string"},
+	}
+}
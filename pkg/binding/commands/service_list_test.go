@@ -0,0 +1,277 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/binding/commands"
+	"github.com/projectriff/cli/pkg/cli"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	bindingsv1alpha1 "github.com/projectriff/system/pkg/apis/bindings/v1alpha1"
+	"github.com/vmware-labs/reconciler-runtime/apis"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+)
+
+func TestServiceBindingListOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "invalid list",
+			Options: &commands.ServiceListOptions{
+				ListOptions: rifftesting.InvalidListOptions,
+			},
+			ExpectFieldErrors: rifftesting.InvalidListOptionsFieldError,
+		},
+		{
+			Name: "valid list",
+			Options: &commands.ServiceListOptions{
+				ListOptions: rifftesting.ValidListOptions,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "valid filters",
+			Options: &commands.ServiceListOptions{
+				ListOptions: rifftesting.ValidListOptions,
+				Subject:     "deployment:my-deployment",
+				Secret:      "my-secret",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid subject filter",
+			Options: &commands.ServiceListOptions{
+				ListOptions: rifftesting.ValidListOptions,
+				Subject:     "deployment:My_Deployment",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("deployment:My_Deployment", cli.SubjectFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestServiceBindingListCommand(t *testing.T) {
+	serviceBindingName := "test-service-binding"
+	serviceBindingOtherName := "test-other-service-binding"
+	defaultNamespace := "default"
+	otherNamespace := "other-namespace"
+
+	serviceBinding := func(name, deployment, secret string) *bindingsv1alpha1.ServiceBinding {
+		return &bindingsv1alpha1.ServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: defaultNamespace,
+			},
+			Spec: bindingsv1alpha1.ServiceBindingSpec{
+				Subject: &bindingsv1alpha1.Reference{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Namespace:  defaultNamespace,
+					Name:       deployment,
+				},
+				Providers: []bindingsv1alpha1.ServiceCredentialProvider{
+					{
+						Name: name,
+						Ref: bindingsv1alpha1.ServiceCredentialReference{
+							Secret: corev1.LocalObjectReference{Name: secret},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name: "invalid args",
+			Args: []string{},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				// disable default namespace
+				c.Client.(*rifftesting.FakeClient).Namespace = ""
+				return ctx, nil
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "empty",
+			Args: []string{},
+			ExpectOutput: `
+No service bindings found.
+`,
+		},
+		{
+			Name: "lists an item",
+			Args: []string{},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceBindingName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+NAME                   SUBJECT   SECRET    STATUS      AGE
+test-service-binding   <empty>   <empty>   <unknown>   <unknown>
+`,
+		},
+		{
+			Name: "filters by namespace",
+			Args: []string{cli.NamespaceFlagName, otherNamespace},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceBindingName,
+						Namespace: defaultNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+No service bindings found.
+`,
+		},
+		{
+			Name: "all namespace",
+			Args: []string{cli.AllNamespacesFlagName},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceBindingName,
+						Namespace: defaultNamespace,
+					},
+				},
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceBindingOtherName,
+						Namespace: otherNamespace,
+					},
+				},
+			},
+			ExpectOutput: `
+NAMESPACE         NAME                         SUBJECT   SECRET    STATUS      AGE
+default           test-service-binding         <empty>   <empty>   <unknown>   <unknown>
+other-namespace   test-other-service-binding   <empty>   <empty>   <unknown>   <unknown>
+`,
+		},
+		{
+			Name: "table populates all columns",
+			Args: []string{},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-binding",
+						Namespace: defaultNamespace,
+					},
+					Spec: bindingsv1alpha1.ServiceBindingSpec{
+						Subject: &bindingsv1alpha1.Reference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Namespace:  defaultNamespace,
+							Name:       "my-deployment",
+						},
+						Providers: []bindingsv1alpha1.ServiceCredentialProvider{
+							{
+								Name: "my-binding",
+								Ref: bindingsv1alpha1.ServiceCredentialReference{
+									Secret: corev1.LocalObjectReference{Name: "my-secret"},
+								},
+							},
+						},
+					},
+					Status: bindingsv1alpha1.ServiceBindingStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{Type: apis.ConditionReady, Status: "True"},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+NAME         SUBJECT                          SECRET      STATUS   AGE
+my-binding   deployments.apps:my-deployment   my-secret   Ready    <unknown>
+`,
+		},
+		{
+			Name: "filters by subject",
+			Args: []string{cli.SubjectFlagName, "deployment:my-deployment"},
+			GivenObjects: []runtime.Object{
+				serviceBinding(serviceBindingName, "my-deployment", "my-secret"),
+				serviceBinding(serviceBindingOtherName, "my-other-deployment", "my-secret"),
+			},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				addTestDiscoveryResources(discovery)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config) error {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{}
+				return nil
+			},
+			ExpectOutput: `
+NAME                   SUBJECT                          SECRET      STATUS      AGE
+test-service-binding   deployments.apps:my-deployment   my-secret   <unknown>   <unknown>
+`,
+		},
+		{
+			Name: "filters by secret",
+			Args: []string{cli.SecretFlagName, "my-other-secret"},
+			GivenObjects: []runtime.Object{
+				serviceBinding(serviceBindingName, "my-deployment", "my-secret"),
+				serviceBinding(serviceBindingOtherName, "my-deployment", "my-other-secret"),
+			},
+			ExpectOutput: `
+NAME                         SUBJECT                          SECRET            STATUS      AGE
+test-other-service-binding   deployments.apps:my-deployment   my-other-secret   <unknown>   <unknown>
+`,
+		},
+		{
+			Name: "unknown subject resource",
+			Args: []string{cli.SubjectFlagName, "foo"},
+			GivenObjects: []runtime.Object{
+				serviceBinding(serviceBindingName, "my-deployment", "my-secret"),
+			},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config) (context.Context, error) {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				addTestDiscoveryResources(discovery)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config) error {
+				discovery := config.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{}
+				return nil
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "list error",
+			Args: []string{},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("list", "servicebindings"),
+			},
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewServiceListCommand)
+}
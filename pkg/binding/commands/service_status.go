@@ -0,0 +1,162 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/spf13/cobra"
+	"github.com/vmware-labs/reconciler-runtime/apis"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ServiceStatusOptions struct {
+	options.ResourceOptions
+}
+
+var (
+	_ cli.Validatable = (*ServiceStatusOptions)(nil)
+	_ cli.Executable  = (*ServiceStatusOptions)(nil)
+)
+
+func (opts *ServiceStatusOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+
+	return errs
+}
+
+func (opts *ServiceStatusOptions) Exec(ctx context.Context, c *cli.Config) error {
+	binding, err := c.Bindings().ServiceBindings(opts.Namespace).Get(opts.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			return err
+		}
+		c.Errorf("Service binding %q not found\n", fmt.Sprintf("%s/%s", opts.Namespace, opts.Name))
+		return cli.SilenceError(err)
+	}
+
+	ready := binding.Status.GetCondition(apis.ConditionReady)
+	if ready == nil {
+		ready = &apis.Condition{}
+	}
+	cli.PrintResourceStatus(c, binding.Name, &apis.Condition{
+		Type:    apis.ConditionReady,
+		Message: ready.Message,
+		Reason:  ready.Reason,
+		Status:  ready.Status,
+		LastTransitionTime: apis.VolatileTime{
+			Inner: ready.LastTransitionTime.Inner,
+		},
+	})
+
+	status := serviceBindingService{
+		Providers: []serviceBindingProvider{},
+	}
+	for _, provider := range binding.Spec.Providers {
+		p := serviceBindingProvider{
+			Name:      provider.Name,
+			Container: provider.ContainerName,
+			Mode:      string(provider.BindingMode),
+			Metadata:  provider.Ref.Metadata.Name,
+			Secret:    provider.Ref.Secret.Name,
+		}
+		if p.Secret != "" {
+			secret, err := c.Core().Secrets(binding.Namespace).Get(p.Secret, metav1.GetOptions{})
+			if err != nil {
+				if !apierrs.IsNotFound(err) {
+					return err
+				}
+				found := false
+				p.SecretFound = &found
+			} else {
+				for key := range secret.Data {
+					p.Keys = append(p.Keys, key)
+				}
+				sort.Strings(p.Keys)
+			}
+		}
+		status.Providers = append(status.Providers, p)
+	}
+	s, err := yaml.Marshal(status)
+	if err != nil {
+		return err
+	}
+	c.Printf("# service\n")
+	c.Printf("---\n")
+	c.Printf("%s", string(s))
+
+	return nil
+}
+
+// serviceBindingService describes how a binding's connection details are projected
+type serviceBindingService struct {
+	Providers []serviceBindingProvider `json:"providers"`
+}
+
+// serviceBindingProvider lists the secret entries projected for a provider
+type serviceBindingProvider struct {
+	Name        string   `json:"name"`
+	Container   string   `json:"container,omitempty"`
+	Mode        string   `json:"mode,omitempty"`
+	Metadata    string   `json:"metadata,omitempty"`
+	Secret      string   `json:"secret,omitempty"`
+	SecretFound *bool    `json:"secretFound,omitempty"`
+	Keys        []string `json:"keys,omitempty"`
+}
+
+func NewServiceStatusCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &ServiceStatusOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "show service binding status",
+		Long: strings.TrimSpace(`
+Display status details for a service binding.
+
+The Ready condition is shown which should include a reason code and a
+descriptive message when the status is not "True". The status for the condition
+may be: "True", "False" or "Unknown". An "Unknown" status is common while the
+binding is applied to the subject.
+
+The entries of each bound secret are listed, as projected into the subject. A
+missing secret is reported with "secretFound: false".
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s binding service status my-service-binding", c.Name),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.NameArg(&opts.Name),
+	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListServiceBindings)
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+
+	return cmd
+}
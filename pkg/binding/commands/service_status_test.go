@@ -0,0 +1,241 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/projectriff/cli/pkg/binding/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	bindingsv1alpha1 "github.com/projectriff/system/pkg/apis/bindings/v1alpha1"
+	"github.com/vmware-labs/reconciler-runtime/apis"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestServiceBindingStatusOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "invalid resource",
+			Options: &commands.ServiceStatusOptions{
+				ResourceOptions: rifftesting.InvalidResourceOptions,
+			},
+			ExpectFieldErrors: rifftesting.InvalidResourceOptionsFieldError,
+		},
+		{
+			Name: "valid resource",
+			Options: &commands.ServiceStatusOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+			},
+			ShouldValidate: true,
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestServiceBindingStatusCommand(t *testing.T) {
+	defaultNamespace := "default"
+	serviceBindingName := "my-service-binding"
+
+	serviceBinding := &bindingsv1alpha1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceBindingName,
+			Namespace: defaultNamespace,
+		},
+		Spec: bindingsv1alpha1.ServiceBindingSpec{
+			Subject: &bindingsv1alpha1.Reference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Namespace:  defaultNamespace,
+				Name:       "my-deployment",
+			},
+			Providers: []bindingsv1alpha1.ServiceCredentialProvider{
+				{
+					Name:        serviceBindingName,
+					BindingMode: bindingsv1alpha1.SecretServiceBinding,
+					Ref: bindingsv1alpha1.ServiceCredentialReference{
+						Secret: corev1.LocalObjectReference{Name: "my-secret"},
+					},
+				},
+			},
+		},
+		Status: bindingsv1alpha1.ServiceBindingStatus{
+			Status: apis.Status{
+				Conditions: apis.Conditions{
+					{
+						Type:   apis.ConditionReady,
+						Status: corev1.ConditionTrue,
+						LastTransitionTime: apis.VolatileTime{
+							Inner: metav1.Time{
+								Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-secret",
+			Namespace: defaultNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("admin"),
+			"password": []byte("hunter2"),
+			"host":     []byte("db.example.com"),
+		},
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name: "show status",
+			Args: []string{serviceBindingName},
+			GivenObjects: []runtime.Object{
+				&bindingsv1alpha1.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceBindingName,
+						Namespace: defaultNamespace,
+					},
+					Status: bindingsv1alpha1.ServiceBindingStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:    apis.ConditionReady,
+									Status:  corev1.ConditionFalse,
+									Reason:  "OopsieDoodle",
+									Message: "a hopefully informative message about what went wrong",
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+# my-service-binding: OopsieDoodle
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+message: a hopefully informative message about what went wrong
+reason: OopsieDoodle
+status: "False"
+type: Ready
+# service
+---
+providers: []
+`,
+		},
+		{
+			Name: "show secret keys",
+			Args: []string{serviceBindingName},
+			GivenObjects: []runtime.Object{
+				serviceBinding,
+				secret,
+			},
+			ExpectOutput: `
+# my-service-binding: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+# service
+---
+providers:
+- keys:
+  - host
+  - password
+  - username
+  mode: Secret
+  name: my-service-binding
+  secret: my-secret
+`,
+		},
+		{
+			Name: "show missing secret",
+			Args: []string{serviceBindingName},
+			GivenObjects: []runtime.Object{
+				serviceBinding,
+			},
+			ExpectOutput: `
+# my-service-binding: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+# service
+---
+providers:
+- mode: Secret
+  name: my-service-binding
+  secret: my-secret
+  secretFound: false
+`,
+		},
+		{
+			Name: "secret get error",
+			Args: []string{serviceBindingName},
+			GivenObjects: []runtime.Object{
+				serviceBinding,
+				secret,
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "secrets"),
+			},
+			ExpectOutput: `
+# my-service-binding: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+`,
+			ShouldError: true,
+		},
+		{
+			Name: "not found",
+			Args: []string{serviceBindingName},
+			ExpectOutput: `
+Service binding "default/my-service-binding" not found
+`,
+			ShouldError: true,
+		},
+		{
+			Name: "get error",
+			Args: []string{serviceBindingName},
+			GivenObjects: []runtime.Object{
+				serviceBinding,
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "servicebindings"),
+			},
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewServiceStatusCommand)
+}
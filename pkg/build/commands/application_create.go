@@ -40,6 +40,7 @@ import (
 
 type ApplicationCreateOptions struct {
 	options.ResourceOptions
+	options.EnvFileOptions
 
 	Image     string
 	CacheSize string
@@ -108,6 +109,7 @@ func (opts *ApplicationCreateOptions) Validate(ctx context.Context) cli.FieldErr
 	}
 
 	errs = errs.Also(validation.EnvVars(opts.Env, cli.EnvFlagName))
+	errs = errs.Also(opts.EnvFileOptions.Validate(ctx))
 
 	if opts.LimitCPU != "" {
 		errs = errs.Also(validation.Quantity(opts.LimitCPU, cli.LimitCPUFlagName))
@@ -159,6 +161,11 @@ func (opts *ApplicationCreateOptions) Exec(ctx context.Context, c *cli.Config) e
 		}
 	}
 
+	env, err := opts.EnvFromFiles()
+	if err != nil {
+		return err
+	}
+	application.Spec.Build.Env = append(application.Spec.Build.Env, env...)
 	for _, env := range opts.Env {
 		if application.Spec.Build.Env == nil {
 			application.Spec.Build.Env = []corev1.EnvVar{}
@@ -280,8 +287,11 @@ cluster).
 			fmt.Sprintf("%s application create my-app %s registry.example.com/image %s https://example.com/my-app.git", c.Name, cli.ImageFlagName, cli.GitRepoFlagName),
 			fmt.Sprintf("%s application create my-app %s registry.example.com/image %s ./my-app", c.Name, cli.ImageFlagName, cli.LocalPathFlagName),
 		}, "\n"),
-		PreRunE: cli.ValidateOptions(ctx, opts),
-		RunE:    cli.ExecOptions(ctx, c, opts),
+		PreRunE: cli.Sequence(
+			cli.DefaultImage(&opts.Image, &opts.Name),
+			cli.ValidateOptions(ctx, opts),
+		),
+		RunE: cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.Args(cmd,
@@ -297,12 +307,15 @@ cluster).
 	cmd.Flags().MarkHidden("docker-network")
 	cmd.Flags().StringVar(&opts.GitRepo, cli.StripDash(cli.GitRepoFlagName), "", "git `url` to remote source code")
 	cmd.Flags().StringVar(&opts.GitRevision, cli.StripDash(cli.GitRevisionFlagName), "main", "`refspec` within the git repo to checkout")
+	cli.ConfigDefault(cmd, cli.GitRevisionFlagName, cli.GitRevisionConfigKey)
 	cmd.Flags().StringVar(&opts.SubPath, cli.StripDash(cli.SubPathFlagName), "", "path to `directory` within the git repo to checkout")
 	cmd.Flags().StringArrayVar(&opts.Env, cli.StripDash(cli.EnvFlagName), []string{}, fmt.Sprintf("environment `variable` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s MY_VAR=my-value", cli.EnvFlagName)))
+	opts.EnvFileOptions.AddFlags(cmd)
 	cmd.Flags().StringVar(&opts.LimitCPU, cli.StripDash(cli.LimitCPUFlagName), "", "the maximum amount of cpu allowed, in CPU `cores` (500m = .5 cores)")
 	cmd.Flags().StringVar(&opts.LimitMemory, cli.StripDash(cli.LimitMemoryFlagName), "", "the maximum amount of memory allowed, in `bytes` (500Mi = 500MiB = 500 * 1024 * 1024)")
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch build logs")
 	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the application to become ready when watching logs")
+	cli.ConfigDefault(cmd, cli.WaitTimeoutFlagName, cli.WaitTimeoutConfigKey)
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
 
 	return cmd
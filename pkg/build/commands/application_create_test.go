@@ -449,6 +449,37 @@ Created application "my-application"
 			},
 			ExpectOutput: `
 Created application "my-application"
+`,
+		},
+		{
+			Name: "git repo with env file",
+			Args: []string{applicationName, cli.ImageFlagName, imageTag, cli.GitRepoFlagName, gitRepo, cli.EnvFileFlagName, "../../parsers/testdata/app.env", cli.EnvFlagName, "MY_VAR3=value3"},
+			ExpectCreates: []runtime.Object{
+				&buildv1alpha1.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      applicationName,
+					},
+					Spec: buildv1alpha1.ApplicationSpec{
+						Build: buildv1alpha1.ImageBuild{
+							Env: []corev1.EnvVar{
+								{Name: "MY_VAR1", Value: "value1"},
+								{Name: "MY_VAR2", Value: "multi\nline"},
+								{Name: "MY_VAR3", Value: "value3"},
+							},
+						},
+						Image: imageTag,
+						Source: &buildv1alpha1.Source{
+							Git: &buildv1alpha1.Git{
+								URL:      gitRepo,
+								Revision: gitBranch,
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created application "my-application"
 `,
 		},
 		{
@@ -88,6 +88,7 @@ registry.
 	cli.Args(cmd,
 		cli.NamesArg(&opts.Names),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNamesArg(c, cli.ListApplications)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(cli.AllFlagName), false, "delete all applications within the namespace")
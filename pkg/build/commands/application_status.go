@@ -86,6 +86,7 @@ application roll out is processed or a build is in progress.
 	cli.Args(cmd,
 		cli.NameArg(&opts.Name),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListApplications)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 
@@ -90,6 +90,7 @@ As new builds are started, the logs are displayed. To show historical logs use
 	cli.Args(cmd,
 		cli.NameArg(&opts.Name),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListApplications)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().StringVar(&opts.Since, cli.StripDash(cli.SinceFlagName), "", "time `duration` to start reading logs from")
@@ -133,8 +133,11 @@ for containers.
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s container create my-app %s registry.example.com/image", c.Name, cli.ImageFlagName),
 		}, "\n"),
-		PreRunE: cli.ValidateOptions(ctx, opts),
-		RunE:    cli.ExecOptions(ctx, c, opts),
+		PreRunE: cli.Sequence(
+			cli.DefaultImage(&opts.Image, &opts.Name),
+			cli.ValidateOptions(ctx, opts),
+		),
+		RunE: cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.Args(cmd,
@@ -145,6 +148,7 @@ for containers.
 	cmd.Flags().StringVar(&opts.Image, cli.StripDash(cli.ImageFlagName), "_", "`repository` where the built images are pushed")
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch build logs")
 	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the container to become ready when watching logs")
+	cli.ConfigDefault(cmd, cli.WaitTimeoutFlagName, cli.WaitTimeoutConfigKey)
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
 
 	return cmd
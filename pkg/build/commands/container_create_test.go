@@ -26,6 +26,7 @@ import (
 	"github.com/projectriff/cli/pkg/k8s"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	"github.com/spf13/viper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	cachetesting "k8s.io/client-go/tools/cache/testing"
@@ -150,6 +151,32 @@ Created container "my-container"
 			},
 			ExpectOutput: `
 Created container "my-container"
+`,
+		},
+		{
+			Name: "default image from config",
+			Args: []string{containerName},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				viper.Set(cli.ImagePrefixConfigKey, "registry.example.com/me")
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+				viper.Reset()
+				return nil
+			},
+			ExpectCreates: []runtime.Object{
+				&buildv1alpha1.Container{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      containerName,
+					},
+					Spec: buildv1alpha1.ContainerSpec{
+						Image: "registry.example.com/me/my-container",
+					},
+				},
+			},
+			ExpectOutput: `
+Created container "my-container"
 `,
 		},
 		{
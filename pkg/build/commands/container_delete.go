@@ -87,6 +87,7 @@ Deleting a container prevents resolution of new images.
 	cli.Args(cmd,
 		cli.NamesArg(&opts.Names),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNamesArg(c, cli.ListContainers)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(cli.AllFlagName), false, "delete all containers within the namespace")
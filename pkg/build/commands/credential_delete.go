@@ -93,6 +93,7 @@ unless another credential for the same registry is available.
 	cli.Args(cmd,
 		cli.NamesArg(&opts.Names),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNamesArg(c, cli.ListCredentials)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(cli.AllFlagName), false, "delete all credentials within the namespace")
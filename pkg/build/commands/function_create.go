@@ -40,6 +40,7 @@ import (
 
 type FunctionCreateOptions struct {
 	options.ResourceOptions
+	options.EnvFileOptions
 
 	Image     string
 	CacheSize string
@@ -63,13 +64,15 @@ type FunctionCreateOptions struct {
 	Tail        bool
 	WaitTimeout string
 
-	DryRun bool
+	DryRun      bool
+	Interactive bool
 }
 
 var (
 	_ cli.Validatable = (*FunctionCreateOptions)(nil)
 	_ cli.Executable  = (*FunctionCreateOptions)(nil)
 	_ cli.DryRunable  = (*FunctionCreateOptions)(nil)
+	_ cli.Promptable  = (*FunctionCreateOptions)(nil)
 )
 
 func (opts *FunctionCreateOptions) Validate(ctx context.Context) cli.FieldErrors {
@@ -114,6 +117,7 @@ func (opts *FunctionCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 	// nothing to do for artifact, handler, and invoker
 
 	errs = errs.Also(validation.EnvVars(opts.Env, cli.EnvFlagName))
+	errs = errs.Also(opts.EnvFileOptions.Validate(ctx))
 
 	if opts.LimitCPU != "" {
 		errs = errs.Also(validation.Quantity(opts.LimitCPU, cli.LimitCPUFlagName))
@@ -141,6 +145,69 @@ func (opts *FunctionCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 	return errs
 }
 
+func (opts *FunctionCreateOptions) Prompt(ctx context.Context, p *cli.Prompter) error {
+	if err := p.PromptName("Name", &opts.Name); err != nil {
+		return err
+	}
+	source := sourceGit
+	switch {
+	case p.Changed(cli.GitRepoFlagName):
+	case p.Changed(cli.LocalPathFlagName):
+		source = sourceLocal
+	default:
+		var err error
+		if source, err = p.Choose("Source", []string{sourceGit, sourceLocal}, sourceGit, false); err != nil {
+			return err
+		}
+	}
+	if err := promptSource(p, source); err != nil {
+		return err
+	}
+
+	if err := promptImage(p, opts.Namespace); err != nil {
+		return err
+	}
+
+	if err := p.PromptFlag(cli.InvokerFlagName, "Invoker", true); err != nil {
+		return err
+	}
+	if err := p.PromptFlag(cli.ArtifactFlagName, "Artifact", true); err != nil {
+		return err
+	}
+	return p.PromptFlag(cli.HandlerFlagName, "Handler", true)
+}
+
+const (
+	sourceGit   = "git repository"
+	sourceLocal = "local directory"
+)
+
+func promptSource(p *cli.Prompter, source string) error {
+	if source == sourceLocal {
+		return p.PromptFlag(cli.LocalPathFlagName, "Local directory", false)
+	}
+	if err := p.PromptFlag(cli.GitRepoFlagName, "Git repository URL", false); err != nil {
+		return err
+	}
+	if err := p.PromptFlag(cli.GitRevisionFlagName, "Git revision", false); err != nil {
+		return err
+	}
+	return p.PromptFlag(cli.SubPathFlagName, "Directory within the git repository", true)
+}
+
+func promptImage(p *cli.Prompter, namespace string) error {
+	if p.Changed(cli.ImageFlagName) {
+		return nil
+	}
+	// images are pushed with the registry credentials in the namespace
+	if credentials := p.ListNames(namespace, cli.ListCredentials); len(credentials) != 0 {
+		p.Printf("Registry credentials in namespace %q: %s\n", namespace, strings.Join(credentials, ", "))
+	} else {
+		p.Printf("No registry credentials found in namespace %q, pushing the image may fail\n", namespace)
+	}
+	return p.PromptFlag(cli.ImageFlagName, "Image repository (_ for the default prefix)", false)
+}
+
 func (opts *FunctionCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
 	function := &buildv1alpha1.Function{
 		ObjectMeta: metav1.ObjectMeta{
@@ -168,6 +235,11 @@ func (opts *FunctionCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 		}
 	}
 
+	env, err := opts.EnvFromFiles()
+	if err != nil {
+		return err
+	}
+	function.Spec.Build.Env = append(function.Spec.Build.Env, env...)
 	for _, env := range opts.Env {
 		if function.Spec.Build.Env == nil {
 			function.Spec.Build.Env = []corev1.EnvVar{}
@@ -307,17 +379,23 @@ The riff.toml file takes the form:
 	artifact = "<path to artifact>"
 	handler = "<function handler>"
 
+` + cli.InteractiveFlagName + ` prompts for missing values when stdin is a terminal and prints
+the equivalent command, other values are validated as usual.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s function create my-func %s registry.example.com/image %s https://example.com/my-func.git", c.Name, cli.ImageFlagName, cli.GitRepoFlagName),
 			fmt.Sprintf("%s function create my-func %s registry.example.com/image %s ./my-func", c.Name, cli.ImageFlagName, cli.LocalPathFlagName),
 		}, "\n"),
-		PreRunE: cli.ValidateOptions(ctx, opts),
-		RunE:    cli.ExecOptions(ctx, c, opts),
+		PreRunE: cli.Sequence(
+			cli.PromptOptions(ctx, c, &opts.Interactive, opts),
+			cli.DefaultImage(&opts.Image, &opts.Name),
+			cli.ValidateOptions(ctx, opts),
+		),
+		RunE: cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.Args(cmd,
-		cli.NameArg(&opts.Name),
+		cli.InteractiveNameArg(&opts.Name, &opts.Interactive),
 	)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
@@ -332,13 +410,17 @@ The riff.toml file takes the form:
 	cmd.Flags().MarkHidden("docker-network")
 	cmd.Flags().StringVar(&opts.GitRepo, cli.StripDash(cli.GitRepoFlagName), "", "git `url` to remote source code")
 	cmd.Flags().StringVar(&opts.GitRevision, cli.StripDash(cli.GitRevisionFlagName), "main", "`refspec` within the git repo to checkout")
+	cli.ConfigDefault(cmd, cli.GitRevisionFlagName, cli.GitRevisionConfigKey)
 	cmd.Flags().StringVar(&opts.SubPath, cli.StripDash(cli.SubPathFlagName), "", "path to `directory` within the git repo to checkout")
 	cmd.Flags().StringArrayVar(&opts.Env, cli.StripDash(cli.EnvFlagName), []string{}, fmt.Sprintf("environment `variable` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s MY_VAR=my-value", cli.EnvFlagName)))
+	opts.EnvFileOptions.AddFlags(cmd)
 	cmd.Flags().StringVar(&opts.LimitCPU, cli.StripDash(cli.LimitCPUFlagName), "", "the maximum amount of cpu allowed, in CPU `cores` (500m = .5 cores)")
 	cmd.Flags().StringVar(&opts.LimitMemory, cli.StripDash(cli.LimitMemoryFlagName), "", "the maximum amount of memory allowed, in `bytes` (500Mi = 500MiB = 500 * 1024 * 1024)")
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch build logs")
 	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the function to become ready when watching logs")
+	cli.ConfigDefault(cmd, cli.WaitTimeoutFlagName, cli.WaitTimeoutConfigKey)
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+	cmd.Flags().BoolVar(&opts.Interactive, cli.StripDash(cli.InteractiveFlagName), false, "prompt for missing values when stdin is a terminal")
 
 	return cmd
 }
@@ -372,6 +372,133 @@ Created function "my-function"
 			},
 			ExpectOutput: `
 Created function "my-function"
+`,
+		},
+		{
+			Name: "interactive",
+			Args: []string{functionName, cli.InteractiveFlagName},
+			GivenObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "my-credential",
+						Labels: map[string]string{
+							buildv1alpha1.CredentialLabelKey: "docker-hub",
+						},
+					},
+				},
+			},
+			Terminal: true,
+			Stdin:    []byte(fmt.Sprintf("\n%s\n\n%s\n%s\n%s\n\n\n", gitRepo, subPath, imageTag, invoker)),
+			ExpectCreates: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      functionName,
+					},
+					Spec: buildv1alpha1.FunctionSpec{
+						Image:   imageTag,
+						Invoker: invoker,
+						Source: &buildv1alpha1.Source{
+							Git: &buildv1alpha1.Git{
+								URL:      gitRepo,
+								Revision: gitBranch,
+							},
+							SubPath: subPath,
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Source:
+  1) git repository
+  2) local directory
+Choose [git repository]: Git repository URL: Git revision [main]: Directory within the git repository (optional): Registry credentials in namespace "default": my-credential
+Image repository (_ for the default prefix) [_]: Invoker (optional): Artifact (optional): Handler (optional): 
+Equivalent command:
+  create my-function --git-repo https://example.com/repo.git --image registry.example.com/repo:tag --invoker java --sub-path some/path
+
+Created function "my-function"
+`,
+		},
+		{
+			Name:     "interactive, flags are not prompted",
+			Args:     []string{functionName, cli.ImageFlagName, imageTag, cli.GitRepoFlagName, gitRepo, cli.InteractiveFlagName},
+			Terminal: true,
+			Stdin:    []byte("\n\n\n\n\n"),
+			ExpectCreates: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      functionName,
+					},
+					Spec: buildv1alpha1.FunctionSpec{
+						Image: imageTag,
+						Source: &buildv1alpha1.Source{
+							Git: &buildv1alpha1.Git{
+								URL:      gitRepo,
+								Revision: gitBranch,
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Git revision [main]: Directory within the git repository (optional): Invoker (optional): Artifact (optional): Handler (optional): 
+Equivalent command:
+  create my-function --git-repo https://example.com/repo.git --image registry.example.com/repo:tag
+
+Created function "my-function"
+`,
+		},
+		{
+			Name:     "interactive, name is prompted",
+			Args:     []string{cli.ImageFlagName, imageTag, cli.GitRepoFlagName, gitRepo, cli.InteractiveFlagName},
+			Terminal: true,
+			Stdin:    []byte(fmt.Sprintf("%s\n\n\n\n\n\n", functionName)),
+			ExpectCreates: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      functionName,
+					},
+					Spec: buildv1alpha1.FunctionSpec{
+						Image: imageTag,
+						Source: &buildv1alpha1.Source{
+							Git: &buildv1alpha1.Git{
+								URL:      gitRepo,
+								Revision: gitBranch,
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Name: Git revision [main]: Directory within the git repository (optional): Invoker (optional): Artifact (optional): Handler (optional): 
+Equivalent command:
+  create my-function --git-repo https://example.com/repo.git --image registry.example.com/repo:tag
+
+Created function "my-function"
+`,
+		},
+		{
+			Name:        "interactive, not a terminal",
+			Args:        []string{functionName, cli.ImageFlagName, imageTag, cli.InteractiveFlagName},
+			ShouldError: true,
+			ExpectOutput: `
+Warning: stdin is not a terminal, ignoring --interactive
+`,
+		},
+		{
+			Name:        "interactive, no answer",
+			Args:        []string{functionName, cli.InteractiveFlagName},
+			Terminal:    true,
+			ShouldError: true,
+			ExpectOutput: `
+Source:
+  1) git repository
+  2) local directory
+Choose [git repository]: 
 `,
 		},
 		{
@@ -452,6 +579,37 @@ Created function "my-function"
 			},
 			ExpectOutput: `
 Created function "my-function"
+`,
+		},
+		{
+			Name: "git repo with env file",
+			Args: []string{functionName, cli.ImageFlagName, imageTag, cli.GitRepoFlagName, gitRepo, cli.EnvFileFlagName, "../../parsers/testdata/app.env", cli.EnvFlagName, "MY_VAR3=value3"},
+			ExpectCreates: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      functionName,
+					},
+					Spec: buildv1alpha1.FunctionSpec{
+						Build: buildv1alpha1.ImageBuild{
+							Env: []corev1.EnvVar{
+								{Name: "MY_VAR1", Value: "value1"},
+								{Name: "MY_VAR2", Value: "multi\nline"},
+								{Name: "MY_VAR3", Value: "value3"},
+							},
+						},
+						Image: imageTag,
+						Source: &buildv1alpha1.Source{
+							Git: &buildv1alpha1.Git{
+								URL:      gitRepo,
+								Revision: gitBranch,
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created function "my-function"
 `,
 		},
 		{
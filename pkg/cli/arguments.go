@@ -26,6 +26,10 @@ import (
 const (
 	NameArgumentName  = "name"
 	NamesArgumentName = "name(s)"
+	// ResourcesArgumentName references resources in the form <kind>/<name>
+	ResourcesArgumentName = "kind/name(s)"
+	KeyArgumentName       = "key"
+	ValueArgumentName     = "value"
 )
 
 var ErrIgnoreArg = fmt.Errorf("ignore argument")
@@ -34,7 +38,9 @@ type Arg struct {
 	Name     string
 	Arity    int
 	Optional bool
-	Set      func(cmd *cobra.Command, args []string, offset int) error
+	// Interactive, when true, allows a required argument to be missing so it can be prompted for
+	Interactive *bool
+	Set         func(cmd *cobra.Command, args []string, offset int) error
 }
 
 func Args(cmd *cobra.Command, argDefs ...Arg) {
@@ -48,25 +54,28 @@ func Args(cmd *cobra.Command, argDefs ...Arg) {
 				arity = len(args) - offset
 			}
 			if len(args)-offset < arity {
-				if argDef.Optional {
+				if argDef.Optional || (argDef.Interactive != nil && *argDef.Interactive) {
 					continue
 				}
 				// TODO create a better message saying what is missing
-				return fmt.Errorf("missing required argument(s)")
+				return WithExitCode(fmt.Errorf("missing required argument(s)"), ExitCodeInvalid)
 			}
 
 			if err := argDef.Set(cmd, args, offset); err != nil {
 				if err == ErrIgnoreArg {
 					continue
 				}
-				return err
+				return WithExitCode(err, ExitCodeInvalid)
 			}
 
 			offset += arity
 		}
 
 		// no additional args
-		return cobra.NoArgs(cmd, args[offset:])
+		if err := cobra.NoArgs(cmd, args[offset:]); err != nil {
+			return WithExitCode(err, ExitCodeInvalid)
+		}
+		return nil
 	}
 
 	if cmd.Annotations == nil {
@@ -114,6 +123,14 @@ func NameArg(name *string) Arg {
 	}
 }
 
+// InteractiveNameArg is a NameArg that may be omitted when interactive is set, the name is then
+// prompted for with Prompter.PromptName.
+func InteractiveNameArg(name *string, interactive *bool) Arg {
+	arg := NameArg(name)
+	arg.Interactive = interactive
+	return arg
+}
+
 func NamesArg(names *[]string) Arg {
 	return Arg{
 		Name:  NamesArgumentName,
@@ -125,6 +142,39 @@ func NamesArg(names *[]string) Arg {
 	}
 }
 
+func KeyArg(key *string) Arg {
+	return Arg{
+		Name:  KeyArgumentName,
+		Arity: 1,
+		Set: func(cmd *cobra.Command, args []string, offset int) error {
+			*key = args[offset]
+			return nil
+		},
+	}
+}
+
+func ValueArg(value *string) Arg {
+	return Arg{
+		Name:  ValueArgumentName,
+		Arity: 1,
+		Set: func(cmd *cobra.Command, args []string, offset int) error {
+			*value = args[offset]
+			return nil
+		},
+	}
+}
+
+func ResourcesArg(resources *[]string) Arg {
+	return Arg{
+		Name:  ResourcesArgumentName,
+		Arity: -1,
+		Set: func(cmd *cobra.Command, args []string, offset int) error {
+			*resources = args[offset:]
+			return nil
+		},
+	}
+}
+
 func BareDoubleDashArgs(values *[]string) Arg {
 	return Arg{
 		Arity: -1,
@@ -279,6 +279,54 @@ func TestNameArg(t *testing.T) {
 	}
 }
 
+func TestInteractiveNameArg(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		interactive bool
+		actual      string
+		expected    string
+		err         error
+	}{{
+		name: "too few args",
+		err:  fmt.Errorf("missing required argument(s)"),
+	}, {
+		name:        "missing name, interactive",
+		interactive: true,
+	}, {
+		name:        "name arg, interactive",
+		args:        []string{"my-name"},
+		interactive: true,
+		expected:    "my-name",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := &cobra.Command{
+				Use: "args-test",
+				RunE: func(cmd *cobra.Command, args []string) error {
+					return nil
+				},
+			}
+			cli.Args(cmd,
+				cli.InteractiveNameArg(&test.actual, &test.interactive),
+			)
+			cmd.SetArgs(test.args)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			err := cmd.Execute()
+
+			if expected, actual := fmt.Sprintf("%s", test.err), fmt.Sprintf("%s", err); expected != actual {
+				t.Errorf("Expected error %q, actually %q", expected, actual)
+			}
+			if diff := cmp.Diff(test.expected, test.actual); diff != "" {
+				t.Errorf("Unexpected arg binding (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
 func TestNamesArg(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -327,6 +375,50 @@ func TestNamesArg(t *testing.T) {
 	}
 }
 
+func TestResourcesArg(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		actual   []string
+		expected []string
+		err      error
+	}{{
+		name:     "no resource",
+		args:     []string{},
+		expected: []string{},
+	}, {
+		name:     "multiple resources",
+		args:     []string{"function/my-name", "stream/my-other-name"},
+		expected: []string{"function/my-name", "stream/my-other-name"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := &cobra.Command{
+				Use: "args-test",
+				RunE: func(cmd *cobra.Command, args []string) error {
+					return nil
+				},
+			}
+			cli.Args(cmd,
+				cli.ResourcesArg(&test.actual),
+			)
+			cmd.SetArgs(test.args)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			err := cmd.Execute()
+
+			if expected, actual := fmt.Sprintf("%s", test.err), fmt.Sprintf("%s", err); expected != actual {
+				t.Errorf("Expected error %q, actually %q", expected, actual)
+			}
+			if diff := cmp.Diff(test.expected, test.actual); diff != "" {
+				t.Errorf("Unexpected arg binding (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
 func TestBareDoubleDashArgs(t *testing.T) {
 	tests := []struct {
 		name     string
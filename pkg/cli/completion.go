@@ -0,0 +1,227 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/k8s"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// CompletionFunc completes the value of an argument or flag, see cobra's ValidArgsFunction
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// ResourceLister lists resources within a namespace to complete their names
+type ResourceLister func(c *Config, namespace string) (runtime.Object, error)
+
+// CompleteNames completes the names of resources within the namespace of the command.
+func CompleteNames(c *Config, list ResourceLister) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		names, err := listNames(cmd, c, list)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// CompleteNameArg completes the name argument of a command with the names of existing resources.
+// Only the first argument is completed.
+func CompleteNameArg(c *Config, list ResourceLister) CompletionFunc {
+	complete := CompleteNames(c, list)
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// CompleteNamesArg completes the names argument of a command with the names of existing
+// resources, skipping names that are already present.
+func CompleteNamesArg(c *Config, list ResourceLister) CompletionFunc {
+	complete := CompleteNames(c, list)
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		names, directive := complete(cmd, args, toComplete)
+		remaining := []string{}
+		for _, name := range names {
+			if !containsString(args, name) {
+				remaining = append(remaining, name)
+			}
+		}
+		return remaining, directive
+	}
+}
+
+// CompleteStreamRefs completes stream references in the form [<alias>:]<stream>, preserving the
+// alias as typed.
+func CompleteStreamRefs(c *Config, list ResourceLister) CompletionFunc {
+	complete := CompleteNames(c, list)
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		names, directive := complete(cmd, args, toComplete)
+		if i := strings.Index(toComplete, ":"); i != -1 {
+			for j := range names {
+				names[j] = toComplete[:i+1] + names[j]
+			}
+		}
+		return names, directive
+	}
+}
+
+// CompleteValues completes a fixed set of values.
+func CompleteValues(values ...string) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func listNames(cmd *cobra.Command, c *Config, list ResourceLister) (names []string, err error) {
+	// the client panics when the kube config is unusable, completion should fail quietly
+	defer func() {
+		if r := recover(); r != nil {
+			names, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
+
+	if flag := cmd.Flag(StripDash(KubeConfigFlagName)); flag != nil && flag.Changed {
+		// the client is initialized before flags are parsed for completion requests
+		c.Client = k8s.NewClientWithOverrides(c.KubeConfigFile, &c.KubeConfigOverrides)
+	}
+	namespace := ""
+	if flag := cmd.Flag(StripDash(NamespaceFlagName)); flag != nil {
+		namespace = flag.Value.String()
+	}
+	if namespace == "" {
+		namespace = c.DefaultNamespace()
+	}
+
+	return ListNames(c, namespace, list)
+}
+
+// ListNames lists the sorted names of the resources within the namespace.
+func ListNames(c *Config, namespace string, list ResourceLister) ([]string, error) {
+	obj, err := list(c, namespace)
+	if err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(obj)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, item := range items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, accessor.GetName())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ListNamespaces and the listers that follow are ResourceListers for the resources riff
+// manages or references.
+func ListNamespaces(c *Config, namespace string) (runtime.Object, error) {
+	return c.Core().Namespaces().List(metav1.ListOptions{})
+}
+
+func ListCredentials(c *Config, namespace string) (runtime.Object, error) {
+	return c.Core().Secrets(namespace).List(metav1.ListOptions{
+		LabelSelector: buildv1alpha1.CredentialLabelKey,
+	})
+}
+
+func ListApplications(c *Config, namespace string) (runtime.Object, error) {
+	return c.Build().Applications(namespace).List(metav1.ListOptions{})
+}
+
+func ListContainers(c *Config, namespace string) (runtime.Object, error) {
+	return c.Build().Containers(namespace).List(metav1.ListOptions{})
+}
+
+func ListFunctions(c *Config, namespace string) (runtime.Object, error) {
+	return c.Build().Functions(namespace).List(metav1.ListOptions{})
+}
+
+func ListImageBindings(c *Config, namespace string) (runtime.Object, error) {
+	return c.Bindings().ImageBindings(namespace).List(metav1.ListOptions{})
+}
+
+func ListServiceBindings(c *Config, namespace string) (runtime.Object, error) {
+	return c.Bindings().ServiceBindings(namespace).List(metav1.ListOptions{})
+}
+
+func ListCoreDeployers(c *Config, namespace string) (runtime.Object, error) {
+	return c.CoreRuntime().Deployers(namespace).List(metav1.ListOptions{})
+}
+
+func ListKnativeAdapters(c *Config, namespace string) (runtime.Object, error) {
+	return c.KnativeRuntime().Adapters(namespace).List(metav1.ListOptions{})
+}
+
+func ListKnativeDeployers(c *Config, namespace string) (runtime.Object, error) {
+	return c.KnativeRuntime().Deployers(namespace).List(metav1.ListOptions{})
+}
+
+func ListKnativeConfigurations(c *Config, namespace string) (runtime.Object, error) {
+	return c.KnativeServing().Configurations(namespace).List(metav1.ListOptions{})
+}
+
+func ListKnativeServices(c *Config, namespace string) (runtime.Object, error) {
+	return c.KnativeServing().Services(namespace).List(metav1.ListOptions{})
+}
+
+func ListStreamingGateways(c *Config, namespace string) (runtime.Object, error) {
+	return c.StreamingRuntime().Gateways(namespace).List(metav1.ListOptions{})
+}
+
+func ListStreamingInMemoryGateways(c *Config, namespace string) (runtime.Object, error) {
+	return c.StreamingRuntime().InMemoryGateways(namespace).List(metav1.ListOptions{})
+}
+
+func ListStreamingKafkaGateways(c *Config, namespace string) (runtime.Object, error) {
+	return c.StreamingRuntime().KafkaGateways(namespace).List(metav1.ListOptions{})
+}
+
+func ListStreamingPulsarGateways(c *Config, namespace string) (runtime.Object, error) {
+	return c.StreamingRuntime().PulsarGateways(namespace).List(metav1.ListOptions{})
+}
+
+func ListStreamingProcessors(c *Config, namespace string) (runtime.Object, error) {
+	return c.StreamingRuntime().Processors(namespace).List(metav1.ListOptions{})
+}
+
+func ListStreamingStreams(c *Config, namespace string) (runtime.Object, error) {
+	return c.StreamingRuntime().Streams(namespace).List(metav1.ListOptions{})
+}
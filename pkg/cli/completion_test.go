@@ -0,0 +1,168 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/cli"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCompletion(t *testing.T) {
+	givenObjects := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-namespace"}},
+		&buildv1alpha1.Function{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "square"}},
+		&buildv1alpha1.Function{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cube"}},
+		&buildv1alpha1.Function{ObjectMeta: metav1.ObjectMeta{Namespace: "my-namespace", Name: "double"}},
+		&streamv1alpha1.Stream{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "numbers"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-secret"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-credential", Labels: map[string]string{buildv1alpha1.CredentialLabelKey: "docker-hub"}}},
+	}
+
+	tests := []struct {
+		name              string
+		complete          func(c *cli.Config) cli.CompletionFunc
+		args              []string
+		flags             []string
+		toComplete        string
+		withReactors      []rifftesting.ReactionFunc
+		expectCompletions []string
+		expectDirective   cobra.ShellCompDirective
+	}{{
+		name: "names",
+		complete: func(c *cli.Config) cli.CompletionFunc {
+			return cli.CompleteNames(c, cli.ListFunctions)
+		},
+		args:              []string{"square"},
+		expectCompletions: []string{"cube", "square"},
+		expectDirective:   cobra.ShellCompDirectiveNoFileComp,
+	}, {
+		name: "names in namespace",
+		complete: func(c *cli.Config) cli.CompletionFunc {
+			return cli.CompleteNames(c, cli.ListFunctions)
+		},
+		flags:             []string{cli.NamespaceFlagName, "my-namespace"},
+		expectCompletions: []string{"double"},
+		expectDirective:   cobra.ShellCompDirectiveNoFileComp,
+	}, {
+		name: "names list error",
+		complete: func(c *cli.Config) cli.CompletionFunc {
+			return cli.CompleteNames(c, cli.ListFunctions)
+		},
+		withReactors: []rifftesting.ReactionFunc{
+			rifftesting.InduceFailure("list", "functions"),
+		},
+		expectDirective: cobra.ShellCompDirectiveError,
+	}, {
+		name: "namespaces",
+		complete: func(c *cli.Config) cli.CompletionFunc {
+			return cli.CompleteNames(c, cli.ListNamespaces)
+		},
+		expectCompletions: []string{"default", "my-namespace"},
+		expectDirective:   cobra.ShellCompDirectiveNoFileComp,
+	}, {
+		name: "credentials",
+		complete: func(c *cli.Config) cli.CompletionFunc {
+			return cli.CompleteNames(c, cli.ListCredentials)
+		},
+		expectCompletions: []string{"my-credential"},
+		expectDirective:   cobra.ShellCompDirectiveNoFileComp,
+	}, {
+		name: "name arg",
+		complete: func(c *cli.Config) cli.CompletionFunc {
+			return cli.CompleteNameArg(c, cli.ListFunctions)
+		},
+		expectCompletions: []string{"cube", "square"},
+		expectDirective:   cobra.ShellCompDirectiveNoFileComp,
+	}, {
+		name: "name arg, already set",
+		complete: func(c *cli.Config) cli.CompletionFunc {
+			return cli.CompleteNameArg(c, cli.ListFunctions)
+		},
+		args:            []string{"square"},
+		expectDirective: cobra.ShellCompDirectiveNoFileComp,
+	}, {
+		name: "names arg",
+		complete: func(c *cli.Config) cli.CompletionFunc {
+			return cli.CompleteNamesArg(c, cli.ListFunctions)
+		},
+		args:              []string{"square"},
+		expectCompletions: []string{"cube"},
+		expectDirective:   cobra.ShellCompDirectiveNoFileComp,
+	}, {
+		name: "stream refs",
+		complete: func(c *cli.Config) cli.CompletionFunc {
+			return cli.CompleteStreamRefs(c, cli.ListStreamingStreams)
+		},
+		toComplete:        "num",
+		expectCompletions: []string{"numbers"},
+		expectDirective:   cobra.ShellCompDirectiveNoFileComp,
+	}, {
+		name: "stream refs with alias",
+		complete: func(c *cli.Config) cli.CompletionFunc {
+			return cli.CompleteStreamRefs(c, cli.ListStreamingStreams)
+		},
+		toComplete:        "in:",
+		expectCompletions: []string{"in:numbers"},
+		expectDirective:   cobra.ShellCompDirectiveNoFileComp,
+	}, {
+		name: "values",
+		complete: func(c *cli.Config) cli.CompletionFunc {
+			return cli.CompleteValues("ClusterLocal", "External")
+		},
+		expectCompletions: []string{"ClusterLocal", "External"},
+		expectDirective:   cobra.ShellCompDirectiveNoFileComp,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := rifftesting.NewClient(givenObjects...)
+			for i := range test.withReactors {
+				// in reverse order since we prepend
+				reactor := test.withReactors[len(test.withReactors)-1-i]
+				client.PrependReactor("*", "*", reactor)
+			}
+			c := cli.NewDefaultConfig()
+			c.Client = client
+			namespace := ""
+			cmd := &cobra.Command{}
+			cli.NamespaceFlag(cmd, c, &namespace)
+			if err := cmd.ParseFlags(test.flags); err != nil {
+				t.Fatalf("Unexpected error parsing flags: %v", err)
+			}
+
+			completions, directive := test.complete(c)(cmd, test.args, test.toComplete)
+
+			if diff := cmp.Diff(test.expectCompletions, completions); diff != "" {
+				t.Errorf("Unexpected completions (-expected, +actual): %s", diff)
+			}
+			if expected, actual := test.expectDirective, directive; expected != actual {
+				t.Errorf("Expected directive %s, actually %s", fmt.Sprint(expected), fmt.Sprint(actual))
+			}
+		})
+	}
+}
@@ -22,7 +22,9 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/fatih/color"
 	homedir "github.com/mitchellh/go-homedir"
@@ -31,12 +33,16 @@ import (
 	"github.com/projectriff/cli/pkg/pack"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 type Config struct {
 	CompiledEnv
-	ViperConfigFile string
-	KubeConfigFile  string
+	ViperConfigFile     string
+	ProjectConfigFile   string
+	KubeConfigFile      string
+	KubeConfigOverrides clientcmd.ConfigOverrides
+	ErrorFormat         string
 	k8s.Client
 	Exec   func(ctx context.Context, command string, args ...string) *exec.Cmd
 	Pack   pack.Client
@@ -44,6 +50,13 @@ type Config struct {
 	Stdin  io.Reader
 	Stdout io.Writer
 	Stderr io.Writer
+
+	// initialize runs the initializers once, set by Initialize
+	initialize func()
+	// initViper reads the config file once, set by Initialize
+	initViper func()
+	// detectRuntimes defers detecting the runtimes served by the cluster until LoadRuntimes
+	detectRuntimes bool
 }
 
 func NewDefaultConfig() *Config {
@@ -91,13 +104,31 @@ func (c *Config) Eerrorf(format string, a ...interface{}) (n int, err error) {
 func Initialize() *Config {
 	c := NewDefaultConfig()
 
-	cobra.OnInitialize(c.initViperConfig)
-	cobra.OnInitialize(c.initKubeConfig)
-	cobra.OnInitialize(c.init)
+	var once, viperOnce sync.Once
+	c.initViper = func() {
+		viperOnce.Do(c.initViperConfig)
+	}
+	c.initialize = func() {
+		once.Do(func() {
+			c.initViper()
+			c.initKubeConfig()
+			c.init()
+			c.initRuntimes()
+		})
+	}
+	cobra.OnInitialize(c.initialize)
 
 	return c
 }
 
+// EnsureInitialized runs the initializers of a config created by Initialize, unless they already
+// ran. Cobra skips the initializers when printing help.
+func (c *Config) EnsureInitialized() {
+	if c.initialize != nil {
+		c.initialize()
+	}
+}
+
 // initViperConfig reads in config file and ENV variables if set.
 func (c *Config) initViperConfig() {
 	if c.ViperConfigFile != "" {
@@ -123,6 +154,19 @@ func (c *Config) initViperConfig() {
 
 	// If a config file is found, read it in.
 	err := viper.ReadInConfig()
+	projectSettings := c.readProjectConfig()
+	// the profile from the project config file wins over the user's
+	profile := viper.GetString(ProfileConfigKey)
+	if p, ok := projectSettings[ProfileConfigKey].(string); ok && p != "" {
+		profile = p
+	}
+	if profile != "" {
+		// settings from the profile are merged over the top level settings
+		viper.MergeConfigMap(viper.GetStringMap(fmt.Sprintf("%s.%s", ProfilesConfigKey, profile)))
+	}
+	if projectSettings != nil {
+		viper.MergeConfigMap(projectSettings)
+	}
 	// hack for no-color since we urgently need to know if color should be disabled
 	if viper.GetBool(StripDash(NoColorFlagName)) {
 		color.NoColor = true
@@ -130,21 +174,76 @@ func (c *Config) initViperConfig() {
 	if err == nil {
 		c.Einfof("Using config file: %s\n", viper.ConfigFileUsed())
 	}
+	if c.ProjectConfigFile != "" {
+		c.Einfof("Using project config file: %s\n", c.ProjectConfigFile)
+	}
+	if profile != "" {
+		c.Einfof("Using profile: %s\n", profile)
+	}
+}
+
+// readProjectConfig reads the settings from the nearest project config file, named like the
+// user's config file, found walking up from the working directory. Project config files are
+// ignored when a config file is set explicitly.
+func (c *Config) readProjectConfig() map[string]interface{} {
+	if c.ViperConfigFile != "" {
+		return nil
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	home, _ := homedir.Dir()
+	path := findProjectConfigFile(dir, home, fmt.Sprintf(".%s.yaml", c.Name))
+	if path == "" {
+		return nil
+	}
+	settings, err := ReadConfigFile(path)
+	if err != nil {
+		c.Eerrorf("Unable to read project config file %s: %s\n", path, err)
+		return nil
+	}
+	c.ProjectConfigFile = path
+	return settings
 }
 
-// initKubeConfig defines the default location for the kubectl config file
+// UserConfigFile returns the path of the user's config file, which is set with the --config flag
+// or found in the home directory.
+func (c *Config) UserConfigFile() (string, error) {
+	if c.ViperConfigFile != "" {
+		return c.ViperConfigFile, nil
+	}
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path, nil
+	}
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, fmt.Sprintf(".%s.yaml", c.Name)), nil
+}
+
+// initKubeConfig defines the default location for the kubectl config file and the default
+// context and namespace
 func (c *Config) initKubeConfig() {
-	if c.KubeConfigFile != "" {
-		return
+	if c.KubeConfigFile == "" {
+		if kubeEnvConf, ok := os.LookupEnv("KUBECONFIG"); ok {
+			c.KubeConfigFile = kubeEnvConf
+		}
+	}
+	kubeContext := viper.GetString(ContextConfigKey)
+	if kubeContext != "" && c.KubeConfigOverrides.CurrentContext == "" {
+		c.KubeConfigOverrides.CurrentContext = kubeContext
 	}
-	if kubeEnvConf, ok := os.LookupEnv("KUBECONFIG"); ok {
-		c.KubeConfigFile = kubeEnvConf
+	// the namespace is only defaulted for the context it was set with
+	if namespace := viper.GetString(NamespaceConfigKey); namespace != "" && c.KubeConfigOverrides.Context.Namespace == "" && c.KubeConfigOverrides.CurrentContext == kubeContext {
+		c.KubeConfigOverrides.Context.Namespace = namespace
 	}
 }
 
 func (c *Config) init() {
 	if c.Client == nil {
-		c.Client = k8s.NewClient(c.KubeConfigFile)
+		c.Client = k8s.NewClientWithOverrides(c.KubeConfigFile, &c.KubeConfigOverrides)
 	}
 	if c.Pack == nil {
 		packClient, err := pack.NewClient(c.Stdout)
@@ -158,3 +257,39 @@ func (c *Config) init() {
 		c.Kail = kail.NewDefault(c.Client)
 	}
 }
+
+// initRuntimes enables the runtimes set in the config file or environment. For "auto", detecting
+// the runtimes served by the cluster is deferred until LoadRuntimes. Otherwise, the runtimes
+// compiled into the cli are enabled.
+func (c *Config) initRuntimes() {
+	value := viper.GetString(RuntimesConfigKey)
+	switch value {
+	case "":
+		return
+	case AutoRuntimes:
+		c.detectRuntimes = true
+	default:
+		runtimes, err := ParseRuntimes(value)
+		if err != nil {
+			c.Eerrorf("Invalid %s setting: %s\n", RuntimesConfigKey, err)
+			return
+		}
+		c.Runtimes = runtimes
+	}
+}
+
+// LoadRuntimes detects the runtimes served by the cluster when the runtimes setting is "auto".
+// Commands that talk to the cluster call it before consulting the enabled runtimes. The compiled
+// runtimes are kept when the cluster cannot be reached.
+func (c *Config) LoadRuntimes() {
+	if !c.detectRuntimes {
+		return
+	}
+	c.detectRuntimes = false
+	runtimes, err := c.DetectRuntimes()
+	if err != nil {
+		// keep the compiled runtimes, commands report an unreachable cluster
+		return
+	}
+	c.Runtimes = runtimes
+}
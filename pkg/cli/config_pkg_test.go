@@ -18,13 +18,16 @@ package cli
 
 import (
 	"bytes"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/fatih/color"
 	"github.com/google/go-cmp/cmp"
 	homedir "github.com/mitchellh/go-homedir"
+	"github.com/projectriff/cli/pkg/k8s"
 	"github.com/spf13/viper"
 )
 
@@ -138,6 +141,274 @@ func TestInitKubeConfig_EnvVar(t *testing.T) {
 	}
 }
 
+func TestInitKubeConfig_ViperContext(t *testing.T) {
+	defer viper.Reset()
+
+	tests := []struct {
+		name              string
+		context           string
+		namespace         string
+		overrideContext   string
+		overrideNamespace string
+		expectContext     string
+		expectNamespace   string
+	}{{
+		name: "no context",
+	}, {
+		name:          "context",
+		context:       "my-context",
+		expectContext: "my-context",
+	}, {
+		name:            "context and namespace",
+		context:         "my-context",
+		namespace:       "my-namespace",
+		expectContext:   "my-context",
+		expectNamespace: "my-namespace",
+	}, {
+		name:            "context flag",
+		context:         "my-context",
+		namespace:       "my-namespace",
+		overrideContext: "other-context",
+		expectContext:   "other-context",
+	}, {
+		name:              "namespace override",
+		context:           "my-context",
+		namespace:         "my-namespace",
+		overrideNamespace: "other-namespace",
+		expectContext:     "my-context",
+		expectNamespace:   "other-namespace",
+	}, {
+		name:            "namespace without context",
+		namespace:       "my-namespace",
+		expectNamespace: "my-namespace",
+	}, {
+		name:            "namespace without context, context flag",
+		namespace:       "my-namespace",
+		overrideContext: "other-context",
+		expectContext:   "other-context",
+	}, {
+		name:            "context flag matches saved context",
+		context:         "my-context",
+		namespace:       "my-namespace",
+		overrideContext: "my-context",
+		expectContext:   "my-context",
+		expectNamespace: "my-namespace",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set(ContextConfigKey, test.context)
+			viper.Set(NamespaceConfigKey, test.namespace)
+
+			c := NewDefaultConfig()
+			c.KubeConfigFile = "testdata/.kube/config"
+			c.KubeConfigOverrides.CurrentContext = test.overrideContext
+			c.KubeConfigOverrides.Context.Namespace = test.overrideNamespace
+			c.initKubeConfig()
+
+			if expected, actual := test.expectContext, c.KubeConfigOverrides.CurrentContext; expected != actual {
+				t.Errorf("Expected context %q, actually %q", expected, actual)
+			}
+			if expected, actual := test.expectNamespace, c.KubeConfigOverrides.Context.Namespace; expected != actual {
+				t.Errorf("Expected namespace %q, actually %q", expected, actual)
+			}
+		})
+	}
+}
+
+func TestInitViperConfig_ProjectAndProfile(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	defer viper.Reset()
+
+	dir, err := ioutil.TempDir("", "riff-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	home := filepath.Join(dir, "home")
+	project := filepath.Join(dir, "project")
+	nested := filepath.Join(project, "nested")
+	if err := os.MkdirAll(home, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	userConfig := strings.Join([]string{
+		"namespace: my-namespace",
+		"image-prefix: registry.example.com/me",
+		"wait-timeout: 5m",
+		"profiles:",
+		"  staging:",
+		"    namespace: staging",
+		"    image-prefix: registry.example.com/staging",
+		"",
+	}, "\n")
+	if err := ioutil.WriteFile(filepath.Join(home, ".riff.yaml"), []byte(userConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	projectConfig := "profile: staging\nwait-timeout: 10m\n"
+	if err := ioutil.WriteFile(filepath.Join(project, ".riff.yaml"), []byte(projectConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir(nested); err != nil {
+		t.Fatal(err)
+	}
+	homeEnv, homeisset := os.LookupEnv("HOME")
+	defer func() {
+		homedir.Reset()
+		if homeisset {
+			os.Setenv("HOME", homeEnv)
+		} else {
+			os.Unsetenv("HOME")
+		}
+	}()
+	homedir.Reset()
+	os.Setenv("HOME", home)
+
+	c := NewDefaultConfig()
+	output := &bytes.Buffer{}
+	c.Stdout = output
+	c.Stderr = output
+	c.initViperConfig()
+
+	for key, expected := range map[string]string{
+		NamespaceConfigKey:   "staging",
+		ImagePrefixConfigKey: "registry.example.com/staging",
+		WaitTimeoutConfigKey: "10m",
+		ProfileConfigKey:     "staging",
+	} {
+		if actual := viper.GetString(key); expected != actual {
+			t.Errorf("Expected %s %q, actually %q", key, expected, actual)
+		}
+	}
+	// symlinks in the temp dir may be resolved by the working directory
+	if expected, actual := ".riff.yaml", filepath.Base(c.ProjectConfigFile); expected != actual {
+		t.Errorf("Expected project config file %q, actually %q", expected, actual)
+	}
+	if expected, actual := "project", filepath.Base(filepath.Dir(c.ProjectConfigFile)); expected != actual {
+		t.Errorf("Expected project config file in %q, actually %q", expected, actual)
+	}
+	if expected, actual := "Using profile: staging", strings.TrimSpace(output.String()); !strings.HasSuffix(actual, expected) {
+		t.Errorf("Expected output to end with %q, actually %q", expected, actual)
+	}
+}
+
+func TestInitRuntimes(t *testing.T) {
+	defer viper.Reset()
+
+	tests := []struct {
+		name     string
+		runtimes string
+		expect   map[string]bool
+	}{{
+		name:   "compiled",
+		expect: map[string]bool{CoreRuntime: true},
+	}, {
+		name:     "listed",
+		runtimes: "streaming,knative",
+		expect:   map[string]bool{StreamingRuntime: true, KnativeRuntime: true},
+	}, {
+		name:     "invalid",
+		runtimes: "lambda",
+		expect:   map[string]bool{CoreRuntime: true},
+	}, {
+		name:     "auto is deferred",
+		runtimes: AutoRuntimes,
+		expect:   map[string]bool{CoreRuntime: true},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set(RuntimesConfigKey, test.runtimes)
+
+			c := NewDefaultConfig()
+			c.Runtimes = map[string]bool{CoreRuntime: true}
+			c.Stderr = &bytes.Buffer{}
+			c.initRuntimes()
+
+			if diff := cmp.Diff(test.expect, c.Runtimes); diff != "" {
+				t.Errorf("Unexpected runtimes (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadRuntimes_UnusableKubeConfig(t *testing.T) {
+	defer viper.Reset()
+	viper.Set(RuntimesConfigKey, AutoRuntimes)
+
+	c := NewDefaultConfig()
+	c.Runtimes = map[string]bool{CoreRuntime: true}
+	c.Stderr = &bytes.Buffer{}
+	c.KubeConfigFile = filepath.Join("testdata", ".kube", "missing")
+	c.Client = k8s.NewClientWithOverrides(c.KubeConfigFile, &c.KubeConfigOverrides)
+	c.initRuntimes()
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Unexpected panic: %v", r)
+		}
+	}()
+	c.LoadRuntimes()
+
+	if diff := cmp.Diff(map[string]bool{CoreRuntime: true}, c.Runtimes); diff != "" {
+		t.Errorf("Unexpected runtimes (-expected, +actual): %s", diff)
+	}
+}
+
+func TestWriteConfigFile(t *testing.T) {
+	defer viper.Reset()
+
+	dir, err := ioutil.TempDir("", "riff-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := NewDefaultConfig()
+	c.ViperConfigFile = filepath.Join(dir, ".riff.yaml")
+	if err := ioutil.WriteFile(c.ViperConfigFile, []byte("no-color: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// settings from flags are not persisted
+	viper.Set("kubeconfig", "testdata/.kube/config")
+
+	path, err := c.UserConfigFile()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected, actual := c.ViperConfigFile, path; expected != actual {
+		t.Errorf("Expected path %q, actually %q", expected, actual)
+	}
+	err = WriteConfigFile(path, func(settings map[string]interface{}) {
+		SetConfigValue(settings, ContextConfigKey, "my-context")
+		SetConfigValue(settings, "profiles.staging.namespace", "staging")
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("context: my-context\nno-color: true\nprofiles:\n  staging:\n    namespace: staging\n", string(content)); diff != "" {
+		t.Errorf("Unexpected config file (-expected, +actual): %s", diff)
+	}
+}
+
 func TestInit(t *testing.T) {
 	noColor := color.NoColor
 	color.NoColor = false
@@ -0,0 +1,283 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Keys in the config file. Settings for a key are overridden by a profile, the project config
+// file and environment variables, in that order.
+const (
+	ContextConfigKey       = "context"
+	NamespaceConfigKey     = "namespace"
+	ImagePrefixConfigKey   = "image-prefix"
+	WaitTimeoutConfigKey   = "wait-timeout"
+	IngressPolicyConfigKey = "ingress-policy"
+	GitRevisionConfigKey   = "git-revision"
+	OutputConfigKey        = "output"
+	RuntimeConfigKey       = "runtime"
+	RuntimesConfigKey      = "runtimes"
+	NoColorConfigKey       = "no-color"
+	ErrorFormatConfigKey   = "error-format"
+	ProfileConfigKey       = "profile"
+	ProfilesConfigKey      = "profiles"
+)
+
+// ConfigKey describes a setting that may be stored in the config file
+type ConfigKey struct {
+	Name        string
+	Description string
+	Validate    func(value string) error
+}
+
+// ConfigKeys are the settings that may be stored in the config file
+var ConfigKeys = []ConfigKey{
+	{Name: ContextConfigKey, Description: "kubectl config context to use", Validate: validateNotEmpty},
+	{Name: NamespaceConfigKey, Description: "kubernetes namespace to use", Validate: validateDNSLabel},
+	{Name: ImagePrefixConfigKey, Description: "repository prefix for built images when --image is not set", Validate: validateNotEmpty},
+	{Name: WaitTimeoutConfigKey, Description: "duration to wait for resources to become ready", Validate: validateDuration},
+	{Name: IngressPolicyConfigKey, Description: "ingress policy for deployers", Validate: validateOneOf("ClusterLocal", "External")},
+	{Name: GitRevisionConfigKey, Description: "git revision to build from", Validate: validateNotEmpty},
+	{Name: OutputConfigKey, Description: "output format for commands that support it", Validate: validateOneOf("table", "json")},
+	{Name: RuntimeConfigKey, Description: "preferred runtime when a resource kind exists in several", Validate: validateOneOf(AllRuntimes...)},
+	{Name: RuntimesConfigKey, Description: "runtimes to enable, a comma separated list or \"auto\" to detect", Validate: validateRuntimes},
+	{Name: NoColorConfigKey, Description: "disable color output in terminals", Validate: validateBool},
+	{Name: ErrorFormatConfigKey, Description: "format of errors for failed commands", Validate: validateOneOf(TextErrorFormat, JSONErrorFormat)},
+	{Name: ProfileConfigKey, Description: "profile whose settings override the top level settings", Validate: validateDNSLabel},
+}
+
+// LookupConfigKey finds the config key by name
+func LookupConfigKey(name string) (ConfigKey, bool) {
+	for _, key := range ConfigKeys {
+		if key.Name == name {
+			return key, true
+		}
+	}
+	return ConfigKey{}, false
+}
+
+// ConfigKeyNames returns the names of all config keys
+func ConfigKeyNames() []string {
+	names := make([]string, len(ConfigKeys))
+	for i, key := range ConfigKeys {
+		names[i] = key.Name
+	}
+	return names
+}
+
+func validateNotEmpty(value string) error {
+	if value == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	return nil
+}
+
+func validateDNSLabel(value string) error {
+	if errs := validation.IsDNS1123Label(value); len(errs) != 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func validateDuration(value string) error {
+	if _, err := time.ParseDuration(value); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateBool(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("must be true or false")
+	}
+	return nil
+}
+
+func validateRuntimes(value string) error {
+	if value == AutoRuntimes {
+		return nil
+	}
+	_, err := ParseRuntimes(value)
+	return err
+}
+
+func validateOneOf(values ...string) func(value string) error {
+	return func(value string) error {
+		for _, v := range values {
+			if v == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of: %s", strings.Join(values, ", "))
+	}
+}
+
+const configDefaultAnnotation = "projectriff.io/config-default"
+
+// ConfigDefault defaults the flag to the setting for the config key, when the flag is not set
+// explicitly. Defaults are applied by ApplyConfigDefaults.
+func ConfigDefault(cmd *cobra.Command, flagName, key string) {
+	_ = cmd.Flags().SetAnnotation(StripDash(flagName), configDefaultAnnotation, []string{key})
+}
+
+// ApplyConfigDefaults sets flags that were not set explicitly to the value of their config key,
+// if the key is set.
+func ApplyConfigDefaults(cmd *cobra.Command) error {
+	var err error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		keys := f.Annotations[configDefaultAnnotation]
+		if err != nil || f.Changed || len(keys) == 0 || !viper.IsSet(keys[0]) {
+			return
+		}
+		value := viper.GetString(keys[0])
+		if e := f.Value.Set(value); e != nil {
+			err = WithExitCode(fmt.Errorf("invalid config %q value %q for flag --%s: %v", keys[0], value, f.Name, e), ExitCodeInvalid)
+		}
+	})
+	return err
+}
+
+// DefaultImage resolves images starting with "_" against the image prefix from the config
+// file, when set. An image of "_" is resolved to the prefix followed by the name.
+func DefaultImage(image, name *string) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		prefix := strings.TrimSuffix(viper.GetString(ImagePrefixConfigKey), "/")
+		if prefix == "" || *name == "" {
+			return nil
+		}
+		switch {
+		case *image == "_":
+			*image = fmt.Sprintf("%s/%s", prefix, *name)
+		case strings.HasPrefix(*image, "_/"):
+			*image = prefix + strings.TrimPrefix(*image, "_")
+		}
+		return nil
+	}
+}
+
+// PreferredRuntime returns the runtime preferred by the user, or an empty string
+func PreferredRuntime() string {
+	return viper.GetString(RuntimeConfigKey)
+}
+
+// GetConfigValue returns the value for the dotted key from the settings
+func GetConfigValue(settings map[string]interface{}, key string) (interface{}, bool) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := settings[part].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		settings = next
+	}
+	value, ok := settings[parts[len(parts)-1]]
+	return value, ok
+}
+
+// SetConfigValue sets the value for the dotted key in the settings, creating nested settings as
+// needed
+func SetConfigValue(settings map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := settings[part].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			settings[part] = next
+		}
+		settings = next
+	}
+	settings[parts[len(parts)-1]] = value
+}
+
+// UnsetConfigValue removes the dotted key from the settings, pruning nested settings left
+// empty. False is returned if the key was not set.
+func UnsetConfigValue(settings map[string]interface{}, key string) bool {
+	parts := strings.SplitN(key, ".", 2)
+	if len(parts) == 1 {
+		if _, ok := settings[key]; !ok {
+			return false
+		}
+		delete(settings, key)
+		return true
+	}
+	next, ok := settings[parts[0]].(map[string]interface{})
+	if !ok || !UnsetConfigValue(next, parts[1]) {
+		return false
+	}
+	if len(next) == 0 {
+		delete(settings, parts[0])
+	}
+	return true
+}
+
+// ReadConfigFile reads the settings from the config file, a missing file has no settings
+func ReadConfigFile(path string) (map[string]interface{}, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return map[string]interface{}{}, nil
+	}
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	return v.AllSettings(), nil
+}
+
+// WriteConfigFile applies the update to the settings in the config file and writes it back,
+// creating the file if needed. Settings from flags and environment variables are not persisted.
+func WriteConfigFile(path string, update func(settings map[string]interface{})) error {
+	settings, err := ReadConfigFile(path)
+	if err != nil {
+		return err
+	}
+	update(settings)
+	v := viper.New()
+	if err := v.MergeConfigMap(settings); err != nil {
+		return err
+	}
+	return v.WriteConfigAs(path)
+}
+
+// findProjectConfigFile walks up from the directory looking for a file with the name, stopping
+// before the home directory since the config file there is the user's.
+func findProjectConfigFile(dir, home, name string) string {
+	for {
+		if dir == home {
+			return ""
+		}
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
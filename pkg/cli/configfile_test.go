@@ -0,0 +1,210 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestConfigKeys(t *testing.T) {
+	tests := []struct {
+		key     string
+		value   string
+		invalid bool
+	}{
+		{key: NamespaceConfigKey, value: "my-namespace"},
+		{key: NamespaceConfigKey, value: "My_Namespace", invalid: true},
+		{key: WaitTimeoutConfigKey, value: "5m"},
+		{key: WaitTimeoutConfigKey, value: "soon", invalid: true},
+		{key: IngressPolicyConfigKey, value: "External"},
+		{key: IngressPolicyConfigKey, value: "Public", invalid: true},
+		{key: OutputConfigKey, value: "json"},
+		{key: OutputConfigKey, value: "yaml", invalid: true},
+		{key: RuntimeConfigKey, value: KnativeRuntime},
+		{key: RuntimeConfigKey, value: "lambda", invalid: true},
+		{key: RuntimesConfigKey, value: AutoRuntimes},
+		{key: RuntimesConfigKey, value: "core,knative"},
+		{key: RuntimesConfigKey, value: "core,lambda", invalid: true},
+		{key: NoColorConfigKey, value: "true"},
+		{key: NoColorConfigKey, value: "maybe", invalid: true},
+		{key: ImagePrefixConfigKey, value: "", invalid: true},
+	}
+	for _, test := range tests {
+		t.Run(test.key+"="+test.value, func(t *testing.T) {
+			key, ok := LookupConfigKey(test.key)
+			if !ok {
+				t.Fatalf("Expected key %q to exist", test.key)
+			}
+			if err := key.Validate(test.value); (err != nil) != test.invalid {
+				t.Errorf("Expected invalid %v, actually %v", test.invalid, err)
+			}
+		})
+	}
+
+	if _, ok := LookupConfigKey("unknown"); ok {
+		t.Errorf("Expected unknown key to not exist")
+	}
+}
+
+func TestApplyConfigDefaults(t *testing.T) {
+	defer viper.Reset()
+	viper.Set(WaitTimeoutConfigKey, "5m")
+	viper.Set(GitRevisionConfigKey, "develop")
+	viper.Set(OutputConfigKey, "json")
+
+	var waitTimeout time.Duration
+	var gitRevision, output string
+	cmd := &cobra.Command{}
+	cmd.Flags().DurationVar(&waitTimeout, StripDash(WaitTimeoutFlagName), 0, "")
+	cmd.Flags().StringVar(&gitRevision, StripDash(GitRevisionFlagName), "main", "")
+	cmd.Flags().StringVar(&output, StripDash(OutputFlagName), "table", "")
+	ConfigDefault(cmd, WaitTimeoutFlagName, WaitTimeoutConfigKey)
+	ConfigDefault(cmd, GitRevisionFlagName, GitRevisionConfigKey)
+	if err := cmd.Flags().Parse([]string{GitRevisionFlagName, "v1.0.0"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ApplyConfigDefaults(cmd); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected, actual := 5*time.Minute, waitTimeout; expected != actual {
+		t.Errorf("Expected wait timeout %v, actually %v", expected, actual)
+	}
+	// explicit flags win
+	if expected, actual := "v1.0.0", gitRevision; expected != actual {
+		t.Errorf("Expected git revision %q, actually %q", expected, actual)
+	}
+	// flags without a config default are untouched
+	if expected, actual := "table", output; expected != actual {
+		t.Errorf("Expected output %q, actually %q", expected, actual)
+	}
+
+	viper.Set(WaitTimeoutConfigKey, "soon")
+	waitTimeout = 0
+	if err := ApplyConfigDefaults(cmd); err == nil {
+		t.Errorf("Expected error for invalid config value")
+	}
+}
+
+func TestDefaultImage(t *testing.T) {
+	defer viper.Reset()
+
+	tests := []struct {
+		name   string
+		prefix string
+		image  string
+		expect string
+	}{{
+		name:   "no prefix",
+		image:  "_",
+		expect: "_",
+	}, {
+		name:   "default image",
+		prefix: "registry.example.com/me/",
+		image:  "_",
+		expect: "registry.example.com/me/my-name",
+	}, {
+		name:   "default prefix",
+		prefix: "registry.example.com/me",
+		image:  "_/other:latest",
+		expect: "registry.example.com/me/other:latest",
+	}, {
+		name:   "explicit image",
+		prefix: "registry.example.com/me",
+		image:  "example.com/image",
+		expect: "example.com/image",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set(ImagePrefixConfigKey, test.prefix)
+			image, name := test.image, "my-name"
+			if err := DefaultImage(&image, &name)(&cobra.Command{}, nil); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if expected, actual := test.expect, image; expected != actual {
+				t.Errorf("Expected image %q, actually %q", expected, actual)
+			}
+		})
+	}
+}
+
+func TestConfigValues(t *testing.T) {
+	settings := map[string]interface{}{
+		"namespace": "my-namespace",
+	}
+
+	SetConfigValue(settings, "profiles.staging.namespace", "staging")
+	SetConfigValue(settings, "profiles.staging.runtime", "core")
+	if value, ok := GetConfigValue(settings, "profiles.staging.namespace"); !ok || value != "staging" {
+		t.Errorf("Expected staging namespace, actually %v", value)
+	}
+	if _, ok := GetConfigValue(settings, "profiles.prod.namespace"); ok {
+		t.Errorf("Expected prod namespace to not be set")
+	}
+
+	if UnsetConfigValue(settings, "profiles.prod.namespace") {
+		t.Errorf("Expected unset of missing key to return false")
+	}
+	if !UnsetConfigValue(settings, "profiles.staging.namespace") {
+		t.Errorf("Expected unset to return true")
+	}
+	if !UnsetConfigValue(settings, "profiles.staging.runtime") {
+		t.Errorf("Expected unset to return true")
+	}
+	expected := map[string]interface{}{
+		"namespace": "my-namespace",
+	}
+	if diff := cmp.Diff(expected, settings); diff != "" {
+		t.Errorf("Unexpected settings (-expected, +actual): %s", diff)
+	}
+}
+
+func TestFindProjectConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "riff-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	project := filepath.Join(dir, "project")
+	nested := filepath.Join(project, "a", "b")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(project, ".riff.yaml"), []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected, actual := filepath.Join(project, ".riff.yaml"), findProjectConfigFile(nested, "", ".riff.yaml"); expected != actual {
+		t.Errorf("Expected project config file %q, actually %q", expected, actual)
+	}
+	// the search stops at the home directory
+	if expected, actual := "", findProjectConfigFile(nested, project, ".riff.yaml"); expected != actual {
+		t.Errorf("Expected project config file %q, actually %q", expected, actual)
+	}
+	if expected, actual := "", findProjectConfigFile(dir, "", ".riff.yaml"); expected != actual {
+		t.Errorf("Expected project config file %q, actually %q", expected, actual)
+	}
+}
@@ -51,7 +51,8 @@ func defaultTypeMeta(resource runtime.Object, gvk schema.GroupVersionKind) runti
 
 type stdoutKey struct{}
 
-func withStdout(ctx context.Context, stdout io.Writer) context.Context {
+// WithStdout sets the writer resources are printed to by DryRunResource
+func WithStdout(ctx context.Context, stdout io.Writer) context.Context {
 	return context.WithValue(ctx, stdoutKey{}, stdout)
 }
 
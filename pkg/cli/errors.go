@@ -16,6 +16,22 @@
 
 package cli
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
 var SilentError = &silentError{}
 
 type silentError struct {
@@ -38,3 +54,225 @@ func (e *silentError) Is(err error) bool {
 func SilenceError(err error) error {
 	return &silentError{err: err}
 }
+
+const (
+	// ExitCodeError is the process exit code for a command that failed
+	ExitCodeError = 1
+	// ExitCodeTimeout is the process exit code for a command that gave up waiting
+	ExitCodeTimeout = 2
+	// ExitCodeConditionFailed is the process exit code for a command that observed a resource
+	// reach a state that will not satisfy the command
+	ExitCodeConditionFailed = 3
+	// ExitCodeInvalid is the process exit code for a command with invalid arguments, flags or
+	// config
+	ExitCodeInvalid = 4
+	// ExitCodeNotFound is the process exit code for a command that referenced a resource that
+	// does not exist
+	ExitCodeNotFound = 5
+	// ExitCodeConflict is the process exit code for a command that created a resource that
+	// already exists or updated a resource that was modified concurrently
+	ExitCodeConflict = 6
+	// ExitCodeForbidden is the process exit code for a command whose request was not authorized
+	ExitCodeForbidden = 7
+)
+
+// ErrorCategory groups the errors that exit with the same process exit code
+type ErrorCategory struct {
+	Code        string
+	ExitCode    int
+	Description string
+}
+
+// ErrorCategories lists the categories of errors ordered by exit code
+var ErrorCategories = []ErrorCategory{
+	{Code: "Error", ExitCode: ExitCodeError, Description: "the command failed"},
+	{Code: "Timeout", ExitCode: ExitCodeTimeout, Description: "the command gave up waiting"},
+	{Code: "ConditionFailed", ExitCode: ExitCodeConditionFailed, Description: "a resource reached a state that fails the command"},
+	{Code: "Invalid", ExitCode: ExitCodeInvalid, Description: "invalid arguments, flags or config"},
+	{Code: "NotFound", ExitCode: ExitCodeNotFound, Description: "a resource does not exist"},
+	{Code: "Conflict", ExitCode: ExitCodeConflict, Description: "a resource already exists or was modified concurrently"},
+	{Code: "Forbidden", ExitCode: ExitCodeForbidden, Description: "the request was not authorized"},
+}
+
+type exitCodeError struct {
+	err  error
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// WithExitCode associates a process exit code with the error.
+func WithExitCode(err error, code int) error {
+	return &exitCodeError{err: err, code: code}
+}
+
+// ExitCode resolves the process exit code for an error. Errors without an explicit exit code
+// are categorized by their field errors or the reason of the Kubernetes API status, other errors
+// map to ExitCodeError.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var e *exitCodeError
+	if errors.As(err, &e) {
+		return e.code
+	}
+	if len(fieldErrors(err)) != 0 {
+		return ExitCodeInvalid
+	}
+	switch statusReason(err) {
+	case metav1.StatusReasonInvalid:
+		return ExitCodeInvalid
+	case metav1.StatusReasonNotFound:
+		return ExitCodeNotFound
+	case metav1.StatusReasonAlreadyExists, metav1.StatusReasonConflict:
+		return ExitCodeConflict
+	case metav1.StatusReasonForbidden, metav1.StatusReasonUnauthorized:
+		return ExitCodeForbidden
+	}
+	return ExitCodeError
+}
+
+// ErrorCode names the category of the error, see ErrorCategories
+func ErrorCode(err error) string {
+	code := ExitCode(err)
+	for _, category := range ErrorCategories {
+		if category.ExitCode == code {
+			return category.Code
+		}
+	}
+	return ErrorCategories[0].Code
+}
+
+func fieldErrors(err error) []*field.Error {
+	var aggregate utilerrors.Aggregate
+	if !errors.As(err, &aggregate) {
+		return nil
+	}
+	errs := []*field.Error{}
+	for _, err := range aggregate.Errors() {
+		var fieldErr *field.Error
+		if errors.As(err, &fieldErr) {
+			errs = append(errs, fieldErr)
+		}
+	}
+	return errs
+}
+
+func statusReason(err error) metav1.StatusReason {
+	var status apierrs.APIStatus
+	if !errors.As(err, &status) {
+		return metav1.StatusReasonUnknown
+	}
+	return status.Status().Reason
+}
+
+const (
+	// TextErrorFormat reports errors as indented text
+	TextErrorFormat = "text"
+	// JSONErrorFormat reports errors as an ErrorDocument
+	JSONErrorFormat = "json"
+)
+
+// ErrorDocument is the structured form of an error for automation
+type ErrorDocument struct {
+	// Code names the category of the error, see ErrorCategories
+	Code string `json:"code"`
+	// ExitCode is the process exit code for the error
+	ExitCode int `json:"exitCode"`
+	// Message describes the error
+	Message string `json:"message"`
+	// Reason is the reason of the Kubernetes API status for the error, if any
+	Reason metav1.StatusReason `json:"reason,omitempty"`
+	// Fields are the invalid flags or fields
+	Fields []FieldErrorDocument `json:"fields,omitempty"`
+}
+
+// FieldErrorDocument is the structured form of an invalid flag or field
+type FieldErrorDocument struct {
+	// Field is the path of the flag or field, like "--image"
+	Field string `json:"field"`
+	// Type of the field error, like "FieldValueRequired"
+	Type field.ErrorType `json:"type"`
+	// Detail explains the field error, if any
+	Detail string `json:"detail,omitempty"`
+	// Message describes the field error
+	Message string `json:"message"`
+}
+
+// NewErrorDocument creates the structured form of the error
+func NewErrorDocument(err error) ErrorDocument {
+	doc := ErrorDocument{
+		Code:     ErrorCode(err),
+		ExitCode: ExitCode(err),
+		Message:  err.Error(),
+		Reason:   statusReason(err),
+	}
+	for _, fieldErr := range fieldErrors(err) {
+		doc.Fields = append(doc.Fields, FieldErrorDocument{
+			Field:   fieldErr.Field,
+			Type:    fieldErr.Type,
+			Detail:  fieldErr.Detail,
+			Message: fieldErr.Error(),
+		})
+	}
+	return doc
+}
+
+// InitErrorFormat resolves the error format before the command executes, so errors parsing flags
+// and arguments are reported in the format set by the flag in args, or else by the config file.
+// Usage is not printed with JSON errors.
+func (c *Config) InitErrorFormat(cmd *cobra.Command, args []string) {
+	flags := pflag.NewFlagSet(cmd.Name(), pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(ioutil.Discard)
+	flags.StringVar(&c.ViperConfigFile, StripDash(ConfigFlagName), c.ViperConfigFile, "")
+	flags.StringVar(&c.ErrorFormat, StripDash(ErrorFormatFlagName), c.ErrorFormat, "")
+	// help is handled by the command
+	flags.BoolP("help", "h", false, "")
+	_ = flags.Parse(args)
+
+	if !flags.Changed(StripDash(ErrorFormatFlagName)) {
+		if c.initViper != nil {
+			c.initViper()
+		}
+		if viper.IsSet(ErrorFormatConfigKey) {
+			c.ErrorFormat = viper.GetString(ErrorFormatConfigKey)
+		}
+	}
+	if c.ErrorFormat == JSONErrorFormat {
+		cmd.SilenceUsage = true
+	}
+}
+
+// PrintError reports the error for a failed command in the error format. Text errors are printed
+// indented to stdout, silent errors are skipped as the command has typically already logged the
+// error with more detail. JSON errors are always written to stderr as an ErrorDocument.
+func (c *Config) PrintError(err error) {
+	if c.ErrorFormat == JSONErrorFormat {
+		doc, _ := json.MarshalIndent(NewErrorDocument(err), "", "  ")
+		fmt.Fprintf(c.Stderr, "%s\n", doc)
+		return
+	}
+
+	if errors.Is(err, SilentError) {
+		return
+	}
+	c.Errorf("Error executing command:\n")
+	if aggregate, ok := err.(utilerrors.Aggregate); ok {
+		for _, err := range aggregate.Errors() {
+			c.Errorf("  %s\n", err.Error())
+		}
+	} else {
+		// errors can be multiple lines, indent each line
+		for _, line := range strings.Split(err.Error(), "\n") {
+			c.Errorf("  %s\n", line)
+		}
+	}
+}
@@ -17,11 +17,18 @@
 package cli_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"testing"
 
+	"github.com/fatih/color"
+	"github.com/google/go-cmp/cmp"
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestSilenceError(t *testing.T) {
@@ -41,3 +48,254 @@ func TestSilenceError(t *testing.T) {
 		t.Errorf("errors expected to match, expected %q, actually %q", expected, actual)
 	}
 }
+
+func TestExitCode(t *testing.T) {
+	err := fmt.Errorf("test error")
+
+	if expected, actual := 0, cli.ExitCode(nil); expected != actual {
+		t.Errorf("expected exit code %d, actually %d", expected, actual)
+	}
+	if expected, actual := cli.ExitCodeError, cli.ExitCode(err); expected != actual {
+		t.Errorf("expected exit code %d, actually %d", expected, actual)
+	}
+
+	timeoutErr := cli.WithExitCode(err, cli.ExitCodeTimeout)
+	if expected, actual := cli.ExitCodeTimeout, cli.ExitCode(timeoutErr); expected != actual {
+		t.Errorf("expected exit code %d, actually %d", expected, actual)
+	}
+	if expected, actual := cli.ExitCodeTimeout, cli.ExitCode(cli.SilenceError(timeoutErr)); expected != actual {
+		t.Errorf("expected exit code %d, actually %d", expected, actual)
+	}
+	if !errors.Is(cli.WithExitCode(cli.SilenceError(err), cli.ExitCodeTimeout), cli.SilentError) {
+		t.Errorf("expected error to be silent")
+	}
+	if expected, actual := err.Error(), timeoutErr.Error(); expected != actual {
+		t.Errorf("errors expected to match, expected %q, actually %q", expected, actual)
+	}
+}
+
+func TestExitCode_Categories(t *testing.T) {
+	resource := schema.GroupResource{Group: "build.projectriff.io", Resource: "functions"}
+	tests := []struct {
+		name     string
+		err      error
+		exitCode int
+		code     string
+	}{{
+		name:     "error",
+		err:      fmt.Errorf("test error"),
+		exitCode: cli.ExitCodeError,
+		code:     "Error",
+	}, {
+		name:     "explicit",
+		err:      cli.WithExitCode(apierrs.NewNotFound(resource, "my-function"), cli.ExitCodeConditionFailed),
+		exitCode: cli.ExitCodeConditionFailed,
+		code:     "ConditionFailed",
+	}, {
+		name:     "unknown explicit",
+		err:      cli.WithExitCode(fmt.Errorf("test error"), 42),
+		exitCode: 42,
+		code:     "Error",
+	}, {
+		name:     "field errors",
+		err:      cli.ErrMissingField(cli.ImageFlagName).ToAggregate(),
+		exitCode: cli.ExitCodeInvalid,
+		code:     "Invalid",
+	}, {
+		name:     "not found",
+		err:      apierrs.NewNotFound(resource, "my-function"),
+		exitCode: cli.ExitCodeNotFound,
+		code:     "NotFound",
+	}, {
+		name:     "wrapped not found",
+		err:      fmt.Errorf("wrapped: %w", apierrs.NewNotFound(resource, "my-function")),
+		exitCode: cli.ExitCodeNotFound,
+		code:     "NotFound",
+	}, {
+		name:     "already exists",
+		err:      apierrs.NewAlreadyExists(resource, "my-function"),
+		exitCode: cli.ExitCodeConflict,
+		code:     "Conflict",
+	}, {
+		name:     "conflict",
+		err:      apierrs.NewConflict(resource, "my-function", fmt.Errorf("modified")),
+		exitCode: cli.ExitCodeConflict,
+		code:     "Conflict",
+	}, {
+		name:     "forbidden",
+		err:      apierrs.NewForbidden(resource, "my-function", fmt.Errorf("denied")),
+		exitCode: cli.ExitCodeForbidden,
+		code:     "Forbidden",
+	}, {
+		name:     "unauthorized",
+		err:      apierrs.NewUnauthorized("denied"),
+		exitCode: cli.ExitCodeForbidden,
+		code:     "Forbidden",
+	}, {
+		name:     "server error",
+		err:      apierrs.NewInternalError(fmt.Errorf("boom")),
+		exitCode: cli.ExitCodeError,
+		code:     "Error",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if expected, actual := test.exitCode, cli.ExitCode(test.err); expected != actual {
+				t.Errorf("expected exit code %d, actually %d", expected, actual)
+			}
+			if expected, actual := test.code, cli.ErrorCode(test.err); expected != actual {
+				t.Errorf("expected error code %q, actually %q", expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewErrorDocument(t *testing.T) {
+	err := cli.ErrMissingField(cli.ImageFlagName).Also(
+		cli.ErrMultipleOneOf(cli.GitRepoFlagName, cli.LocalPathFlagName),
+	).ToAggregate()
+
+	expected := cli.ErrorDocument{
+		Code:     "Invalid",
+		ExitCode: cli.ExitCodeInvalid,
+		Message:  err.Error(),
+		Fields: []cli.FieldErrorDocument{
+			{Field: "--image", Type: "FieldValueRequired", Message: "--image: Required value"},
+			{Field: "[--git-repo, --local-path]", Type: "FieldValueRequired", Detail: "expected exactly one, got both", Message: "[--git-repo, --local-path]: Required value: expected exactly one, got both"},
+		},
+	}
+	if diff := cmp.Diff(expected, cli.NewErrorDocument(err)); diff != "" {
+		t.Errorf("Unexpected document (-expected, +actual): %s", diff)
+	}
+
+	notFound := apierrs.NewNotFound(schema.GroupResource{Group: "build.projectriff.io", Resource: "functions"}, "my-function")
+	expected = cli.ErrorDocument{
+		Code:     "NotFound",
+		ExitCode: cli.ExitCodeNotFound,
+		Message:  `functions.build.projectriff.io "my-function" not found`,
+		Reason:   "NotFound",
+	}
+	if diff := cmp.Diff(expected, cli.NewErrorDocument(notFound)); diff != "" {
+		t.Errorf("Unexpected document (-expected, +actual): %s", diff)
+	}
+}
+
+func TestPrintError(t *testing.T) {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+	color.NoColor = true
+
+	tests := []struct {
+		name   string
+		format string
+		err    error
+		stdout string
+		stderr string
+	}{{
+		name:   "text",
+		format: cli.TextErrorFormat,
+		err:    fmt.Errorf("first line\nsecond line"),
+		stdout: "Error executing command:\n  first line\n  second line\n",
+	}, {
+		name:   "text field errors",
+		format: cli.TextErrorFormat,
+		err:    cli.ErrMissingField(cli.ImageFlagName).Also(cli.ErrMissingField(cli.GitRepoFlagName)).ToAggregate(),
+		stdout: "Error executing command:\n  --image: Required value\n  --git-repo: Required value\n",
+	}, {
+		name:   "text silent",
+		format: cli.TextErrorFormat,
+		err:    cli.SilenceError(fmt.Errorf("test error")),
+	}, {
+		name:   "json",
+		format: cli.JSONErrorFormat,
+		err:    cli.ErrMissingField(cli.ImageFlagName).ToAggregate(),
+		stderr: `{
+  "code": "Invalid",
+  "exitCode": 4,
+  "message": "--image: Required value",
+  "fields": [
+    {
+      "field": "--image",
+      "type": "FieldValueRequired",
+      "message": "--image: Required value"
+    }
+  ]
+}
+`,
+	}, {
+		name:   "json silent",
+		format: cli.JSONErrorFormat,
+		err:    cli.WithExitCode(cli.SilenceError(fmt.Errorf("wait failed")), cli.ExitCodeTimeout),
+		stderr: `{
+  "code": "Timeout",
+  "exitCode": 2,
+  "message": "wait failed"
+}
+`,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			c := cli.NewDefaultConfig()
+			c.Stdout, c.Stderr = stdout, stderr
+			c.ErrorFormat = test.format
+
+			c.PrintError(test.err)
+
+			if diff := cmp.Diff(test.stdout, stdout.String()); diff != "" {
+				t.Errorf("Unexpected stdout (-expected, +actual): %s", diff)
+			}
+			if diff := cmp.Diff(test.stderr, stderr.String()); diff != "" {
+				t.Errorf("Unexpected stderr (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
+func TestInitErrorFormat(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		config       string
+		format       string
+		silenceUsage bool
+	}{{
+		name:   "default",
+		args:   []string{"function", "list"},
+		format: cli.TextErrorFormat,
+	}, {
+		name:         "flag",
+		args:         []string{"function", "list", "--unknown", cli.ErrorFormatFlagName, cli.JSONErrorFormat},
+		format:       cli.JSONErrorFormat,
+		silenceUsage: true,
+	}, {
+		name:         "config",
+		args:         []string{"function", "delete"},
+		config:       cli.JSONErrorFormat,
+		format:       cli.JSONErrorFormat,
+		silenceUsage: true,
+	}, {
+		name:   "flag overrides config",
+		args:   []string{"function", "list", cli.ErrorFormatFlagName, cli.TextErrorFormat},
+		config: cli.JSONErrorFormat,
+		format: cli.TextErrorFormat,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer viper.Reset()
+			if test.config != "" {
+				viper.Set(cli.ErrorFormatConfigKey, test.config)
+			}
+			c := cli.NewDefaultConfig()
+			c.ErrorFormat = cli.TextErrorFormat
+			cmd := &cobra.Command{Use: "riff"}
+
+			c.InitErrorFormat(cmd, test.args)
+
+			if expected, actual := test.format, c.ErrorFormat; expected != actual {
+				t.Errorf("expected error format %q, actually %q", expected, actual)
+			}
+			if expected, actual := test.silenceUsage, cmd.SilenceUsage; expected != actual {
+				t.Errorf("expected silence usage %v, actually %v", expected, actual)
+			}
+		})
+	}
+}
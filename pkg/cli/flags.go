@@ -23,57 +23,100 @@ import (
 )
 
 const (
-	AllFlagName                   = "--all"
-	AllNamespacesFlagName         = "--all-namespaces"
-	ApplicationRefFlagName        = "--application-ref"
-	ArtifactFlagName              = "--artifact"
-	BootstrapServersFlagName      = "--bootstrap-servers"
-	CacheSizeFlagName             = "--cache-size"
-	ContainerConcurrencyFlagName  = "--container-concurrency"
-	ContainerNameFlagName         = "--container-name"
-	ConfigFlagName                = "--config"
-	ConfigurationRefFlagName      = "--configuration-ref"
-	ContainerRefFlagName          = "--container-ref"
-	ContentTypeFlagName           = "--content-type"
-	DefaultImagePrefixFlagName    = "--default-image-prefix"
-	DirectoryFlagName             = "--directory"
-	DockerHubFlagName             = "--docker-hub"
-	DryRunFlagName                = "--dry-run"
-	EnvFlagName                   = "--env"
-	EnvFromFlagName               = "--env-from"
-	FunctionRefFlagName           = "--function-ref"
-	GatewayFlagName               = "--gateway"
-	GcrFlagName                   = "--gcr"
-	GitRepoFlagName               = "--git-repo"
-	GitRevisionFlagName           = "--git-revision"
-	HandlerFlagName               = "--handler"
-	ImageFlagName                 = "--image"
-	IngressPolicyFlagName         = "--ingress-policy"
-	InputFlagName                 = "--input"
-	InvokerFlagName               = "--invoker"
-	KubeConfigFlagName            = "--kubeconfig"
-	KubeConfigFlagNameDeprecated  = "--kube-config"
-	LimitCPUFlagName              = "--limit-cpu"
-	LimitMemoryFlagName           = "--limit-memory"
-	LocalPathFlagName             = "--local-path"
-	MaxScaleFlagName              = "--max-scale"
-	MinScaleFlagName              = "--min-scale"
-	NamespaceFlagName             = "--namespace"
-	NoColorFlagName               = "--no-color"
-	OutputFlagName                = "--output"
-	ProviderFlagName              = "--provider"
-	RegistryFlagName              = "--registry"
-	RegistryUserFlagName          = "--registry-user"
-	ServiceRefFlagName            = "--service-ref"
-	ServiceURLFlagName            = "--service-url"
-	SetDefaultImagePrefixFlagName = "--set-default-image-prefix"
-	ShellFlagName                 = "--shell"
-	SinceFlagName                 = "--since"
-	SubjectFlagName               = "--subject"
-	SubPathFlagName               = "--sub-path"
-	TailFlagName                  = "--tail"
-	TargetPortFlagName            = "--target-port"
-	WaitTimeoutFlagName           = "--wait-timeout"
+	AllFlagName                            = "--all"
+	AllNamespacesFlagName                  = "--all-namespaces"
+	ApplicationRefFlagName                 = "--application-ref"
+	ArgFlagName                            = "--arg"
+	ArtifactFlagName                       = "--artifact"
+	AsFlagName                             = "--as"
+	AsGroupFlagName                        = "--as-group"
+	BindingModeFlagName                    = "--binding-mode"
+	BootstrapServersFlagName               = "--bootstrap-servers"
+	CacheSizeFlagName                      = "--cache-size"
+	ClusterFlagName                        = "--cluster"
+	CommandFlagName                        = "--command"
+	ContainerConcurrencyFlagName           = "--container-concurrency"
+	ContainerNameFlagName                  = "--container-name"
+	ConfigFlagName                         = "--config"
+	ConfigurationRefFlagName               = "--configuration-ref"
+	ContainerRefFlagName                   = "--container-ref"
+	ContentTypeFlagName                    = "--content-type"
+	ContextFlagName                        = "--context"
+	DefaultImagePrefixFlagName             = "--default-image-prefix"
+	DirectoryFlagName                      = "--directory"
+	DockerHubFlagName                      = "--docker-hub"
+	DryRunFlagName                         = "--dry-run"
+	EmptyDirFlagName                       = "--empty-dir"
+	EnvFileFlagName                        = "--env-file"
+	EnvFlagName                            = "--env"
+	EnvFromConfigMapFlagName               = "--env-from-configmap"
+	EnvFromFlagName                        = "--env-from"
+	EnvFromSecretFlagName                  = "--env-from-secret"
+	ErrorFormatFlagName                    = "--error-format"
+	ForFlagName                            = "--for"
+	FunctionRefFlagName                    = "--function-ref"
+	GatewayFlagName                        = "--gateway"
+	GcrFlagName                            = "--gcr"
+	GenerateRBACFlagName                   = "--generate-rbac"
+	GitRepoFlagName                        = "--git-repo"
+	GitRevisionFlagName                    = "--git-revision"
+	HandlerFlagName                        = "--handler"
+	HealthFlagName                         = "--health"
+	ImageFlagName                          = "--image"
+	IngressPolicyFlagName                  = "--ingress-policy"
+	InputFlagName                          = "--input"
+	InteractiveFlagName                    = "--interactive"
+	InvokerFlagName                        = "--invoker"
+	KubeConfigFlagName                     = "--kubeconfig"
+	KubeConfigFlagNameDeprecated           = "--kube-config"
+	LimitCPUFlagName                       = "--limit-cpu"
+	LimitMemoryFlagName                    = "--limit-memory"
+	LivenessProbeFailureThresholdFlagName  = "--liveness-probe-failure-threshold"
+	LivenessProbeFlagName                  = "--liveness-probe"
+	LivenessProbeInitialDelayFlagName      = "--liveness-probe-initial-delay"
+	LivenessProbePeriodFlagName            = "--liveness-probe-period"
+	LivenessProbeSuccessThresholdFlagName  = "--liveness-probe-success-threshold"
+	LivenessProbeTimeoutFlagName           = "--liveness-probe-timeout"
+	LocalPathFlagName                      = "--local-path"
+	MaxScaleFlagName                       = "--max-scale"
+	MetadataFlagName                       = "--metadata"
+	MinScaleFlagName                       = "--min-scale"
+	MountConfigMapFlagName                 = "--mount-configmap"
+	MountSecretFlagName                    = "--mount-secret"
+	NamespaceFlagName                      = "--namespace"
+	NoColorFlagName                        = "--no-color"
+	OutputFlagName                         = "--output"
+	ProfileFlagName                        = "--profile"
+	ProjectFlagName                        = "--project"
+	ProviderFlagName                       = "--provider"
+	ReadinessProbeFailureThresholdFlagName = "--readiness-probe-failure-threshold"
+	ReadinessProbeFlagName                 = "--readiness-probe"
+	ReadinessProbeInitialDelayFlagName     = "--readiness-probe-initial-delay"
+	ReadinessProbePeriodFlagName           = "--readiness-probe-period"
+	ReadinessProbeSuccessThresholdFlagName = "--readiness-probe-success-threshold"
+	ReadinessProbeTimeoutFlagName          = "--readiness-probe-timeout"
+	RegistryFlagName                       = "--registry"
+	RegistryUserFlagName                   = "--registry-user"
+	RequestTimeoutFlagName                 = "--request-timeout"
+	RestoreImageFlagName                   = "--restore-image"
+	SecretFlagName                         = "--secret"
+	ServerFlagName                         = "--server"
+	ServiceAccountFlagName                 = "--service-account"
+	ServiceRefFlagName                     = "--service-ref"
+	ServiceURLFlagName                     = "--service-url"
+	SetDefaultImagePrefixFlagName          = "--set-default-image-prefix"
+	ShellFlagName                          = "--shell"
+	SinceFlagName                          = "--since"
+	SubjectFlagName                        = "--subject"
+	SubjectSelectorFlagName                = "--subject-selector"
+	SubPathFlagName                        = "--sub-path"
+	TailFlagName                           = "--tail"
+	TargetPortFlagName                     = "--target-port"
+	TerminationGracePeriodFlagName         = "--termination-grace-period"
+	TimeoutFlagName                        = "--timeout"
+	TokenFlagName                          = "--token"
+	UserFlagName                           = "--user"
+	WaitTimeoutFlagName                    = "--wait-timeout"
 )
 
 func AllNamespacesFlag(cmd *cobra.Command, c *Config, namespace *string, allNamespaces *bool) {
@@ -114,7 +157,7 @@ func NamespaceFlag(cmd *cobra.Command, c *Config, namespace *string) {
 	}
 
 	cmd.Flags().StringVarP(namespace, StripDash(NamespaceFlagName), "n", "", "kubernetes `name`space (defaulted from kube config)")
-	_ = cmd.MarkFlagCustom(StripDash(NamespaceFlagName), "__"+c.Name+"_list_namespaces")
+	_ = cmd.RegisterFlagCompletionFunc(StripDash(NamespaceFlagName), CompleteNames(c, ListNamespaces))
 }
 
 func StripDash(flagName string) string {
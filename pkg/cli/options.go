@@ -62,7 +62,7 @@ func ExecOptions(ctx context.Context, c *Config, opts Executable) func(cmd *cobr
 		ctx := WithCommand(ctx, cmd)
 		if o, ok := opts.(DryRunable); ok && o.IsDryRun() {
 			// reserve Stdout for resources, redirect normal stdout to stderr
-			ctx = withStdout(ctx, c.Stdout)
+			ctx = WithStdout(ctx, c.Stdout)
 			c.Stdout = c.Stderr
 		}
 		return opts.Exec(ctx, c)
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/parsers"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// EnvFileOptions are the dotenv formatted files environment variables are read from
+type EnvFileOptions struct {
+	EnvFiles []string
+}
+
+func (opts *EnvFileOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	for i, path := range opts.EnvFiles {
+		if path == "" {
+			errs = errs.Also(cli.ErrInvalidArrayValue(path, cli.EnvFileFlagName, i))
+		}
+	}
+
+	return errs
+}
+
+// EnvFromFiles reads the variables from the env files, in order. An error is returned for the
+// first file that cannot be read or parsed.
+func (opts *EnvFileOptions) EnvFromFiles() ([]corev1.EnvVar, error) {
+	env := []corev1.EnvVar{}
+	for _, path := range opts.EnvFiles {
+		fileEnv, err := parsers.EnvFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s %q: %w", cli.EnvFileFlagName, path, err)
+		}
+		env = append(env, fileEnv...)
+	}
+	return env, nil
+}
+
+func (opts *EnvFileOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&opts.EnvFiles, cli.StripDash(cli.EnvFileFlagName), []string{}, "`path` to a file of environment variables in the dotenv format, one KEY=VALUE per line (may be set multiple times)")
+}
@@ -19,9 +19,11 @@ package options_test
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestListOptions(t *testing.T) {
@@ -156,3 +158,206 @@ func TestDeleteOptions(t *testing.T) {
 
 	table.Run(t)
 }
+
+func TestWorkloadOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name:           "default",
+			Options:        &options.WorkloadOptions{},
+			ShouldValidate: true,
+		},
+		{
+			Name: "valid",
+			Options: &options.WorkloadOptions{
+				Command:                "/bin/server",
+				Args:                   []string{"--verbose"},
+				TerminationGracePeriod: "60s",
+				ReadinessProbe: options.ProbeOptions{
+					Handler:          "http:/ready",
+					InitialDelay:     "5s",
+					Period:           "10s",
+					Timeout:          "2s",
+					SuccessThreshold: 2,
+					FailureThreshold: 5,
+				},
+				LivenessProbe: options.ProbeOptions{
+					Handler:          "tcp:8080",
+					SuccessThreshold: 1,
+				},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid termination grace period",
+			Options: &options.WorkloadOptions{
+				TerminationGracePeriod: "1.5s",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("1.5s", cli.TerminationGracePeriodFlagName),
+		},
+		{
+			Name: "invalid probe",
+			Options: &options.WorkloadOptions{
+				ReadinessProbe: options.ProbeOptions{
+					Handler:          "grpc",
+					InitialDelay:     "-1s",
+					Period:           "0s",
+					Timeout:          "soon",
+					SuccessThreshold: -1,
+					FailureThreshold: -1,
+				},
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidValue("grpc", cli.ReadinessProbeFlagName),
+				cli.ErrInvalidValue("-1s", cli.ReadinessProbeInitialDelayFlagName),
+				cli.ErrInvalidValue("0s", cli.ReadinessProbePeriodFlagName),
+				cli.ErrInvalidValue("soon", cli.ReadinessProbeTimeoutFlagName),
+				cli.ErrInvalidValue("-1", cli.ReadinessProbeSuccessThresholdFlagName),
+				cli.ErrInvalidValue("-1", cli.ReadinessProbeFailureThresholdFlagName),
+			),
+		},
+		{
+			Name: "probe settings without action",
+			Options: &options.WorkloadOptions{
+				LivenessProbe: options.ProbeOptions{
+					Period: "10s",
+				},
+			},
+			ExpectFieldErrors: cli.ErrMissingField(cli.LivenessProbeFlagName),
+		},
+		{
+			Name: "liveness success threshold",
+			Options: &options.WorkloadOptions{
+				LivenessProbe: options.ProbeOptions{
+					Handler:          "exec:cat /tmp/healthy",
+					SuccessThreshold: 2,
+				},
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("2", cli.LivenessProbeSuccessThresholdFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestVolumeOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name:           "default",
+			Options:        &options.VolumeOptions{},
+			ShouldValidate: true,
+		},
+		{
+			Name: "valid",
+			Options: &options.VolumeOptions{
+				MountConfigMaps: []string{"my-config:/etc/config"},
+				MountSecrets:    []string{"my-tls:/etc/tls"},
+				EmptyDirs:       []string{"/tmp/cache"},
+				ServiceAccount:  "my-service-account",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid",
+			Options: &options.VolumeOptions{
+				MountConfigMaps: []string{"my-config"},
+				MountSecrets:    []string{"my-tls:etc/tls"},
+				EmptyDirs:       []string{"tmp"},
+				ServiceAccount:  "My_Account",
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidValue("my-config", cli.CurrentField).ViaFieldIndex(cli.MountConfigMapFlagName, 0),
+				cli.ErrInvalidValue("my-tls:etc/tls", cli.CurrentField).ViaFieldIndex(cli.MountSecretFlagName, 0),
+				cli.ErrInvalidValue("tmp", cli.CurrentField).ViaFieldIndex(cli.EmptyDirFlagName, 0),
+				cli.ErrInvalidValue("My_Account", cli.ServiceAccountFlagName),
+			),
+		},
+		{
+			Name: "conflicting paths",
+			Options: &options.VolumeOptions{
+				MountConfigMaps: []string{"my-config:/etc/config", "my-other-config:/etc/config"},
+				MountSecrets:    []string{"my-tls:/etc/config/tls"},
+				EmptyDirs:       []string{"/etc"},
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidValue("/etc/config conflicts with /etc/config", cli.CurrentField).ViaFieldIndex(cli.MountConfigMapFlagName, 1),
+				cli.ErrInvalidValue("/etc/config/tls conflicts with /etc/config", cli.CurrentField).ViaFieldIndex(cli.MountSecretFlagName, 0),
+				cli.ErrInvalidValue("/etc conflicts with /etc/config", cli.CurrentField).ViaFieldIndex(cli.EmptyDirFlagName, 0),
+			),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestEnvFileOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name:           "default",
+			Options:        &options.EnvFileOptions{},
+			ShouldValidate: true,
+		},
+		{
+			Name: "valid",
+			Options: &options.EnvFileOptions{
+				EnvFiles: []string{"../../parsers/testdata/app.env"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "empty path",
+			Options: &options.EnvFileOptions{
+				EnvFiles: []string{"../../parsers/testdata/app.env", ""},
+			},
+			ExpectFieldErrors: cli.ErrInvalidArrayValue("", cli.EnvFileFlagName, 1),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestEnvFileOptions_EnvFromFiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		envFiles    []string
+		expected    []corev1.EnvVar
+		expectedErr string
+	}{{
+		name:     "none",
+		expected: []corev1.EnvVar{},
+	}, {
+		name:     "files",
+		envFiles: []string{"../../parsers/testdata/app.env", "../../parsers/testdata/app.env"},
+		expected: []corev1.EnvVar{
+			{Name: "MY_VAR1", Value: "value1"},
+			{Name: "MY_VAR2", Value: "multi\nline"},
+			{Name: "MY_VAR1", Value: "value1"},
+			{Name: "MY_VAR2", Value: "multi\nline"},
+		},
+	}, {
+		name:        "invalid file",
+		envFiles:    []string{"../../parsers/testdata/app.env", "../../parsers/testdata/invalid.env"},
+		expectedErr: `unable to read --env-file "../../parsers/testdata/invalid.env": line 2: expected KEY=VALUE, found "MY_VAR2"`,
+	}, {
+		name:        "missing file",
+		envFiles:    []string{"../../parsers/testdata/missing.env"},
+		expectedErr: `unable to read --env-file "../../parsers/testdata/missing.env": open ../../parsers/testdata/missing.env: no such file or directory`,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := &options.EnvFileOptions{EnvFiles: test.envFiles}
+			actual, err := opts.EnvFromFiles()
+			if test.expectedErr != "" {
+				if err == nil || err.Error() != test.expectedErr {
+					t.Errorf("Expected error %q, actually %v", test.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("Unexpected env (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
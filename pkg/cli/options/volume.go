@@ -0,0 +1,159 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/validation"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// VolumeOptions are the volumes mounted into a workload and the identity it runs as
+type VolumeOptions struct {
+	MountConfigMaps []string
+	MountSecrets    []string
+	EmptyDirs       []string
+	ServiceAccount  string
+}
+
+func (opts *VolumeOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(validation.Mounts(opts.MountConfigMaps, cli.MountConfigMapFlagName))
+	errs = errs.Also(validation.Mounts(opts.MountSecrets, cli.MountSecretFlagName))
+	errs = errs.Also(validation.MountPaths(opts.EmptyDirs, cli.EmptyDirFlagName))
+
+	// mount paths may not be reused or nested within another mount
+	type mountRef struct {
+		path  string
+		field string
+		index int
+	}
+	mounts := []mountRef{}
+	for i, mount := range opts.MountConfigMaps {
+		mounts = append(mounts, mountRef{path: mountPath(mount), field: cli.MountConfigMapFlagName, index: i})
+	}
+	for i, mount := range opts.MountSecrets {
+		mounts = append(mounts, mountRef{path: mountPath(mount), field: cli.MountSecretFlagName, index: i})
+	}
+	for i, path := range opts.EmptyDirs {
+		mounts = append(mounts, mountRef{path: path, field: cli.EmptyDirFlagName, index: i})
+	}
+	for i, mount := range mounts {
+		for _, other := range mounts[:i] {
+			if mount.path == "" || other.path == "" {
+				continue
+			}
+			if mount.path == other.path || strings.HasPrefix(mount.path, other.path+"/") || strings.HasPrefix(other.path, mount.path+"/") {
+				errs = errs.Also(cli.ErrInvalidValue(fmt.Sprintf("%s conflicts with %s", mount.path, other.path), cli.CurrentField).ViaFieldIndex(mount.field, mount.index))
+				break
+			}
+		}
+	}
+
+	if opts.ServiceAccount != "" {
+		errs = errs.Also(validation.K8sName(opts.ServiceAccount, cli.ServiceAccountFlagName))
+	}
+
+	return errs
+}
+
+// ApplyTo adds the volumes to the pod spec and mounts them into the first container
+func (opts *VolumeOptions) ApplyTo(spec *corev1.PodSpec) {
+	container := &spec.Containers[0]
+
+	for i, mount := range opts.MountConfigMaps {
+		name := fmt.Sprintf("configmap-%d", i)
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: mountName(mount)},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: mountPath(mount),
+			ReadOnly:  true,
+		})
+	}
+	for i, mount := range opts.MountSecrets {
+		name := fmt.Sprintf("secret-%d", i)
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: mountName(mount),
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: mountPath(mount),
+			ReadOnly:  true,
+		})
+	}
+	for i, path := range opts.EmptyDirs {
+		name := fmt.Sprintf("empty-dir-%d", i)
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: path,
+		})
+	}
+
+	if opts.ServiceAccount != "" {
+		spec.ServiceAccountName = opts.ServiceAccount
+	}
+}
+
+func (opts *VolumeOptions) AddFlags(cmd *cobra.Command) {
+	opts.AddKnativeFlags(cmd)
+	cmd.Flags().StringArrayVar(&opts.EmptyDirs, cli.StripDash(cli.EmptyDirFlagName), []string{}, "absolute `path` to mount an empty scratch directory (may be set multiple times)")
+}
+
+// AddKnativeFlags registers the flags for the volumes a Knative workload accepts, Knative does
+// not allow empty dir volumes.
+func (opts *VolumeOptions) AddKnativeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&opts.MountConfigMaps, cli.StripDash(cli.MountConfigMapFlagName), []string{}, fmt.Sprintf("config map to mount read only as files, in the form `<name>:<path>`, example %q (may be set multiple times)", fmt.Sprintf("%s my-config-map:/etc/config", cli.MountConfigMapFlagName)))
+	cmd.Flags().StringArrayVar(&opts.MountSecrets, cli.StripDash(cli.MountSecretFlagName), []string{}, fmt.Sprintf("secret to mount read only as files, in the form `<name>:<path>`, example %q (may be set multiple times)", fmt.Sprintf("%s my-secret:/etc/tls", cli.MountSecretFlagName)))
+	cmd.Flags().StringVar(&opts.ServiceAccount, cli.StripDash(cli.ServiceAccountFlagName), "", "`name` of the service account the workload runs as")
+}
+
+func mountName(mount string) string {
+	return strings.SplitN(mount, ":", 2)[0]
+}
+
+// mountPath returns the path of a mount, or an empty string for malformed mounts
+func mountPath(mount string) string {
+	parts := strings.SplitN(mount, ":", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	return parts[1]
+}
@@ -0,0 +1,230 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/parsers"
+	"github.com/projectriff/cli/pkg/validation"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultPort is the port riff workloads listen on when a target port is not specified
+const DefaultPort = int32(8080)
+
+// WorkloadOptions are the container lifecycle settings shared by deployers
+type WorkloadOptions struct {
+	Command                string
+	Args                   []string
+	TerminationGracePeriod string
+
+	ReadinessProbe ProbeOptions
+	LivenessProbe  ProbeOptions
+}
+
+type ProbeOptions struct {
+	Handler          string
+	InitialDelay     string
+	Period           string
+	Timeout          string
+	SuccessThreshold int32
+	FailureThreshold int32
+}
+
+type probeFlagNames struct {
+	handler, initialDelay, period, timeout, successThreshold, failureThreshold string
+}
+
+var (
+	readinessProbeFlagNames = probeFlagNames{
+		handler:          cli.ReadinessProbeFlagName,
+		initialDelay:     cli.ReadinessProbeInitialDelayFlagName,
+		period:           cli.ReadinessProbePeriodFlagName,
+		timeout:          cli.ReadinessProbeTimeoutFlagName,
+		successThreshold: cli.ReadinessProbeSuccessThresholdFlagName,
+		failureThreshold: cli.ReadinessProbeFailureThresholdFlagName,
+	}
+	livenessProbeFlagNames = probeFlagNames{
+		handler:          cli.LivenessProbeFlagName,
+		initialDelay:     cli.LivenessProbeInitialDelayFlagName,
+		period:           cli.LivenessProbePeriodFlagName,
+		timeout:          cli.LivenessProbeTimeoutFlagName,
+		successThreshold: cli.LivenessProbeSuccessThresholdFlagName,
+		failureThreshold: cli.LivenessProbeFailureThresholdFlagName,
+	}
+)
+
+func (opts *WorkloadOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if opts.TerminationGracePeriod != "" {
+		errs = errs.Also(validation.WholeSeconds(opts.TerminationGracePeriod, 0, cli.TerminationGracePeriodFlagName))
+	}
+
+	errs = errs.Also(opts.validateProbes())
+
+	return errs
+}
+
+// ValidateKnative validates the workload settings for a Knative workload. Knative routes probes
+// to the container's port, so a probe may not name a port.
+func (opts *WorkloadOptions) ValidateKnative(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(opts.validateProbes())
+	if opts.ReadinessProbe.hasPort() {
+		errs = errs.Also(cli.ErrInvalidValue(opts.ReadinessProbe.Handler, cli.ReadinessProbeFlagName))
+	}
+	if opts.LivenessProbe.hasPort() {
+		errs = errs.Also(cli.ErrInvalidValue(opts.LivenessProbe.Handler, cli.LivenessProbeFlagName))
+	}
+
+	return errs
+}
+
+func (opts *WorkloadOptions) validateProbes() cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(opts.ReadinessProbe.validate(readinessProbeFlagNames))
+	errs = errs.Also(opts.LivenessProbe.validate(livenessProbeFlagNames))
+	if opts.LivenessProbe.SuccessThreshold > 1 {
+		// kubernetes requires liveness probes to succeed once
+		errs = errs.Also(cli.ErrInvalidValue(fmt.Sprint(opts.LivenessProbe.SuccessThreshold), cli.LivenessProbeSuccessThresholdFlagName))
+	}
+
+	return errs
+}
+
+func (opts *ProbeOptions) validate(names probeFlagNames) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if opts.Handler == "" {
+		if opts.InitialDelay != "" || opts.Period != "" || opts.Timeout != "" || opts.SuccessThreshold != 0 || opts.FailureThreshold != 0 {
+			errs = errs.Also(cli.ErrMissingField(names.handler))
+		}
+		return errs
+	}
+
+	errs = errs.Also(validation.ProbeHandler(opts.Handler, names.handler))
+	if opts.InitialDelay != "" {
+		errs = errs.Also(validation.WholeSeconds(opts.InitialDelay, 0, names.initialDelay))
+	}
+	if opts.Period != "" {
+		errs = errs.Also(validation.WholeSeconds(opts.Period, time.Second, names.period))
+	}
+	if opts.Timeout != "" {
+		errs = errs.Also(validation.WholeSeconds(opts.Timeout, time.Second, names.timeout))
+	}
+	if opts.SuccessThreshold < 0 {
+		errs = errs.Also(cli.ErrInvalidValue(fmt.Sprint(opts.SuccessThreshold), names.successThreshold))
+	}
+	if opts.FailureThreshold < 0 {
+		errs = errs.Also(cli.ErrInvalidValue(fmt.Sprint(opts.FailureThreshold), names.failureThreshold))
+	}
+
+	return errs
+}
+
+// ApplyTo sets the workload settings on the first container of the pod spec. Probes without an
+// explicit port target the port, the probe port is left unset when the port is zero.
+func (opts *WorkloadOptions) ApplyTo(spec *corev1.PodSpec, port int32) {
+	container := &spec.Containers[0]
+
+	if opts.Command != "" {
+		container.Command = []string{opts.Command}
+	}
+	if len(opts.Args) != 0 {
+		container.Args = append([]string{}, opts.Args...)
+	}
+	if opts.TerminationGracePeriod != "" {
+		// parse errors are handled by the opt validation
+		period := seconds(opts.TerminationGracePeriod)
+		spec.TerminationGracePeriodSeconds = &period
+	}
+	container.ReadinessProbe = opts.ReadinessProbe.probe(port)
+	container.LivenessProbe = opts.LivenessProbe.probe(port)
+}
+
+func (opts *ProbeOptions) probe(port int32) *corev1.Probe {
+	if opts.Handler == "" {
+		return nil
+	}
+	// parse errors are handled by the opt validation
+	return &corev1.Probe{
+		Handler:             parsers.ProbeHandler(opts.Handler, port),
+		InitialDelaySeconds: int32(seconds(opts.InitialDelay)),
+		PeriodSeconds:       int32(seconds(opts.Period)),
+		TimeoutSeconds:      int32(seconds(opts.Timeout)),
+		SuccessThreshold:    opts.SuccessThreshold,
+		FailureThreshold:    opts.FailureThreshold,
+	}
+}
+
+// hasPort reports whether the probe action names a port
+func (opts *ProbeOptions) hasPort() bool {
+	if opts.Handler == "" {
+		return false
+	}
+	handler := parsers.ProbeHandler(opts.Handler, 0)
+	switch {
+	case handler.HTTPGet != nil:
+		return handler.HTTPGet.Port.IntValue() != 0
+	case handler.TCPSocket != nil:
+		return handler.TCPSocket.Port.IntValue() != 0
+	}
+	return false
+}
+
+func (opts *WorkloadOptions) AddFlags(cmd *cobra.Command) {
+	opts.addFlags(cmd, []string{"http:[<port>:]<path>", "tcp[:<port>]", "exec:<command>"})
+	cmd.Flags().StringVar(&opts.TerminationGracePeriod, cli.StripDash(cli.TerminationGracePeriodFlagName), "", "`duration` the workload has to shut down gracefully before it is killed (default 30s)")
+}
+
+// AddKnativeFlags registers the flags for the settings a Knative workload accepts, see
+// ValidateKnative.
+func (opts *WorkloadOptions) AddKnativeFlags(cmd *cobra.Command) {
+	opts.addFlags(cmd, []string{"http:<path>", "tcp", "exec:<command>"})
+}
+
+func (opts *WorkloadOptions) addFlags(cmd *cobra.Command, actions []string) {
+	cmd.Flags().StringVar(&opts.Command, cli.StripDash(cli.CommandFlagName), "", "`executable` to run in the container, replacing the image's entrypoint")
+	cmd.Flags().StringArrayVar(&opts.Args, cli.StripDash(cli.ArgFlagName), []string{}, "`argument` passed to the command or the image's entrypoint (may be set multiple times)")
+	opts.ReadinessProbe.addFlags(cmd, "readiness", readinessProbeFlagNames, actions)
+	opts.LivenessProbe.addFlags(cmd, "liveness", livenessProbeFlagNames, actions)
+}
+
+func (opts *ProbeOptions) addFlags(cmd *cobra.Command, kind string, names probeFlagNames, actions []string) {
+	cmd.Flags().StringVar(&opts.Handler, cli.StripDash(names.handler), "", fmt.Sprintf("%s probe `action`, one of %q, %q or %q, the exec command is split on whitespace", kind, actions[0], actions[1], actions[2]))
+	cmd.Flags().StringVar(&opts.InitialDelay, cli.StripDash(names.initialDelay), "", fmt.Sprintf("`duration` after the container starts before the %s probe is run", kind))
+	cmd.Flags().StringVar(&opts.Period, cli.StripDash(names.period), "", fmt.Sprintf("`duration` between %s probes (default 10s)", kind))
+	cmd.Flags().StringVar(&opts.Timeout, cli.StripDash(names.timeout), "", fmt.Sprintf("`duration` after which the %s probe times out (default 1s)", kind))
+	cmd.Flags().Int32Var(&opts.SuccessThreshold, cli.StripDash(names.successThreshold), 0, fmt.Sprintf("consecutive `number` of successful %s probes to be considered healthy (default 1)", kind))
+	cmd.Flags().Int32Var(&opts.FailureThreshold, cli.StripDash(names.failureThreshold), 0, fmt.Sprintf("consecutive `number` of failed %s probes to be considered unhealthy (default 3)", kind))
+}
+
+func seconds(str string) int64 {
+	if str == "" {
+		return 0
+	}
+	d, _ := time.ParseDuration(str)
+	return int64(d / time.Second)
+}
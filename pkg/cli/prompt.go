@@ -0,0 +1,395 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type Promptable interface {
+	Prompt(ctx context.Context, p *Prompter) error
+}
+
+// PromptOptions bridges a cobra RunE function to the Promptable interface. When interactive is
+// set and stdin is a terminal, the options are prompted for missing values and the equivalent
+// non-interactive command is printed. Prompts are written to stderr so stdout is left for the
+// command's output, like the resources printed with --dry-run. Otherwise nothing is prompted and
+// the normal validation errors are reported for missing values. This function is typically
+// sequenced before ValidateOptions in the PreRunE phase of a command.
+//
+// ```
+// cmd := &cobra.Command{
+// 	   ...
+// 	   PreRunE: cli.Sequence(
+// 	       cli.PromptOptions(ctx, c, &opts.Interactive, opts),
+// 	       cli.ValidateOptions(ctx, opts),
+// 	   ),
+// }
+// ```
+func PromptOptions(ctx context.Context, c *Config, interactive *bool, opts Promptable) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if !*interactive {
+			return nil
+		}
+		if !c.StdinIsTerminal() {
+			c.Eerrorf("Warning: stdin is not a terminal, ignoring %s\n", InteractiveFlagName)
+			return nil
+		}
+		ctx := WithCommand(ctx, cmd)
+		p := c.NewPrompter(cmd)
+		if err := opts.Prompt(ctx, p); err != nil {
+			return err
+		}
+		c.Eprintf("\n")
+		c.Einfof("Equivalent command:\n")
+		c.Eprintf("  %s\n\n", CommandLine(cmd, append(args, p.args...)))
+		return nil
+	}
+}
+
+// StdinIsTerminal reports whether stdin is attached to an interactive terminal. Readers that are
+// not files may report for themselves by implementing `IsTerminal() bool`.
+func (c *Config) StdinIsTerminal() bool {
+	switch stdin := c.Stdin.(type) {
+	case *os.File:
+		return isatty.IsTerminal(stdin.Fd()) || isatty.IsCygwinTerminal(stdin.Fd())
+	case interface{ IsTerminal() bool }:
+		return stdin.IsTerminal()
+	}
+	return false
+}
+
+// Prompter asks for the values of a command's flags on the terminal. Answers are set on the
+// flags, as if they were passed on the command line.
+type Prompter struct {
+	c    *Config
+	cmd  *cobra.Command
+	in   *bufio.Reader
+	args []string
+}
+
+func (c *Config) NewPrompter(cmd *cobra.Command) *Prompter {
+	return &Prompter{
+		c:   c,
+		cmd: cmd,
+		in:  bufio.NewReader(c.Stdin),
+	}
+}
+
+// Printf prints a note between prompts.
+func (p *Prompter) Printf(format string, a ...interface{}) {
+	p.c.Eprintf(format, a...)
+}
+
+// PromptName asks for the name argument when it was not passed on the command line, see
+// InteractiveNameArg.
+func (p *Prompter) PromptName(label string, name *string) error {
+	if *name != "" {
+		return nil
+	}
+	value, err := p.Ask(label, "", false)
+	if err != nil {
+		return err
+	}
+	*name = value
+	p.args = append(p.args, value)
+	return nil
+}
+
+// Ask prompts for a free form value. An empty answer selects the default value, a value is
+// required unless optional is set.
+func (p *Prompter) Ask(label, defaultValue string, optional bool) (string, error) {
+	for {
+		if defaultValue != "" {
+			p.c.Eprintf("%s [%s]: ", label, defaultValue)
+		} else if optional {
+			p.c.Eprintf("%s (optional): ", label)
+		} else {
+			p.c.Eprintf("%s: ", label)
+		}
+		answer, err := p.readLine(label)
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = defaultValue
+		}
+		if answer != "" || optional {
+			return answer, nil
+		}
+		p.c.Eerrorf("A value is required\n")
+	}
+}
+
+// Choose prompts for one of the choices, either by number or by value. Values other than the
+// choices are accepted when other is set, which allows naming a resource that does not exist
+// yet.
+func (p *Prompter) Choose(label string, choices []string, defaultValue string, other bool) (string, error) {
+	if len(choices) == 0 {
+		return p.Ask(label, defaultValue, false)
+	}
+	p.c.Eprintf("%s:\n", label)
+	for i, choice := range choices {
+		p.c.Eprintf("  %d) %s\n", i+1, choice)
+	}
+	for {
+		answer, err := p.Ask("Choose", defaultValue, false)
+		if err != nil {
+			return "", err
+		}
+		if choice, ok := p.choice(answer, choices, other); ok {
+			return choice, nil
+		}
+		p.c.Eerrorf("Invalid choice %q, enter a number between 1 and %d\n", answer, len(choices))
+	}
+}
+
+// ChooseMany prompts for any number of the choices as a comma separated list of numbers or
+// values. Values other than the choices are accepted when other is set.
+func (p *Prompter) ChooseMany(label string, choices []string, optional, other bool) ([]string, error) {
+	if len(choices) != 0 {
+		p.c.Eprintf("%s:\n", label)
+		for i, choice := range choices {
+			p.c.Eprintf("  %d) %s\n", i+1, choice)
+		}
+		label = "Choose, separated by commas"
+	}
+	for {
+		answer, err := p.Ask(label, "", optional)
+		if err != nil {
+			return nil, err
+		}
+		selected := []string{}
+		invalid := ""
+		for _, value := range strings.Split(answer, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			choice, ok := p.choice(value, choices, other || len(choices) == 0)
+			if !ok {
+				invalid = value
+				break
+			}
+			selected = append(selected, choice)
+		}
+		if invalid == "" && (len(selected) != 0 || optional) {
+			return selected, nil
+		}
+		if invalid != "" {
+			p.c.Eerrorf("Invalid choice %q, enter numbers between 1 and %d\n", invalid, len(choices))
+		} else {
+			p.c.Eerrorf("A value is required\n")
+		}
+	}
+}
+
+// Changed reports whether the flag was set on the command line or by an earlier prompt.
+func (p *Prompter) Changed(flagName string) bool {
+	flag := p.cmd.Flag(StripDash(flagName))
+	return flag != nil && flag.Changed
+}
+
+// Set sets the value of a flag. Slice flags append the value.
+func (p *Prompter) Set(flagName, value string) error {
+	return p.cmd.Flags().Set(StripDash(flagName), value)
+}
+
+// PromptFlag asks for the value of a flag that was not already set, the current value of the flag
+// is the default answer.
+func (p *Prompter) PromptFlag(flagName, label string, optional bool) error {
+	if p.Changed(flagName) {
+		return nil
+	}
+	current := p.cmd.Flag(StripDash(flagName)).Value.String()
+	value, err := p.Ask(label, current, optional)
+	if err != nil || value == "" || value == current {
+		return err
+	}
+	return p.Set(flagName, value)
+}
+
+// PromptFlagChoice asks for the value of a flag that was not already set from a fixed set of
+// choices, the current value of the flag is the default answer.
+func (p *Prompter) PromptFlagChoice(flagName, label string, choices []string) error {
+	if p.Changed(flagName) {
+		return nil
+	}
+	current := p.cmd.Flag(StripDash(flagName)).Value.String()
+	value, err := p.Choose(label, choices, current, false)
+	if err != nil || value == current {
+		return err
+	}
+	return p.Set(flagName, value)
+}
+
+// PromptFlagName asks for the name of an existing resource as the value of a flag that was not
+// already set. The names listed within the namespace are offered as choices.
+func (p *Prompter) PromptFlagName(flagName, label, namespace string, list ResourceLister) error {
+	if p.Changed(flagName) {
+		return nil
+	}
+	value, err := p.Choose(label, p.ListNames(namespace, list), "", true)
+	if err != nil {
+		return err
+	}
+	return p.Set(flagName, value)
+}
+
+// PromptFlagNames asks for the names of existing resources as the values of a slice flag that
+// was not already set. The names listed within the namespace are offered as choices.
+func (p *Prompter) PromptFlagNames(flagName, label, namespace string, list ResourceLister, optional bool) error {
+	if p.Changed(flagName) {
+		return nil
+	}
+	values, err := p.ChooseMany(label, p.ListNames(namespace, list), optional, true)
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		if err := p.Set(flagName, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PromptChoice is one of several mutually exclusive flags, see PromptFlagOneOf.
+type PromptChoice struct {
+	// Label describes the choice, like "function"
+	Label string
+	// FlagName is set with the value prompted for the choice
+	FlagName string
+	// List offers existing resources as values for the flag, optional
+	List ResourceLister
+}
+
+// PromptFlagOneOf asks which of the mutually exclusive flags to set, then asks for the value of
+// that flag. Nothing is prompted when one of the flags is already set.
+func (p *Prompter) PromptFlagOneOf(label, namespace string, choices ...PromptChoice) error {
+	labels := make([]string, len(choices))
+	for i, choice := range choices {
+		if p.Changed(choice.FlagName) {
+			return nil
+		}
+		labels[i] = choice.Label
+	}
+	selected, err := p.Choose(label, labels, labels[0], false)
+	if err != nil {
+		return err
+	}
+	for _, choice := range choices {
+		if choice.Label != selected {
+			continue
+		}
+		if choice.List != nil {
+			return p.PromptFlagName(choice.FlagName, strings.Title(choice.Label), namespace, choice.List)
+		}
+		return p.PromptFlag(choice.FlagName, strings.Title(choice.Label), false)
+	}
+	return nil
+}
+
+// ListNames lists the names of resources within the namespace to offer as choices. Listing is a
+// convenience, errors are ignored as the user can still type a name.
+func (p *Prompter) ListNames(namespace string, list ResourceLister) []string {
+	if list == nil {
+		return nil
+	}
+	names, err := ListNames(p.c, namespace, list)
+	if err != nil {
+		return nil
+	}
+	return names
+}
+
+func (p *Prompter) choice(answer string, choices []string, other bool) (string, bool) {
+	if i, err := strconv.Atoi(answer); err == nil {
+		if i < 1 || i > len(choices) {
+			return "", false
+		}
+		return choices[i-1], true
+	}
+	if other || containsString(choices, answer) {
+		return answer, true
+	}
+	return "", false
+}
+
+func (p *Prompter) readLine(label string) (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err != nil {
+		p.c.Eprintf("\n")
+		return "", fmt.Errorf("no answer for %q: %w", label, err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// CommandLine formats the command with its arguments and the flags that were set, excluding the
+// interactive flag. Values are quoted for the shell as needed.
+func CommandLine(cmd *cobra.Command, args []string) string {
+	line := []string{cmd.CommandPath()}
+	for _, arg := range args {
+		line = append(line, shellQuote(arg))
+	}
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		name := "--" + flag.Name
+		if name == InteractiveFlagName {
+			return
+		}
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			for _, value := range slice.GetSlice() {
+				line = append(line, name, shellQuote(value))
+			}
+			return
+		}
+		value := flag.Value.String()
+		if flag.NoOptDefVal != "" && value == flag.NoOptDefVal {
+			line = append(line, name)
+			return
+		}
+		if flag.Value.Type() == "bool" {
+			line = append(line, fmt.Sprintf("%s=%s", name, value))
+			return
+		}
+		line = append(line, name, shellQuote(value))
+	})
+	return strings.Join(line, " ")
+}
+
+var shellSafe = regexp.MustCompile(`^[a-zA-Z0-9_@%+=:,./-]+$`)
+
+func shellQuote(value string) string {
+	if shellSafe.MatchString(value) {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+func newPrompter(stdin string) (*cli.Prompter, *cobra.Command, *bytes.Buffer) {
+	c := cli.NewDefaultConfig()
+	output := &bytes.Buffer{}
+	c.Stdin = strings.NewReader(stdin)
+	c.Stdout = output
+	c.Stderr = output
+	cmd := &cobra.Command{Use: "create"}
+	cmd.Flags().String("image", "", "")
+	cmd.Flags().String("function-ref", "", "")
+	cmd.Flags().StringArray("input", []string{}, "")
+	cmd.Flags().String("ingress-policy", "ClusterLocal", "")
+	return c.NewPrompter(cmd), cmd, output
+}
+
+func TestStdinIsTerminal(t *testing.T) {
+	c := cli.NewDefaultConfig()
+	c.Stdin = &bytes.Buffer{}
+	if c.StdinIsTerminal() {
+		t.Errorf("expected a buffer not to be a terminal")
+	}
+}
+
+type terminal struct {
+	*strings.Reader
+}
+
+func (t *terminal) IsTerminal() bool {
+	return true
+}
+
+func TestPromptOptions(t *testing.T) {
+	c := cli.NewDefaultConfig()
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	c.Stdin = &terminal{Reader: strings.NewReader("my-func\nmy-image\n")}
+	c.Stdout = stdout
+	c.Stderr = stderr
+	cmd := &cobra.Command{Use: "create"}
+	cmd.Flags().String("image", "", "")
+
+	interactive := true
+	opts := &promptable{}
+	if err := cli.PromptOptions(context.TODO(), c, &interactive, opts)(cmd, []string{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected, actual := "my-func", opts.name; expected != actual {
+		t.Errorf("expected name %q, actual %q", expected, actual)
+	}
+	if diff := cmp.Diff("", stdout.String()); diff != "" {
+		t.Errorf("Unexpected stdout (-expected, +actual): %s", diff)
+	}
+	if diff := cmp.Diff(`Name: Image: 
+Equivalent command:
+  create my-func --image my-image
+
+`, stderr.String()); diff != "" {
+		t.Errorf("Unexpected stderr (-expected, +actual): %s", diff)
+	}
+}
+
+type promptable struct {
+	name string
+}
+
+func (opts *promptable) Prompt(ctx context.Context, p *cli.Prompter) error {
+	if err := p.PromptName("Name", &opts.name); err != nil {
+		return err
+	}
+	return p.PromptFlag(cli.ImageFlagName, "Image", false)
+}
+
+func TestPrompter_Ask(t *testing.T) {
+	p, _, output := newPrompter("\nmy-value\n\n")
+
+	value, err := p.Ask("Value", "", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected, actual := "my-value", value; expected != actual {
+		t.Errorf("expected value %q, actual %q", expected, actual)
+	}
+	value, err = p.Ask("Other", "my-default", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected, actual := "my-default", value; expected != actual {
+		t.Errorf("expected value %q, actual %q", expected, actual)
+	}
+	if _, err := p.Ask("Missing", "", false); err == nil {
+		t.Errorf("expected error when stdin is closed")
+	}
+	if diff := cmp.Diff("Value: A value is required\nValue: Other [my-default]: Missing: \n", output.String()); diff != "" {
+		t.Errorf("Unexpected output (-expected, +actual): %s", diff)
+	}
+}
+
+func TestPrompter_Choose(t *testing.T) {
+	p, _, output := newPrompter("3\nb\n")
+
+	value, err := p.Choose("Letter", []string{"a", "b"}, "", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected, actual := "b", value; expected != actual {
+		t.Errorf("expected value %q, actual %q", expected, actual)
+	}
+	if diff := cmp.Diff(`Letter:
+  1) a
+  2) b
+Choose: Invalid choice "3", enter a number between 1 and 2
+Choose: `, output.String()); diff != "" {
+		t.Errorf("Unexpected output (-expected, +actual): %s", diff)
+	}
+}
+
+func TestPrompter_ChooseMany(t *testing.T) {
+	p, _, _ := newPrompter("2, c,1\n")
+
+	values, err := p.ChooseMany("Letters", []string{"a", "b"}, false, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"b", "c", "a"}, values); diff != "" {
+		t.Errorf("Unexpected values (-expected, +actual): %s", diff)
+	}
+}
+
+func TestPrompter_PromptFlags(t *testing.T) {
+	p, cmd, _ := newPrompter("")
+	if err := cmd.Flags().Set("image", "my-image"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err := p.PromptFlagOneOf("Workload", "default",
+		cli.PromptChoice{Label: "function", FlagName: cli.FunctionRefFlagName},
+		cli.PromptChoice{Label: "image", FlagName: cli.ImageFlagName},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p.Changed(cli.FunctionRefFlagName) {
+		t.Errorf("expected function ref not to be prompted when image is set")
+	}
+
+	p, cmd, _ = newPrompter("\nmy-func\nin:my-stream@earliest, other-stream\nExternal\n")
+	err = p.PromptFlagOneOf("Workload", "default",
+		cli.PromptChoice{Label: "function", FlagName: cli.FunctionRefFlagName},
+		cli.PromptChoice{Label: "image", FlagName: cli.ImageFlagName},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := p.PromptFlagNames(cli.InputFlagName, "Inputs", "default", nil, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := p.PromptFlagChoice(cli.IngressPolicyFlagName, "Ingress policy", []string{"ClusterLocal", "External"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff("create my-func --function-ref my-func --ingress-policy External --input in:my-stream@earliest --input other-stream", cli.CommandLine(cmd, []string{"my-func"})); diff != "" {
+		t.Errorf("Unexpected command line (-expected, +actual): %s", diff)
+	}
+}
+
+func TestCommandLine(t *testing.T) {
+	cmd := &cobra.Command{Use: "create"}
+	cmd.Flags().String("image", "", "")
+	cmd.Flags().Bool("tail", false, "")
+	cmd.Flags().Bool("interactive", false, "")
+	cmd.Flags().StringArray("env", []string{}, "")
+	for _, flag := range [][]string{
+		{"image", "registry.example.com/image"},
+		{"tail", "true"},
+		{"interactive", "true"},
+		{"env", "MY_VAR=my value"},
+		{"env", "OTHER='quoted'"},
+	} {
+		if err := cmd.Flags().Set(flag[0], flag[1]); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	expected := `create my-name --env 'MY_VAR=my value' --env 'OTHER='"'"'quoted'"'"'' --image registry.example.com/image --tail`
+	if diff := cmp.Diff(expected, cli.CommandLine(cmd, []string{"my-name"})); diff != "" {
+		t.Errorf("Unexpected command line (-expected, +actual): %s", diff)
+	}
+}
@@ -16,6 +16,14 @@
 
 package cli
 
+import (
+	"fmt"
+	"strings"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 const (
 	CoreRuntime      = "core"
 	StreamingRuntime = "streaming"
@@ -23,3 +31,78 @@ const (
 )
 
 var AllRuntimes = []string{CoreRuntime, StreamingRuntime, KnativeRuntime}
+
+// AutoRuntimes enables the runtimes whose API group is served by the cluster
+const AutoRuntimes = "auto"
+
+// RuntimeGroups are the API groups of the resources managed by each runtime
+var RuntimeGroups = map[string]string{
+	CoreRuntime:      "core.projectriff.io",
+	StreamingRuntime: "streaming.projectriff.io",
+	KnativeRuntime:   "knative.projectriff.io",
+}
+
+// ParseRuntimes parses a comma separated list of runtimes
+func ParseRuntimes(value string) (map[string]bool, error) {
+	runtimes := map[string]bool{}
+	for _, runtime := range strings.Split(value, ",") {
+		runtime = strings.TrimSpace(runtime)
+		if runtime == "" {
+			continue
+		}
+		if _, ok := RuntimeGroups[runtime]; !ok {
+			return nil, fmt.Errorf("unknown runtime %q, must be one of: %s", runtime, strings.Join(AllRuntimes, ", "))
+		}
+		runtimes[runtime] = true
+	}
+	return runtimes, nil
+}
+
+// DetectRuntimes finds the runtimes whose API group is served by the cluster
+func (c *Config) DetectRuntimes() (map[string]bool, error) {
+	groups, err := c.ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+	runtimes := map[string]bool{}
+	for _, group := range groups.Groups {
+		for runtime, runtimeGroup := range RuntimeGroups {
+			if group.Name == runtimeGroup {
+				runtimes[runtime] = true
+			}
+		}
+	}
+	return runtimes, nil
+}
+
+// ExplainMissingRuntime replaces a not found error with a description of the missing runtime,
+// when the runtime's API group is not served by the cluster. Other errors are returned as is.
+func (c *Config) ExplainMissingRuntime(err error, runtime string) error {
+	if !apierrs.IsNotFound(err) {
+		return err
+	}
+	groups, discoveryErr := c.ServerGroups()
+	if discoveryErr != nil {
+		return err
+	}
+	for _, group := range groups.Groups {
+		if group.Name == RuntimeGroups[runtime] {
+			return err
+		}
+	}
+	return fmt.Errorf("the %s runtime is not installed in the cluster, the %q API group was not found: %w", runtime, RuntimeGroups[runtime], err)
+}
+
+// ServerGroups lists the API groups served by the cluster. An unusable kube config is returned as
+// an error, rather than the panic from creating the discovery client.
+func (c *Config) ServerGroups() (*metav1.APIGroupList, error) {
+	if c.Client == nil {
+		return nil, fmt.Errorf("no kubernetes client configured")
+	}
+	if kubeConfig := c.KubeConfig(); kubeConfig != nil {
+		if _, err := kubeConfig.ClientConfig(); err != nil {
+			return nil, err
+		}
+	}
+	return c.Discovery().ServerGroups()
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/cli"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+)
+
+func TestParseRuntimes(t *testing.T) {
+	runtimes, err := cli.ParseRuntimes("core, knative,")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]bool{cli.CoreRuntime: true, cli.KnativeRuntime: true}, runtimes); diff != "" {
+		t.Errorf("Unexpected runtimes (-expected, +actual): %s", diff)
+	}
+
+	if _, err := cli.ParseRuntimes("core,lambda"); err == nil {
+		t.Errorf("Expected error for unknown runtime")
+	}
+}
+
+func TestDetectRuntimes(t *testing.T) {
+	c := cli.NewDefaultConfig()
+	c.Client = rifftesting.NewClient()
+	discovery := c.Client.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1"},
+		{GroupVersion: "build.projectriff.io/v1alpha1"},
+		{GroupVersion: "streaming.projectriff.io/v1alpha1"},
+		{GroupVersion: "knative.projectriff.io/v1alpha1"},
+	}
+
+	runtimes, err := c.DetectRuntimes()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]bool{cli.StreamingRuntime: true, cli.KnativeRuntime: true}, runtimes); diff != "" {
+		t.Errorf("Unexpected runtimes (-expected, +actual): %s", diff)
+	}
+}
+
+func TestExplainMissingRuntime(t *testing.T) {
+	c := cli.NewDefaultConfig()
+	c.Client = rifftesting.NewClient()
+	discovery := c.Client.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "knative.projectriff.io/v1alpha1"},
+	}
+
+	missing := apierrs.NewGenericServerResponse(404, "list", schema.GroupResource{Group: "core.projectriff.io", Resource: "deployers"}, "", "", 0, false)
+	if expected, actual := fmt.Sprintf("the core runtime is not installed in the cluster, the \"core.projectriff.io\" API group was not found: %s", missing), c.ExplainMissingRuntime(missing, cli.CoreRuntime).Error(); expected != actual {
+		t.Errorf("Expected error %q, actually %q", expected, actual)
+	}
+
+	notFound := apierrs.NewNotFound(schema.GroupResource{Group: "knative.projectriff.io", Resource: "deployers"}, "my-deployer")
+	if expected, actual := error(notFound), c.ExplainMissingRuntime(notFound, cli.KnativeRuntime); expected != actual {
+		t.Errorf("Expected error %q, actually %q", expected, actual)
+	}
+
+	other := fmt.Errorf("other error")
+	if expected, actual := other, c.ExplainMissingRuntime(other, cli.CoreRuntime); expected != actual {
+		t.Errorf("Expected error %q, actually %q", expected, actual)
+	}
+	if actual := c.ExplainMissingRuntime(nil, cli.CoreRuntime); actual != nil {
+		t.Errorf("Expected no error, actually %q", actual)
+	}
+}
@@ -23,16 +23,19 @@ import (
 	"testing"
 
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
 	"github.com/projectriff/cli/pkg/core/commands"
 	"github.com/projectriff/cli/pkg/k8s"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	kailtesting "github.com/projectriff/cli/pkg/testing/kail"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	corev1alpha1 "github.com/projectriff/system/pkg/apis/core/v1alpha1"
 	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	cachetesting "k8s.io/client-go/tools/cache/testing"
 )
 
@@ -211,6 +214,18 @@ func TestDeployerCreateOptions(t *testing.T) {
 				cli.ErrInvalidValue("-1", cli.TargetPortFlagName),
 			),
 		},
+		{
+			Name: "with invalid workload",
+			Options: &commands.DeployerCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				IngressPolicy:   string(corev1alpha1.IngressPolicyClusterLocal),
+				WorkloadOptions: options.WorkloadOptions{
+					TerminationGracePeriod: "-1s",
+				},
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("-1s", cli.TerminationGracePeriodFlagName),
+		},
 		{
 			Name: "with tail",
 			Options: &commands.DeployerCreateOptions{
@@ -277,6 +292,7 @@ func TestDeployerCreateCommand(t *testing.T) {
 	applicationRef := "my-app"
 	containerRef := "my-container"
 	containerPort := int32(8888)
+	terminationGracePeriod := int64(60)
 	functionRef := "my-func"
 	envName := "MY_VAR"
 	envValue := "my-value"
@@ -379,6 +395,102 @@ Created deployer "my-deployer"
 			},
 			ExpectOutput: `
 Created deployer "my-deployer"
+`,
+		},
+		{
+			Name: "interactive",
+			Args: []string{deployerName, cli.InteractiveFlagName},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "another-func",
+					},
+				},
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      functionRef,
+					},
+				},
+			},
+			Terminal: true,
+			Stdin:    []byte("\n2\nExternal\n"),
+			ExpectCreates: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      deployerName,
+					},
+					Spec: corev1alpha1.DeployerSpec{
+						Build: &corev1alpha1.Build{
+							FunctionRef: functionRef,
+						},
+						IngressPolicy: corev1alpha1.IngressPolicyExternal,
+					},
+				},
+			},
+			ExpectOutput: `
+Workload:
+  1) function
+  2) application
+  3) container
+  4) image
+Choose [function]: Function:
+  1) another-func
+  2) my-func
+Choose: Ingress policy:
+  1) ClusterLocal
+  2) External
+Choose [ClusterLocal]: 
+Equivalent command:
+  create my-deployer --function-ref my-func --ingress-policy External
+
+Created deployer "my-deployer"
+`,
+		},
+		{
+			Name:     "interactive, image",
+			Args:     []string{deployerName, cli.IngressPolicyFlagName, string(corev1alpha1.IngressPolicyClusterLocal), cli.InteractiveFlagName},
+			Terminal: true,
+			Stdin:    []byte(fmt.Sprintf("image\n%s\n", image)),
+			ExpectCreates: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      deployerName,
+					},
+					Spec: corev1alpha1.DeployerSpec{
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{Image: image},
+								},
+							},
+						},
+						IngressPolicy: corev1alpha1.IngressPolicyClusterLocal,
+					},
+				},
+			},
+			ExpectOutput: `
+Workload:
+  1) function
+  2) application
+  3) container
+  4) image
+Choose [function]: Image: 
+Equivalent command:
+  create my-deployer --image registry.example.com/repo@sha256:deadbeefdeadbeefdeadbeefdeadbeef --ingress-policy ClusterLocal
+
+Created deployer "my-deployer"
+`,
+		},
+		{
+			Name:        "interactive, not a terminal",
+			Args:        []string{deployerName, cli.InteractiveFlagName},
+			ShouldError: true,
+			ExpectOutput: `
+Warning: stdin is not a terminal, ignoring --interactive
 `,
 		},
 		{
@@ -548,6 +660,214 @@ Created deployer "my-deployer"
 Created deployer "my-deployer"
 `,
 		},
+		{
+			Name: "create with probes, command and termination grace period",
+			Args: []string{deployerName, cli.ImageFlagName, image,
+				cli.CommandFlagName, "/bin/server", cli.ArgFlagName, "--verbose", cli.ArgFlagName, "--color=false",
+				cli.TerminationGracePeriodFlagName, "60s",
+				cli.ReadinessProbeFlagName, "http:/ready", cli.ReadinessProbeInitialDelayFlagName, "5s", cli.ReadinessProbePeriodFlagName, "15s",
+				cli.ReadinessProbeTimeoutFlagName, "2s", cli.ReadinessProbeSuccessThresholdFlagName, "2", cli.ReadinessProbeFailureThresholdFlagName, "5",
+				cli.LivenessProbeFlagName, "tcp",
+			},
+			ExpectCreates: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      deployerName,
+					},
+					Spec: corev1alpha1.DeployerSpec{
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								TerminationGracePeriodSeconds: &terminationGracePeriod,
+								Containers: []corev1.Container{
+									{
+										Image:   image,
+										Command: []string{"/bin/server"},
+										Args:    []string{"--verbose", "--color=false"},
+										ReadinessProbe: &corev1.Probe{
+											Handler: corev1.Handler{
+												HTTPGet: &corev1.HTTPGetAction{
+													Path: "/ready",
+													Port: intstr.FromInt(8080),
+												},
+											},
+											InitialDelaySeconds: 5,
+											PeriodSeconds:       15,
+											TimeoutSeconds:      2,
+											SuccessThreshold:    2,
+											FailureThreshold:    5,
+										},
+										LivenessProbe: &corev1.Probe{
+											Handler: corev1.Handler{
+												TCPSocket: &corev1.TCPSocketAction{
+													Port: intstr.FromInt(8080),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						IngressPolicy: corev1alpha1.IngressPolicyClusterLocal,
+					},
+				},
+			},
+			ExpectOutput: `
+Created deployer "my-deployer"
+`,
+		},
+		{
+			Name: "create with probe on target port",
+			Args: []string{deployerName, cli.ImageFlagName, image, cli.TargetPortFlagName, "8888", cli.LivenessProbeFlagName, "http:/healthz"},
+			ExpectCreates: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      deployerName,
+					},
+					Spec: corev1alpha1.DeployerSpec{
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Image: image,
+										Ports: []corev1.ContainerPort{
+											{Protocol: corev1.ProtocolTCP, ContainerPort: containerPort},
+										},
+										LivenessProbe: &corev1.Probe{
+											Handler: corev1.Handler{
+												HTTPGet: &corev1.HTTPGetAction{
+													Path: "/healthz",
+													Port: intstr.FromInt(8888),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						IngressPolicy: corev1alpha1.IngressPolicyClusterLocal,
+					},
+				},
+			},
+			ExpectOutput: `
+Created deployer "my-deployer"
+`,
+		},
+		{
+			Name:        "create with invalid probe",
+			Args:        []string{deployerName, cli.ImageFlagName, image, cli.ReadinessProbeFlagName, "grpc"},
+			ShouldError: true,
+		},
+		{
+			Name: "create with volumes and service account",
+			Args: []string{deployerName, cli.ImageFlagName, image, cli.MountConfigMapFlagName, "my-config:/etc/config", cli.MountSecretFlagName, "my-tls:/etc/tls", cli.EmptyDirFlagName, "/tmp/cache", cli.ServiceAccountFlagName, "my-service-account"},
+			ExpectCreates: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      deployerName,
+					},
+					Spec: corev1alpha1.DeployerSpec{
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								ServiceAccountName: "my-service-account",
+								Volumes: []corev1.Volume{
+									{
+										Name: "configmap-0",
+										VolumeSource: corev1.VolumeSource{
+											ConfigMap: &corev1.ConfigMapVolumeSource{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+											},
+										},
+									},
+									{
+										Name: "secret-0",
+										VolumeSource: corev1.VolumeSource{
+											Secret: &corev1.SecretVolumeSource{SecretName: "my-tls"},
+										},
+									},
+									{
+										Name: "empty-dir-0",
+										VolumeSource: corev1.VolumeSource{
+											EmptyDir: &corev1.EmptyDirVolumeSource{},
+										},
+									},
+								},
+								Containers: []corev1.Container{
+									{
+										Image: image,
+										VolumeMounts: []corev1.VolumeMount{
+											{Name: "configmap-0", MountPath: "/etc/config", ReadOnly: true},
+											{Name: "secret-0", MountPath: "/etc/tls", ReadOnly: true},
+											{Name: "empty-dir-0", MountPath: "/tmp/cache"},
+										},
+									},
+								},
+							},
+						},
+						IngressPolicy: corev1alpha1.IngressPolicyClusterLocal,
+					},
+				},
+			},
+			ExpectOutput: `
+Created deployer "my-deployer"
+`,
+		},
+		{
+			Name:        "create with conflicting mounts",
+			Args:        []string{deployerName, cli.ImageFlagName, image, cli.MountSecretFlagName, "my-tls:/etc/tls", cli.EmptyDirFlagName, "/etc/tls"},
+			ShouldError: true,
+		},
+		{
+			Name: "create with env file and env from config map and secret",
+			Args: []string{deployerName, cli.ImageFlagName, image, cli.EnvFileFlagName, "../../parsers/testdata/app.env", cli.EnvFlagName, "MY_VAR3=value3", cli.EnvFromConfigMapFlagName, "my-configmap", cli.EnvFromSecretFlagName, "my-secret"},
+			ExpectCreates: []runtime.Object{
+				&corev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      deployerName,
+					},
+					Spec: corev1alpha1.DeployerSpec{
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Image: image,
+										Env: []corev1.EnvVar{
+											{Name: "MY_VAR1", Value: "value1"},
+											{Name: "MY_VAR2", Value: "multi\nline"},
+											{Name: "MY_VAR3", Value: "value3"},
+										},
+										EnvFrom: []corev1.EnvFromSource{
+											{
+												ConfigMapRef: &corev1.ConfigMapEnvSource{
+													LocalObjectReference: corev1.LocalObjectReference{Name: "my-configmap"},
+												},
+											},
+											{
+												SecretRef: &corev1.SecretEnvSource{
+													LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						IngressPolicy: corev1alpha1.IngressPolicyClusterLocal,
+					},
+				},
+			},
+			ExpectOutput: `
+Created deployer "my-deployer"
+`,
+		},
+		{
+			Name:        "create with invalid env file",
+			Args:        []string{deployerName, cli.ImageFlagName, image, cli.EnvFileFlagName, "../../parsers/testdata/invalid.env"},
+			ShouldError: true,
+		},
 		{
 			Name: "error existing deployer",
 			Args: []string{deployerName, cli.ImageFlagName, image},
@@ -86,6 +86,7 @@ deployer roll out is processed.
 	cli.Args(cmd,
 		cli.NameArg(&opts.Name),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListCoreDeployers)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 
@@ -26,7 +26,9 @@ import (
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	appsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
 	authv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
@@ -35,9 +37,12 @@ import (
 
 type Client interface {
 	DefaultNamespace() string
+	KubeConfig() clientcmd.ClientConfig
 	KubeRestConfig() *rest.Config
 	Core() corev1.CoreV1Interface
+	Apps() appsv1.AppsV1Interface
 	Discovery() discovery.DiscoveryInterface
+	Dynamic() dynamic.Interface
 	Auth() authv1client.AuthorizationV1Interface
 	APIExtension() apiextensionsv1beta1.ApiextensionsV1beta1Interface
 	Bindings() bindingsv1alpha1.BindingsV1alpha1Interface
@@ -45,12 +50,17 @@ type Client interface {
 	CoreRuntime() corev1alpha1.CoreV1alpha1Interface
 	StreamingRuntime() streamv1alpha1.StreamingV1alpha1Interface
 	KnativeRuntime() knativev1alpha1.KnativeV1alpha1Interface
+	KnativeServing() ServingV1Interface
 }
 
 func (c *client) DefaultNamespace() string {
 	return c.lazyLoadDefaultNamespaceOrDie()
 }
 
+func (c *client) KubeConfig() clientcmd.ClientConfig {
+	return c.lazyLoadKubeConfig()
+}
+
 func (c *client) KubeRestConfig() *rest.Config {
 	return c.lazyLoadRestConfigOrDie()
 }
@@ -59,10 +69,18 @@ func (c *client) Core() corev1.CoreV1Interface {
 	return c.lazyLoadKubernetesClientsetOrDie().CoreV1()
 }
 
+func (c *client) Apps() appsv1.AppsV1Interface {
+	return c.lazyLoadKubernetesClientsetOrDie().AppsV1()
+}
+
 func (c *client) Discovery() discovery.DiscoveryInterface {
 	return c.lazyLoadKubernetesClientsetOrDie().Discovery()
 }
 
+func (c *client) Dynamic() dynamic.Interface {
+	return c.lazyLoadDynamicClientOrDie()
+}
+
 func (c *client) Auth() authv1client.AuthorizationV1Interface {
 	return c.lazyLoadKubernetesClientsetOrDie().AuthorizationV1()
 }
@@ -91,26 +109,39 @@ func (c *client) KnativeRuntime() knativev1alpha1.KnativeV1alpha1Interface {
 	return c.lazyLoadRiffClientsetOrDie().KnativeV1alpha1()
 }
 
+func (c *client) KnativeServing() ServingV1Interface {
+	return c.lazyLoadServingClientOrDie()
+}
+
 func NewClient(kubeConfigFile string) Client {
-	return &client{kubeConfigFile: kubeConfigFile}
+	return NewClientWithOverrides(kubeConfigFile, &clientcmd.ConfigOverrides{})
+}
+
+// NewClientWithOverrides creates a client for the kube config file with overrides for the
+// context, cluster and user. The overrides are read when the client is first used, they may be
+// bound to flags that are parsed after the client is created.
+func NewClientWithOverrides(kubeConfigFile string, configOverrides *clientcmd.ConfigOverrides) Client {
+	return &client{kubeConfigFile: kubeConfigFile, configOverrides: configOverrides}
 }
 
 type client struct {
 	defaultNamespace       string
 	kubeConfigFile         string
+	configOverrides        *clientcmd.ConfigOverrides
 	kubeConfig             clientcmd.ClientConfig
 	restConfig             *rest.Config
 	kubeClientset          *kubernetes.Clientset
+	dynamicClient          dynamic.Interface
 	apiExtensionsClientset *apiextensionsclientset.Clientset
 	riffClientset          *projectriffclientset.Clientset
+	servingClient          ServingV1Interface
 }
 
 func (c *client) lazyLoadKubeConfig() clientcmd.ClientConfig {
 	if c.kubeConfig == nil {
 		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 		loadingRules.ExplicitPath = c.kubeConfigFile
-		configOverrides := &clientcmd.ConfigOverrides{}
-		c.kubeConfig = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
+		c.kubeConfig = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, c.configOverrides)
 	}
 	return c.kubeConfig
 }
@@ -135,6 +166,14 @@ func (c *client) lazyLoadKubernetesClientsetOrDie() *kubernetes.Clientset {
 	return c.kubeClientset
 }
 
+func (c *client) lazyLoadDynamicClientOrDie() dynamic.Interface {
+	if c.dynamicClient == nil {
+		restConfig := c.lazyLoadRestConfigOrDie()
+		c.dynamicClient = dynamic.NewForConfigOrDie(restConfig)
+	}
+	return c.dynamicClient
+}
+
 func (c *client) lazyLoadAPIExtensionsClientsetOrDie() *apiextensionsclientset.Clientset {
 	if c.apiExtensionsClientset == nil {
 		restConfig := c.lazyLoadRestConfigOrDie()
@@ -152,6 +191,18 @@ func (c *client) lazyLoadRiffClientsetOrDie() *projectriffclientset.Clientset {
 	return c.riffClientset
 }
 
+func (c *client) lazyLoadServingClientOrDie() ServingV1Interface {
+	if c.servingClient == nil {
+		restConfig := c.lazyLoadRestConfigOrDie()
+		servingClient, err := NewServingV1Client(restConfig)
+		if err != nil {
+			panic(err)
+		}
+		c.servingClient = servingClient
+	}
+	return c.servingClient
+}
+
 func (c *client) lazyLoadDefaultNamespaceOrDie() string {
 	if c.defaultNamespace == "" {
 		kubeConfig := c.lazyLoadKubeConfig()
@@ -18,8 +18,10 @@ package k8s_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/projectriff/cli/pkg/k8s"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 func TestNewClient(t *testing.T) {
@@ -28,12 +30,18 @@ func TestNewClient(t *testing.T) {
 	if expected, actual := "my-namespace", client.DefaultNamespace(); expected != actual {
 		t.Errorf("Expected namespace to be %q, actually %q", expected, actual)
 	}
+	if client.KubeConfig() == nil {
+		t.Errorf("Expected kube config to not be nil")
+	}
 	if client.KubeRestConfig() == nil {
 		t.Errorf("Expected REST config to not be nil")
 	}
 	if client.Discovery() == nil {
 		t.Errorf("Expected Discovery client to not be nil")
 	}
+	if client.Dynamic() == nil {
+		t.Errorf("Expected Dynamic client to not be nil")
+	}
 	if client.Core() == nil {
 		t.Errorf("Expected Core client to not be nil")
 	}
@@ -59,3 +67,31 @@ func TestNewClient(t *testing.T) {
 		t.Errorf("Expected KnativeRuntime client to not be nil")
 	}
 }
+
+func TestNewClientWithOverrides(t *testing.T) {
+	overrides := &clientcmd.ConfigOverrides{}
+	client := k8s.NewClientWithOverrides("testdata/.kube/config", overrides)
+
+	// overrides are read when the client is first used
+	overrides.CurrentContext = "other-context"
+	overrides.AuthInfo.Impersonate = "jane"
+	overrides.AuthInfo.ImpersonateGroups = []string{"developers"}
+	overrides.Timeout = "5s"
+
+	if expected, actual := "other-namespace", client.DefaultNamespace(); expected != actual {
+		t.Errorf("Expected namespace to be %q, actually %q", expected, actual)
+	}
+	restConfig := client.KubeRestConfig()
+	if expected, actual := "https://192.168.1.2:8443", restConfig.Host; expected != actual {
+		t.Errorf("Expected host to be %q, actually %q", expected, actual)
+	}
+	if expected, actual := "jane", restConfig.Impersonate.UserName; expected != actual {
+		t.Errorf("Expected impersonated user to be %q, actually %q", expected, actual)
+	}
+	if expected, actual := 1, len(restConfig.Impersonate.Groups); expected != actual {
+		t.Errorf("Expected %d impersonated groups, actually %d", expected, actual)
+	}
+	if expected, actual := 5*time.Second, restConfig.Timeout; expected != actual {
+		t.Errorf("Expected timeout to be %s, actually %s", expected, actual)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ResolveResource finds the API resource matching the name, similar to kubectl. The name may be
+// the plural, singular, short name or kind of the resource, optionally qualified by the group,
+// "<name>.<group>", or by the version and group, "<name>.<version>.<group>". Names are matched
+// case-insensitively.
+//
+// The returned resource has its Group and Version set. When the name matches resources in more
+// than one group, the core group is preferred and the deprecated extensions group loses to the
+// groups that replaced it, like kubectl. If that does not decide, an error listing the qualified
+// candidates is returned.
+func ResolveResource(resources []*metav1.APIResourceList, name string) (*metav1.APIResource, error) {
+	lower := strings.ToLower(name)
+
+	matches := []metav1.APIResource{}
+	seen := map[schema.GroupResource]bool{}
+	for _, rl := range resources {
+		gv, err := schema.ParseGroupVersion(rl.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range rl.APIResources {
+			if strings.Contains(r.Name, "/") {
+				// subresource
+				continue
+			}
+			gr := schema.GroupResource{Group: gv.Group, Resource: r.Name}
+			if seen[gr] || !matchesResource(r, gv, lower) {
+				continue
+			}
+			// the first version listed for a resource wins
+			seen[gr] = true
+			r.Group = gv.Group
+			r.Version = gv.Version
+			matches = append(matches, r)
+		}
+	}
+
+	if len(matches) > 1 {
+		matches = preferredMatches(matches)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("the server doesn't have a resource type %q", name)
+	case 1:
+		return &matches[0], nil
+	}
+	candidates := make([]string, len(matches))
+	for i, r := range matches {
+		candidates[i] = schema.GroupResource{Group: r.Group, Resource: r.Name}.String()
+	}
+	sort.Strings(candidates)
+	return nil, fmt.Errorf("resource type %q is ambiguous, use one of: %s", name, strings.Join(candidates, ", "))
+}
+
+// preferredMatches narrows resources matched in several groups to the preferred ones
+func preferredMatches(matches []metav1.APIResource) []metav1.APIResource {
+	for _, r := range matches {
+		if r.Group == "" {
+			return []metav1.APIResource{r}
+		}
+	}
+	preferred := []metav1.APIResource{}
+	for _, r := range matches {
+		if r.Group != "extensions" {
+			preferred = append(preferred, r)
+		}
+	}
+	if len(preferred) == 0 {
+		return matches
+	}
+	return preferred
+}
+
+func matchesResource(r metav1.APIResource, gv schema.GroupVersion, name string) bool {
+	names := append([]string{r.Name, r.SingularName, strings.ToLower(r.Kind)}, r.ShortNames...)
+	for _, n := range names {
+		if n == "" {
+			continue
+		}
+		if name == n {
+			return true
+		}
+		if gv.Group != "" && (name == fmt.Sprintf("%s.%s", n, gv.Group) || name == fmt.Sprintf("%s.%s.%s", n, gv.Version, gv.Group)) {
+			return true
+		}
+	}
+	return false
+}
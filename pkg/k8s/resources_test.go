@@ -0,0 +1,186 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package k8s_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolveResource(t *testing.T) {
+	resources := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "services", SingularName: "service", ShortNames: []string{"svc"}, Kind: "Service"},
+				{Name: "services/status", Kind: "Service"},
+				{Name: "events", SingularName: "event", ShortNames: []string{"ev"}, Kind: "Event"},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", SingularName: "deployment", ShortNames: []string{"deploy"}, Kind: "Deployment"},
+			},
+		},
+		{
+			GroupVersion: "events.k8s.io/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "events", SingularName: "event", ShortNames: []string{"ev"}, Kind: "Event"},
+			},
+		},
+		{
+			GroupVersion: "extensions/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", SingularName: "deployment", ShortNames: []string{"deploy"}, Kind: "Deployment"},
+			},
+		},
+		{
+			GroupVersion: "serving.knative.dev/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "services", SingularName: "service", ShortNames: []string{"kservice", "ksvc"}, Kind: "Service"},
+			},
+		},
+		{
+			GroupVersion: "serving.knative.dev/v1alpha1",
+			APIResources: []metav1.APIResource{
+				{Name: "services", SingularName: "service", ShortNames: []string{"kservice", "ksvc"}, Kind: "Service"},
+			},
+		},
+		{
+			GroupVersion: "kpack.io/v1alpha1",
+			APIResources: []metav1.APIResource{
+				// singular names are not always reported for custom resources
+				{Name: "images", ShortNames: []string{"cnbimage", "cnbimages"}, Kind: "Image"},
+			},
+		},
+		{
+			GroupVersion: "core.projectriff.io/v1alpha1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployers", SingularName: "deployer", Kind: "Deployer"},
+			},
+		},
+		{
+			GroupVersion: "knative.projectriff.io/v1alpha1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployers", SingularName: "deployer", Kind: "Deployer"},
+			},
+		},
+	}
+
+	deployment := &metav1.APIResource{Name: "deployments", SingularName: "deployment", ShortNames: []string{"deploy"}, Kind: "Deployment", Group: "apps", Version: "v1"}
+	kservice := &metav1.APIResource{Name: "services", SingularName: "service", ShortNames: []string{"kservice", "ksvc"}, Kind: "Service", Group: "serving.knative.dev", Version: "v1"}
+	service := &metav1.APIResource{Name: "services", SingularName: "service", ShortNames: []string{"svc"}, Kind: "Service", Version: "v1"}
+	event := &metav1.APIResource{Name: "events", SingularName: "event", ShortNames: []string{"ev"}, Kind: "Event", Version: "v1"}
+	extensionsDeployment := &metav1.APIResource{Name: "deployments", SingularName: "deployment", ShortNames: []string{"deploy"}, Kind: "Deployment", Group: "extensions", Version: "v1beta1"}
+	image := &metav1.APIResource{Name: "images", ShortNames: []string{"cnbimage", "cnbimages"}, Kind: "Image", Group: "kpack.io", Version: "v1alpha1"}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected *metav1.APIResource
+		err      error
+	}{{
+		name:     "plural",
+		value:    "deployments",
+		expected: deployment,
+	}, {
+		name:     "singular",
+		value:    "deployment",
+		expected: deployment,
+	}, {
+		name:     "short name",
+		value:    "deploy",
+		expected: deployment,
+	}, {
+		name:     "kind",
+		value:    "Deployment",
+		expected: deployment,
+	}, {
+		name:     "plural and group",
+		value:    "deployments.apps",
+		expected: deployment,
+	}, {
+		name:     "kind and group",
+		value:    "Deployment.apps",
+		expected: deployment,
+	}, {
+		name:     "plural, version and group",
+		value:    "deployments.v1.apps",
+		expected: deployment,
+	}, {
+		name:     "custom resource short name",
+		value:    "ksvc",
+		expected: kservice,
+	}, {
+		name:     "custom resource without singular name",
+		value:    "image",
+		expected: image,
+	}, {
+		name:     "preferred version",
+		value:    "services.serving.knative.dev",
+		expected: kservice,
+	}, {
+		name:     "core short name",
+		value:    "svc",
+		expected: service,
+	}, {
+		name:     "core group preferred",
+		value:    "services",
+		expected: service,
+	}, {
+		name:     "core group preferred by kind",
+		value:    "event",
+		expected: event,
+	}, {
+		name:     "extensions group by group",
+		value:    "deployments.extensions",
+		expected: extensionsDeployment,
+	}, {
+		name:  "ambiguous",
+		value: "deployers",
+		err:   fmt.Errorf(`resource type "deployers" is ambiguous, use one of: deployers.core.projectriff.io, deployers.knative.projectriff.io`),
+	}, {
+		name:  "subresource",
+		value: "services/status",
+		err:   fmt.Errorf(`the server doesn't have a resource type "services/status"`),
+	}, {
+		name:  "partial group",
+		value: "services.serving",
+		err:   fmt.Errorf(`the server doesn't have a resource type "services.serving"`),
+	}, {
+		name:  "unknown",
+		value: "foo",
+		err:   fmt.Errorf(`the server doesn't have a resource type "foo"`),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := k8s.ResolveResource(resources, test.value)
+			if diff := cmp.Diff(fmt.Sprintf("%v", test.err), fmt.Sprintf("%v", err)); diff != "" {
+				t.Errorf("ResolveResource() error (-expected, +actual): %s", diff)
+			}
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("ResolveResource() (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package k8s
+
+import (
+	servingv1 "github.com/projectriff/system/pkg/apis/thirdparty/knative/serving/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+// ServingV1Interface is a client for the Knative Serving resources backing knative deployers
+// and targeted by knative adapters. Revisions and routes are owned by the riff knative runtime,
+// changes are made through the deployer. Services and configurations may be updated directly as
+// they are owned by the user.
+type ServingV1Interface interface {
+	Configurations(namespace string) ConfigurationInterface
+	Revisions(namespace string) RevisionInterface
+	Routes(namespace string) RouteInterface
+	Services(namespace string) ServiceInterface
+}
+
+type ConfigurationInterface interface {
+	Get(name string, options metav1.GetOptions) (*servingv1.Configuration, error)
+	List(opts metav1.ListOptions) (*servingv1.ConfigurationList, error)
+	Update(configuration *servingv1.Configuration) (*servingv1.Configuration, error)
+}
+
+type RevisionInterface interface {
+	Get(name string, options metav1.GetOptions) (*servingv1.Revision, error)
+	List(opts metav1.ListOptions) (*servingv1.RevisionList, error)
+}
+
+type RouteInterface interface {
+	Get(name string, options metav1.GetOptions) (*servingv1.Route, error)
+}
+
+type ServiceInterface interface {
+	Get(name string, options metav1.GetOptions) (*servingv1.Service, error)
+	List(opts metav1.ListOptions) (*servingv1.ServiceList, error)
+	Update(service *servingv1.Service) (*servingv1.Service, error)
+}
+
+func NewServingV1Client(config *rest.Config) (ServingV1Interface, error) {
+	s := runtime.NewScheme()
+	if err := servingv1.AddToScheme(s); err != nil {
+		return nil, err
+	}
+	metav1.AddToGroupVersion(s, servingv1.GroupVersion)
+
+	config = rest.CopyConfig(config)
+	config.GroupVersion = &servingv1.GroupVersion
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = serializer.NewCodecFactory(s).WithoutConversion()
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+	client, err := rest.RESTClientFor(config)
+	if err != nil {
+		return nil, err
+	}
+	return &servingV1Client{client: client}, nil
+}
+
+type servingV1Client struct {
+	client rest.Interface
+}
+
+func (c *servingV1Client) Configurations(namespace string) ConfigurationInterface {
+	return &configurations{client: c.client, ns: namespace}
+}
+
+func (c *servingV1Client) Revisions(namespace string) RevisionInterface {
+	return &revisions{client: c.client, ns: namespace}
+}
+
+func (c *servingV1Client) Routes(namespace string) RouteInterface {
+	return &routes{client: c.client, ns: namespace}
+}
+
+func (c *servingV1Client) Services(namespace string) ServiceInterface {
+	return &services{client: c.client, ns: namespace}
+}
+
+type configurations struct {
+	client rest.Interface
+	ns     string
+}
+
+func (c *configurations) Get(name string, options metav1.GetOptions) (*servingv1.Configuration, error) {
+	result := &servingv1.Configuration{}
+	err := c.client.Get().
+		Namespace(c.ns).
+		Resource("configurations").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return result, err
+}
+
+func (c *configurations) List(opts metav1.ListOptions) (*servingv1.ConfigurationList, error) {
+	result := &servingv1.ConfigurationList{}
+	err := c.client.Get().
+		Namespace(c.ns).
+		Resource("configurations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return result, err
+}
+
+func (c *configurations) Update(configuration *servingv1.Configuration) (*servingv1.Configuration, error) {
+	result := &servingv1.Configuration{}
+	err := c.client.Put().
+		Namespace(c.ns).
+		Resource("configurations").
+		Name(configuration.Name).
+		Body(configuration).
+		Do().
+		Into(result)
+	return result, err
+}
+
+type revisions struct {
+	client rest.Interface
+	ns     string
+}
+
+func (c *revisions) Get(name string, options metav1.GetOptions) (*servingv1.Revision, error) {
+	result := &servingv1.Revision{}
+	err := c.client.Get().
+		Namespace(c.ns).
+		Resource("revisions").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return result, err
+}
+
+func (c *revisions) List(opts metav1.ListOptions) (*servingv1.RevisionList, error) {
+	result := &servingv1.RevisionList{}
+	err := c.client.Get().
+		Namespace(c.ns).
+		Resource("revisions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return result, err
+}
+
+type routes struct {
+	client rest.Interface
+	ns     string
+}
+
+func (c *routes) Get(name string, options metav1.GetOptions) (*servingv1.Route, error) {
+	result := &servingv1.Route{}
+	err := c.client.Get().
+		Namespace(c.ns).
+		Resource("routes").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return result, err
+}
+
+type services struct {
+	client rest.Interface
+	ns     string
+}
+
+func (c *services) Get(name string, options metav1.GetOptions) (*servingv1.Service, error) {
+	result := &servingv1.Service{}
+	err := c.client.Get().
+		Namespace(c.ns).
+		Resource("services").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return result, err
+}
+
+func (c *services) List(opts metav1.ListOptions) (*servingv1.ServiceList, error) {
+	result := &servingv1.ServiceList{}
+	err := c.client.Get().
+		Namespace(c.ns).
+		Resource("services").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return result, err
+}
+
+func (c *services) Update(service *servingv1.Service) (*servingv1.Service, error) {
+	result := &servingv1.Service{}
+	err := c.client.Put().
+		Namespace(c.ns).
+		Resource("services").
+		Name(service.Name).
+		Body(service).
+		Do().
+		Into(result)
+	return result, err
+}
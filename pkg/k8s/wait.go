@@ -18,10 +18,13 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	sapis "github.com/projectriff/system/pkg/apis"
+	"github.com/vmware-labs/reconciler-runtime/apis"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -32,27 +35,55 @@ import (
 	watchclient "k8s.io/client-go/tools/watch"
 )
 
-var ErrWaitTimeout = wait.ErrWaitTimeout
+var (
+	ErrWaitTimeout     = wait.ErrWaitTimeout
+	ErrConditionFailed = errors.New("condition failed")
+)
 
-type object interface {
+// Object is a riff resource that reports status conditions.
+type Object interface {
 	sapis.Resource
 	metav1.Object
 	runtime.Object
 }
 
 // WaitUntilReady watches for mutations of the target object until the target is ready.
-func WaitUntilReady(ctx context.Context, client rest.Interface, resource string, target object) error {
+func WaitUntilReady(ctx context.Context, client rest.Interface, resource string, target Object) error {
 	lw := GetListerWatcher(ctx, client, resource, target)
 	_, err := watchclient.UntilWithSync(ctx, lw, target, nil, readyCondition(target))
 	return err
 }
 
-func readyCondition(target object) watchclient.ConditionFunc {
+// WaitUntilCondition watches for mutations of the target object until the condition of the given
+// type has the desired status. An empty condition type is resolved to the ready condition type
+// of the target. Waiting for a condition to be True fails with ErrConditionFailed once the
+// condition is False, as does the target being deleted.
+func WaitUntilCondition(ctx context.Context, client rest.Interface, resource string, target Object, conditionType apis.ConditionType, status corev1.ConditionStatus) error {
+	lw := GetListerWatcher(ctx, client, resource, target)
+	_, err := watchclient.UntilWithSync(ctx, lw, target, nil, statusCondition(target, conditionType, status))
+	return err
+}
+
+// WaitUntilDeleted watches the target object until it is removed.
+func WaitUntilDeleted(ctx context.Context, client rest.Interface, resource string, target Object) error {
+	lw := GetListerWatcher(ctx, client, resource, target)
+	precondition := func(store cache.Store) (bool, error) {
+		_, exists, err := store.Get(target)
+		if err != nil {
+			return false, err
+		}
+		return !exists, nil
+	}
+	_, err := watchclient.UntilWithSync(ctx, lw, target, precondition, deletedCondition(target))
+	return err
+}
+
+func readyCondition(target Object) watchclient.ConditionFunc {
 	return func(event watch.Event) (bool, error) {
 		if event.Type == watch.Error {
 			return false, fmt.Errorf("error waiting for ready")
 		}
-		obj, ok := event.Object.(object)
+		obj, ok := event.Object.(Object)
 		if !ok || obj.GetUID() != target.GetUID() {
 			// event is not for the target resource
 			return false, nil
@@ -75,13 +106,87 @@ func readyCondition(target object) watchclient.ConditionFunc {
 	}
 }
 
+func statusCondition(target Object, conditionType apis.ConditionType, desired corev1.ConditionStatus) watchclient.ConditionFunc {
+	return func(event watch.Event) (bool, error) {
+		if event.Type == watch.Error {
+			return false, fmt.Errorf("error waiting for condition")
+		}
+		obj, ok := event.Object.(Object)
+		if !ok || obj.GetUID() != target.GetUID() {
+			// event is not for the target resource
+			return false, nil
+		}
+		switch event.Type {
+		case watch.Added, watch.Modified:
+			status := obj.GetStatus()
+			readyType := status.GetReadyConditionType()
+			if conditionType == "" || conditionType == readyType {
+				if desired == corev1.ConditionTrue && status.IsReady() {
+					return true, nil
+				}
+				readyCond := status.GetCondition(readyType)
+				if desired == corev1.ConditionTrue && readyCond != nil && readyCond.IsFalse() {
+					return false, &conditionError{message: fmt.Sprintf("failed to become ready: %s", readyCond.Message)}
+				}
+				if readyCond != nil && readyCond.Status == desired {
+					return true, nil
+				}
+				return false, nil
+			}
+			cond := status.GetCondition(conditionType)
+			if cond != nil && cond.Status == desired {
+				return true, nil
+			}
+			if desired == corev1.ConditionTrue && cond != nil && cond.IsFalse() {
+				message := fmt.Sprintf("condition %s is False", conditionType)
+				if cond.Message != "" {
+					message = fmt.Sprintf("%s: %s", message, cond.Message)
+				}
+				return false, &conditionError{message: message}
+			}
+			return false, nil
+		case watch.Deleted:
+			return false, &conditionError{message: fmt.Sprintf("%s %q deleted", strings.ToLower(target.GetObjectKind().GroupVersionKind().Kind), target.GetName())}
+		}
+		return false, nil
+	}
+}
+
+func deletedCondition(target Object) watchclient.ConditionFunc {
+	return func(event watch.Event) (bool, error) {
+		if event.Type == watch.Error {
+			return false, fmt.Errorf("error waiting for delete")
+		}
+		obj, ok := event.Object.(metav1.Object)
+		if !ok || obj.GetUID() != target.GetUID() {
+			// event is not for the target resource
+			return false, nil
+		}
+		return event.Type == watch.Deleted, nil
+	}
+}
+
+// conditionError is returned when the target resource reached a state that cannot satisfy the
+// wait. The error matches ErrConditionFailed.
+type conditionError struct {
+	message string
+}
+
+func (e *conditionError) Error() string {
+	return e.message
+}
+
+func (e *conditionError) Is(err error) bool {
+	return err == ErrConditionFailed
+}
+
 type lwKey struct{}
 
 func WithListerWatcher(ctx context.Context, lw cache.ListerWatcher) context.Context {
 	return context.WithValue(ctx, lwKey{}, lw)
 }
 
-func GetListerWatcher(ctx context.Context, client rest.Interface, resource string, target object) cache.ListerWatcher {
+func GetListerWatcher(ctx context.Context, client rest.Interface, resource string, target Object) cache.ListerWatcher {
 	if lw, ok := ctx.Value(lwKey{}).(cache.ListerWatcher); ok {
 		return lw
 	}
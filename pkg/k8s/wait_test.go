@@ -18,6 +18,7 @@ package k8s_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -117,6 +118,177 @@ func TestWaitUntilReady(t *testing.T) {
 	}
 }
 
+func TestWaitUntilCondition(t *testing.T) {
+	// using Application, but any type will work
+	application := &buildv1alpha1.Application{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Application",
+			APIVersion: "build.projectriff.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-application",
+			UID:       "c6acbbab-87dd-11e9-807c-42010a80011d",
+		},
+		Status: buildv1alpha1.ApplicationStatus{
+			Status: apis.Status{
+				Conditions: apis.Conditions{
+					{
+						Type:   apis.ConditionReady,
+						Status: corev1.ConditionUnknown,
+					},
+					{
+						Type:   buildv1alpha1.ApplicationConditionImageResolved,
+						Status: corev1.ConditionUnknown,
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		conditionType apis.ConditionType
+		status        corev1.ConditionStatus
+		events        []watch.Event
+		err           error
+	}{{
+		name:   "default ready condition",
+		status: corev1.ConditionTrue,
+		events: []watch.Event{
+			updateReady(application, corev1.ConditionTrue, ""),
+		},
+	}, {
+		name:          "ready condition false",
+		conditionType: apis.ConditionReady,
+		status:        corev1.ConditionTrue,
+		events: []watch.Event{
+			updateReady(application, corev1.ConditionFalse, "test not ready"),
+		},
+		err: fmt.Errorf("failed to become ready: %s", "test not ready"),
+	}, {
+		name:          "wait for ready false",
+		conditionType: apis.ConditionReady,
+		status:        corev1.ConditionFalse,
+		events: []watch.Event{
+			updateReady(application, corev1.ConditionFalse, "test not ready"),
+		},
+	}, {
+		name:          "custom condition",
+		conditionType: buildv1alpha1.ApplicationConditionImageResolved,
+		status:        corev1.ConditionTrue,
+		events: []watch.Event{
+			updateCondition(application, buildv1alpha1.ApplicationConditionImageResolved, corev1.ConditionUnknown),
+			updateCondition(application, buildv1alpha1.ApplicationConditionImageResolved, corev1.ConditionTrue),
+		},
+	}, {
+		name:          "custom condition false",
+		conditionType: buildv1alpha1.ApplicationConditionImageResolved,
+		status:        corev1.ConditionTrue,
+		events: []watch.Event{
+			updateCondition(application, buildv1alpha1.ApplicationConditionImageResolved, corev1.ConditionFalse),
+		},
+		err: fmt.Errorf("condition %s is False", buildv1alpha1.ApplicationConditionImageResolved),
+	}, {
+		name:          "wait for custom condition false",
+		conditionType: buildv1alpha1.ApplicationConditionImageResolved,
+		status:        corev1.ConditionFalse,
+		events: []watch.Event{
+			updateCondition(application, buildv1alpha1.ApplicationConditionImageResolved, corev1.ConditionFalse),
+		},
+	}, {
+		name:          "bail on delete",
+		conditionType: buildv1alpha1.ApplicationConditionImageResolved,
+		status:        corev1.ConditionTrue,
+		events: []watch.Event{
+			updateCondition(application, buildv1alpha1.ApplicationConditionImageResolved, corev1.ConditionUnknown),
+			watch.Event{Type: watch.Deleted, Object: application.DeepCopy()},
+		},
+		err: fmt.Errorf("%s %q deleted", "application", "my-application"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lw := cachetesting.NewFakeControllerSource()
+			defer lw.Shutdown()
+			ctx := k8s.WithListerWatcher(context.Background(), lw)
+
+			client := rifftesting.NewClient(application)
+			done := make(chan error, 1)
+			defer close(done)
+			go func() {
+				done <- k8s.WaitUntilCondition(ctx, client.Build().RESTClient(), "applications", application, test.conditionType, test.status)
+			}()
+
+			time.Sleep(5 * time.Millisecond)
+			for _, event := range test.events {
+				lw.Change(event, 1)
+			}
+
+			err := <-done
+			if expected, actual := fmt.Sprintf("%s", test.err), fmt.Sprintf("%s", err); expected != actual {
+				t.Errorf("expected error %v, actually %v", expected, actual)
+			}
+			if err != nil && !errors.Is(err, k8s.ErrConditionFailed) {
+				t.Errorf("expected error to be a condition failure, actually %#v", err)
+			}
+		})
+	}
+}
+
+func TestWaitUntilDeleted(t *testing.T) {
+	// using Application, but any type will work
+	application := &buildv1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-application",
+			UID:       "c6acbbab-87dd-11e9-807c-42010a80011d",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		present bool
+		events  []watch.Event
+	}{{
+		name: "already deleted",
+	}, {
+		name:    "deleted",
+		present: true,
+		events: []watch.Event{
+			watch.Event{Type: watch.Modified, Object: application.DeepCopy()},
+			watch.Event{Type: watch.Deleted, Object: application.DeepCopy()},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lw := cachetesting.NewFakeControllerSource()
+			defer lw.Shutdown()
+			if test.present {
+				lw.Add(application.DeepCopy())
+			}
+			ctx := k8s.WithListerWatcher(context.Background(), lw)
+
+			client := rifftesting.NewClient(application)
+			done := make(chan error, 1)
+			defer close(done)
+			go func() {
+				done <- k8s.WaitUntilDeleted(ctx, client.Build().RESTClient(), "applications", application)
+			}()
+
+			time.Sleep(5 * time.Millisecond)
+			for _, event := range test.events {
+				lw.Change(event, 1)
+			}
+
+			if err := <-done; err != nil {
+				t.Errorf("expected no error, actually %v", err)
+			}
+		})
+	}
+}
+
 func updateReady(application *buildv1alpha1.Application, status corev1.ConditionStatus, message string) watch.Event {
 	application = application.DeepCopy()
 	application.Status.Conditions[0].Status = status
@@ -131,3 +303,13 @@ func updateReadyOther(application *buildv1alpha1.Application, status corev1.Cond
 	application.Status.Conditions[0].Message = message
 	return watch.Event{Type: watch.Modified, Object: application}
 }
+
+func updateCondition(application *buildv1alpha1.Application, conditionType apis.ConditionType, status corev1.ConditionStatus) watch.Event {
+	application = application.DeepCopy()
+	for i := range application.Status.Conditions {
+		if application.Status.Conditions[i].Type == conditionType {
+			application.Status.Conditions[i].Status = status
+		}
+	}
+	return watch.Event{Type: watch.Modified, Object: application}
+}
@@ -18,12 +18,20 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/projectriff/cli/pkg/cli"
+	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
+	servingv1 "github.com/projectriff/system/pkg/apis/thirdparty/knative/serving/v1"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// AdapterOriginalImageAnnotationKey records the image of the adapter's target before the adapter
+// was created, so it can be restored when the adapter is deleted.
+const AdapterOriginalImageAnnotationKey = "knative.projectriff.io/original-image"
+
 func NewAdapterCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "adapter",
@@ -46,3 +54,69 @@ the image for an existing resource.
 
 	return cmd
 }
+
+// adapterTarget is the Knative Service or Configuration updated by an adapter
+type adapterTarget struct {
+	Kind string
+	Name string
+
+	service       *servingv1.Service
+	configuration *servingv1.Configuration
+}
+
+func getAdapterTarget(c *cli.Config, adapter *knativev1alpha1.Adapter) (*adapterTarget, error) {
+	serving := c.KnativeServing()
+	if adapter.Spec.Target.ServiceRef != "" {
+		service, err := serving.Services(adapter.Namespace).Get(adapter.Spec.Target.ServiceRef, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &adapterTarget{Kind: "Service", Name: service.Name, service: service}, nil
+	}
+	configuration, err := serving.Configurations(adapter.Namespace).Get(adapter.Spec.Target.ConfigurationRef, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &adapterTarget{Kind: "Configuration", Name: configuration.Name, configuration: configuration}, nil
+}
+
+func (t *adapterTarget) template() *servingv1.RevisionTemplateSpec {
+	if t.service != nil {
+		return &t.service.Spec.Template
+	}
+	return &t.configuration.Spec.Template
+}
+
+// Image returns the image of the target's first container
+func (t *adapterTarget) Image() string {
+	containers := t.template().Spec.Containers
+	if len(containers) == 0 {
+		return ""
+	}
+	return containers[0].Image
+}
+
+// LatestCreatedRevisionName returns the name of the most recent revision stamped out for the
+// target
+func (t *adapterTarget) LatestCreatedRevisionName() string {
+	if t.service != nil {
+		return t.service.Status.LatestCreatedRevisionName
+	}
+	return t.configuration.Status.LatestCreatedRevisionName
+}
+
+// SetImage updates the image of the target's first container
+func (t *adapterTarget) SetImage(c *cli.Config, image string) error {
+	containers := t.template().Spec.Containers
+	if len(containers) == 0 {
+		return fmt.Errorf("%s %q has no containers", t.Kind, t.Name)
+	}
+	containers[0].Image = image
+	serving := c.KnativeServing()
+	if t.service != nil {
+		_, err := serving.Services(t.service.Namespace).Update(t.service)
+		return err
+	}
+	_, err := serving.Configurations(t.configuration.Namespace).Update(t.configuration)
+	return err
+}
@@ -162,6 +162,13 @@ func (opts *AdapterCreateOptions) Exec(ctx context.Context, c *cli.Config) error
 	if opts.DryRun {
 		cli.DryRunResource(ctx, adapter, adapter.GetGroupVersionKind())
 	} else {
+		// remember the target's image so it can be restored when the adapter is deleted,
+		// a target that can't be read is treated as having no original image
+		if target, err := getAdapterTarget(c, adapter); err == nil && target.Image() != "" {
+			adapter.Annotations = map[string]string{
+				AdapterOriginalImageAnnotationKey: target.Image(),
+			}
+		}
 		var err error
 		adapter, err = c.KnativeRuntime().Adapters(opts.Namespace).Create(adapter)
 		if err != nil {
@@ -221,17 +228,18 @@ the image for an existing resource.
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().StringVar(&opts.ApplicationRef, cli.StripDash(cli.ApplicationRefFlagName), "", "`name` of application to deploy")
-	_ = cmd.MarkFlagCustom(cli.StripDash(cli.ApplicationRefFlagName), "__"+c.Name+"_list_applications")
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.ApplicationRefFlagName), cli.CompleteNames(c, cli.ListApplications))
 	cmd.Flags().StringVar(&opts.ContainerRef, cli.StripDash(cli.ContainerRefFlagName), "", "`name` of container to deploy")
-	_ = cmd.MarkFlagCustom(cli.StripDash(cli.ContainerRefFlagName), "__"+c.Name+"_list_containers")
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.ContainerRefFlagName), cli.CompleteNames(c, cli.ListContainers))
 	cmd.Flags().StringVar(&opts.FunctionRef, cli.StripDash(cli.FunctionRefFlagName), "", "`name` of function to deploy")
-	_ = cmd.MarkFlagCustom(cli.StripDash(cli.FunctionRefFlagName), "__"+c.Name+"_list_functions")
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.FunctionRefFlagName), cli.CompleteNames(c, cli.ListFunctions))
 	cmd.Flags().StringVar(&opts.ConfigurationRef, cli.StripDash(cli.ConfigurationRefFlagName), "", "`name` of Knative configuration to update")
-	_ = cmd.MarkFlagCustom(cli.StripDash(cli.ConfigurationRefFlagName), "__"+c.Name+"_list_knative_configurations")
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.ConfigurationRefFlagName), cli.CompleteNames(c, cli.ListKnativeConfigurations))
 	cmd.Flags().StringVar(&opts.ServiceRef, cli.StripDash(cli.ServiceRefFlagName), "", "`name` of Knative service to update")
-	_ = cmd.MarkFlagCustom(cli.StripDash(cli.ServiceRefFlagName), "__"+c.Name+"_list_knative_services")
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.ServiceRefFlagName), cli.CompleteNames(c, cli.ListKnativeServices))
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch adapter logs")
 	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the adapter to become ready when watching logs")
+	cli.ConfigDefault(cmd, cli.WaitTimeoutFlagName, cli.WaitTimeoutConfigKey)
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
 
 	return cmd
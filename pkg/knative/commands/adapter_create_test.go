@@ -26,6 +26,8 @@ import (
 	"github.com/projectriff/cli/pkg/knative/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
+	servingv1 "github.com/projectriff/system/pkg/apis/thirdparty/knative/serving/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	cachetesting "k8s.io/client-go/tools/cache/testing"
@@ -296,6 +298,120 @@ Created adapter "my-adapter"
 			},
 			ExpectOutput: `
 Created adapter "my-adapter"
+`,
+		},
+		{
+			Name: "record original service image",
+			Args: []string{adapterName, cli.FunctionRefFlagName, functionRef, cli.ServiceRefFlagName, serviceRef},
+			GivenObjects: []runtime.Object{
+				&servingv1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      serviceRef,
+					},
+					Spec: servingv1.ServiceSpec{
+						ConfigurationSpec: servingv1.ConfigurationSpec{
+							Template: servingv1.RevisionTemplateSpec{
+								Spec: servingv1.RevisionSpec{
+									PodSpec: corev1.PodSpec{
+										Containers: []corev1.Container{{Image: "example.com/original"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectCreates: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      adapterName,
+						Annotations: map[string]string{
+							commands.AdapterOriginalImageAnnotationKey: "example.com/original",
+						},
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Build: knativev1alpha1.Build{
+							FunctionRef: functionRef,
+						},
+						Target: knativev1alpha1.AdapterTarget{
+							ServiceRef: serviceRef,
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created adapter "my-adapter"
+`,
+		},
+		{
+			Name: "record original configuration image",
+			Args: []string{adapterName, cli.FunctionRefFlagName, functionRef, cli.ConfigurationRefFlagName, configurationRef},
+			GivenObjects: []runtime.Object{
+				&servingv1.Configuration{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      configurationRef,
+					},
+					Spec: servingv1.ConfigurationSpec{
+						Template: servingv1.RevisionTemplateSpec{
+							Spec: servingv1.RevisionSpec{
+								PodSpec: corev1.PodSpec{
+									Containers: []corev1.Container{{Image: "example.com/original"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectCreates: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      adapterName,
+						Annotations: map[string]string{
+							commands.AdapterOriginalImageAnnotationKey: "example.com/original",
+						},
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Build: knativev1alpha1.Build{
+							FunctionRef: functionRef,
+						},
+						Target: knativev1alpha1.AdapterTarget{
+							ConfigurationRef: configurationRef,
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created adapter "my-adapter"
+`,
+		},
+		{
+			Name: "error getting target",
+			Args: []string{adapterName, cli.FunctionRefFlagName, functionRef, cli.ServiceRefFlagName, serviceRef},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "services"),
+			},
+			ExpectCreates: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      adapterName,
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Build: knativev1alpha1.Build{
+							FunctionRef: functionRef,
+						},
+						Target: knativev1alpha1.AdapterTarget{
+							ServiceRef: serviceRef,
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created adapter "my-adapter"
 `,
 		},
 		{
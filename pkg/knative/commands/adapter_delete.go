@@ -23,12 +23,16 @@ import (
 
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
+	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
 	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type AdapterDeleteOptions struct {
 	options.DeleteOptions
+
+	RestoreImage bool
 }
 
 var (
@@ -47,24 +51,74 @@ func (opts *AdapterDeleteOptions) Validate(ctx context.Context) cli.FieldErrors
 func (opts *AdapterDeleteOptions) Exec(ctx context.Context, c *cli.Config) error {
 	client := c.KnativeRuntime().Adapters(opts.Namespace)
 
+	// the adapter is deleted before its target is restored, otherwise the adapter
+	// would push the latest build image back onto the target
+	adapters := []knativev1alpha1.Adapter{}
+	if opts.RestoreImage {
+		if opts.All {
+			list, err := client.List(metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+			adapters = list.Items
+		} else {
+			for _, name := range opts.Names {
+				adapter, err := client.Get(name, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				adapters = append(adapters, *adapter)
+			}
+		}
+	}
+
 	if opts.All {
 		if err := client.DeleteCollection(nil, metav1.ListOptions{}); err != nil {
 			return err
 		}
 		c.Successf("Deleted adapters in namespace %q\n", opts.Namespace)
-		return nil
+	} else {
+		for _, name := range opts.Names {
+			if err := client.Delete(name, nil); err != nil {
+				return err
+			}
+			c.Successf("Deleted adapter %q\n", name)
+		}
 	}
 
-	for _, name := range opts.Names {
-		if err := client.Delete(name, nil); err != nil {
+	for i := range adapters {
+		if err := opts.restoreImage(c, &adapters[i]); err != nil {
 			return err
 		}
-		c.Successf("Deleted adapter %q\n", name)
 	}
 
 	return nil
 }
 
+func (opts *AdapterDeleteOptions) restoreImage(c *cli.Config, adapter *knativev1alpha1.Adapter) error {
+	image := adapter.Annotations[AdapterOriginalImageAnnotationKey]
+	if image == "" {
+		c.Infof("Adapter %q has no original image recorded, skipping restore\n", adapter.Name)
+		return nil
+	}
+	target, err := getAdapterTarget(c, adapter)
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			return err
+		}
+		c.Infof("Target for adapter %q not found, skipping restore\n", adapter.Name)
+		return nil
+	}
+	if target.Image() == image {
+		return nil
+	}
+	if err := target.SetImage(c, image); err != nil {
+		return err
+	}
+	c.Successf("Restored image %q for %s %q\n", image, strings.ToLower(target.Kind), target.Name)
+	return nil
+}
+
 func NewAdapterDeleteCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	opts := &AdapterDeleteOptions{}
 
@@ -73,10 +127,15 @@ func NewAdapterDeleteCommand(ctx context.Context, c *cli.Config) *cobra.Command
 		Short: "delete adapter(s)",
 		Long: strings.TrimSpace(`
 Delete one or more adapters by name or all adapters within a namespace.
+
+The target Knative Service or Configuration is left running the latest image
+from the build. Use ` + cli.RestoreImageFlagName + ` to revert the target to the image it
+had before the adapter was created.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s knative adapter delete my-adapter", c.Name),
 			fmt.Sprintf("%s knative adapter delete %s", c.Name, cli.AllFlagName),
+			fmt.Sprintf("%s knative adapter delete my-adapter %s", c.Name, cli.RestoreImageFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateOptions(ctx, opts),
 		RunE:    cli.ExecOptions(ctx, c, opts),
@@ -85,9 +144,11 @@ Delete one or more adapters by name or all adapters within a namespace.
 	cli.Args(cmd,
 		cli.NamesArg(&opts.Names),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNamesArg(c, cli.ListKnativeAdapters)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(cli.AllFlagName), false, "delete all adapters within the namespace")
+	cmd.Flags().BoolVar(&opts.RestoreImage, cli.StripDash(cli.RestoreImageFlagName), false, "revert the target to its image from before the adapter was created")
 
 	return cmd
 }
@@ -23,6 +23,8 @@ import (
 	"github.com/projectriff/cli/pkg/knative/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
+	servingv1 "github.com/projectriff/system/pkg/apis/thirdparty/knative/serving/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -52,6 +54,7 @@ func TestAdapterDeleteCommand(t *testing.T) {
 	adapterName := "test-adapter"
 	adapterOtherName := "test-other-adapter"
 	defaultNamespace := "default"
+	serviceName := "my-service"
 
 	table := rifftesting.CommandTable{
 		{
@@ -187,6 +190,328 @@ Deleted adapter "test-other-adapter"
 			}},
 			ShouldError: true,
 		},
+		{
+			Name: "delete adapter, restore image",
+			Args: []string{adapterName, cli.RestoreImageFlagName},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+						Annotations: map[string]string{
+							commands.AdapterOriginalImageAnnotationKey: "example.com/original",
+						},
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Target: knativev1alpha1.AdapterTarget{
+							ServiceRef: serviceName,
+						},
+					},
+				},
+				&servingv1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceName,
+						Namespace: defaultNamespace,
+					},
+					Spec: servingv1.ServiceSpec{
+						ConfigurationSpec: servingv1.ConfigurationSpec{
+							Template: servingv1.RevisionTemplateSpec{
+								Spec: servingv1.RevisionSpec{
+									PodSpec: corev1.PodSpec{
+										Containers: []corev1.Container{{Image: "example.com/latest"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectUpdates: []runtime.Object{
+				&servingv1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceName,
+						Namespace: defaultNamespace,
+					},
+					Spec: servingv1.ServiceSpec{
+						ConfigurationSpec: servingv1.ConfigurationSpec{
+							Template: servingv1.RevisionTemplateSpec{
+								Spec: servingv1.RevisionSpec{
+									PodSpec: corev1.PodSpec{
+										Containers: []corev1.Container{{Image: "example.com/original"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectDeletes: []rifftesting.DeleteRef{{
+				Group:     "knative.projectriff.io",
+				Resource:  "adapters",
+				Namespace: defaultNamespace,
+				Name:      adapterName,
+			}},
+			ExpectOutput: `
+Deleted adapter "test-adapter"
+Restored image "example.com/original" for service "my-service"
+`,
+		},
+		{
+			Name: "delete all adapters, restore image",
+			Args: []string{cli.AllFlagName, cli.RestoreImageFlagName},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+						Annotations: map[string]string{
+							commands.AdapterOriginalImageAnnotationKey: "example.com/original",
+						},
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Target: knativev1alpha1.AdapterTarget{
+							ServiceRef: serviceName,
+						},
+					},
+				},
+				&servingv1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceName,
+						Namespace: defaultNamespace,
+					},
+					Spec: servingv1.ServiceSpec{
+						ConfigurationSpec: servingv1.ConfigurationSpec{
+							Template: servingv1.RevisionTemplateSpec{
+								Spec: servingv1.RevisionSpec{
+									PodSpec: corev1.PodSpec{
+										Containers: []corev1.Container{{Image: "example.com/latest"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectUpdates: []runtime.Object{
+				&servingv1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceName,
+						Namespace: defaultNamespace,
+					},
+					Spec: servingv1.ServiceSpec{
+						ConfigurationSpec: servingv1.ConfigurationSpec{
+							Template: servingv1.RevisionTemplateSpec{
+								Spec: servingv1.RevisionSpec{
+									PodSpec: corev1.PodSpec{
+										Containers: []corev1.Container{{Image: "example.com/original"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectDeleteCollections: []rifftesting.DeleteCollectionRef{{
+				Group:     "knative.projectriff.io",
+				Resource:  "adapters",
+				Namespace: defaultNamespace,
+			}},
+			ExpectOutput: `
+Deleted adapters in namespace "default"
+Restored image "example.com/original" for service "my-service"
+`,
+		},
+		{
+			Name: "delete adapter, restore image already current",
+			Args: []string{adapterName, cli.RestoreImageFlagName},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+						Annotations: map[string]string{
+							commands.AdapterOriginalImageAnnotationKey: "example.com/original",
+						},
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Target: knativev1alpha1.AdapterTarget{
+							ServiceRef: serviceName,
+						},
+					},
+				},
+				&servingv1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceName,
+						Namespace: defaultNamespace,
+					},
+					Spec: servingv1.ServiceSpec{
+						ConfigurationSpec: servingv1.ConfigurationSpec{
+							Template: servingv1.RevisionTemplateSpec{
+								Spec: servingv1.RevisionSpec{
+									PodSpec: corev1.PodSpec{
+										Containers: []corev1.Container{{Image: "example.com/original"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectDeletes: []rifftesting.DeleteRef{{
+				Group:     "knative.projectriff.io",
+				Resource:  "adapters",
+				Namespace: defaultNamespace,
+				Name:      adapterName,
+			}},
+			ExpectOutput: `
+Deleted adapter "test-adapter"
+`,
+		},
+		{
+			Name: "delete adapter, restore image not recorded",
+			Args: []string{adapterName, cli.RestoreImageFlagName},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Target: knativev1alpha1.AdapterTarget{
+							ServiceRef: serviceName,
+						},
+					},
+				},
+				&servingv1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceName,
+						Namespace: defaultNamespace,
+					},
+					Spec: servingv1.ServiceSpec{
+						ConfigurationSpec: servingv1.ConfigurationSpec{
+							Template: servingv1.RevisionTemplateSpec{
+								Spec: servingv1.RevisionSpec{
+									PodSpec: corev1.PodSpec{
+										Containers: []corev1.Container{{Image: "example.com/latest"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectDeletes: []rifftesting.DeleteRef{{
+				Group:     "knative.projectriff.io",
+				Resource:  "adapters",
+				Namespace: defaultNamespace,
+				Name:      adapterName,
+			}},
+			ExpectOutput: `
+Deleted adapter "test-adapter"
+Adapter "test-adapter" has no original image recorded, skipping restore
+`,
+		},
+		{
+			Name: "delete adapter, restore image target not found",
+			Args: []string{adapterName, cli.RestoreImageFlagName},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+						Annotations: map[string]string{
+							commands.AdapterOriginalImageAnnotationKey: "example.com/original",
+						},
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Target: knativev1alpha1.AdapterTarget{
+							ServiceRef: serviceName,
+						},
+					},
+				},
+			},
+			ExpectDeletes: []rifftesting.DeleteRef{{
+				Group:     "knative.projectriff.io",
+				Resource:  "adapters",
+				Namespace: defaultNamespace,
+				Name:      adapterName,
+			}},
+			ExpectOutput: `
+Deleted adapter "test-adapter"
+Target for adapter "test-adapter" not found, skipping restore
+`,
+		},
+		{
+			Name: "delete adapter, restore image update error",
+			Args: []string{adapterName, cli.RestoreImageFlagName},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+						Annotations: map[string]string{
+							commands.AdapterOriginalImageAnnotationKey: "example.com/original",
+						},
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Target: knativev1alpha1.AdapterTarget{
+							ServiceRef: serviceName,
+						},
+					},
+				},
+				&servingv1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceName,
+						Namespace: defaultNamespace,
+					},
+					Spec: servingv1.ServiceSpec{
+						ConfigurationSpec: servingv1.ConfigurationSpec{
+							Template: servingv1.RevisionTemplateSpec{
+								Spec: servingv1.RevisionSpec{
+									PodSpec: corev1.PodSpec{
+										Containers: []corev1.Container{{Image: "example.com/latest"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("update", "services"),
+			},
+			ExpectUpdates: []runtime.Object{
+				&servingv1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceName,
+						Namespace: defaultNamespace,
+					},
+					Spec: servingv1.ServiceSpec{
+						ConfigurationSpec: servingv1.ConfigurationSpec{
+							Template: servingv1.RevisionTemplateSpec{
+								Spec: servingv1.RevisionSpec{
+									PodSpec: corev1.PodSpec{
+										Containers: []corev1.Container{{Image: "example.com/original"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectDeletes: []rifftesting.DeleteRef{{
+				Group:     "knative.projectriff.io",
+				Resource:  "adapters",
+				Namespace: defaultNamespace,
+				Name:      adapterName,
+			}},
+			ShouldError: true,
+		},
+		{
+			Name:        "delete adapter, restore image adapter not found",
+			Args:        []string{adapterName, cli.RestoreImageFlagName},
+			ShouldError: true,
+		},
 	}
 
 	table.Run(t, commands.NewAdapterDeleteCommand)
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
 	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
@@ -59,9 +60,57 @@ func (opts *AdapterStatusOptions) Exec(ctx context.Context, c *cli.Config) error
 	ready := adapter.Status.GetCondition(knativev1alpha1.AdapterConditionReady)
 	cli.PrintResourceStatus(c, adapter.Name, ready)
 
+	status := adapterTargetStatus{
+		LatestImage: adapter.Status.LatestImage,
+	}
+	switch {
+	case adapter.Spec.Target.ServiceRef != "":
+		status.Kind, status.Name = "Service", adapter.Spec.Target.ServiceRef
+	case adapter.Spec.Target.ConfigurationRef != "":
+		status.Kind, status.Name = "Configuration", adapter.Spec.Target.ConfigurationRef
+	default:
+		return nil
+	}
+	target, err := getAdapterTarget(c, adapter)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+	if target != nil {
+		status.Image = target.Image()
+		upToDate := status.Image != "" && status.Image == status.LatestImage
+		status.UpToDate = &upToDate
+		if upToDate && target.LatestCreatedRevisionName() != "" {
+			// the revision stamped out for the latest image marks the last adaptation
+			revision, err := c.KnativeServing().Revisions(opts.Namespace).Get(target.LatestCreatedRevisionName(), metav1.GetOptions{})
+			if err != nil && !apierrs.IsNotFound(err) {
+				return err
+			}
+			if err == nil && len(revision.Spec.Containers) > 0 && revision.Spec.Containers[0].Image == status.LatestImage {
+				status.LastAdapted = &revision.CreationTimestamp
+			}
+		}
+	}
+	s, err := yaml.Marshal(status)
+	if err != nil {
+		return err
+	}
+	c.Printf("# target\n")
+	c.Printf("---\n")
+	c.Printf("%s", string(s))
+
 	return nil
 }
 
+// adapterTargetStatus compares the adapter's target with the latest image from the build
+type adapterTargetStatus struct {
+	Kind        string       `json:"kind"`
+	Name        string       `json:"name"`
+	Image       string       `json:"image,omitempty"`
+	LatestImage string       `json:"latestImage,omitempty"`
+	UpToDate    *bool        `json:"upToDate,omitempty"`
+	LastAdapted *metav1.Time `json:"lastAdapted,omitempty"`
+}
+
 func NewAdapterStatusCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	opts := &AdapterStatusOptions{}
 
@@ -75,6 +124,10 @@ The Ready condition is shown which should include a reason code and a
 descriptive message when the status is not "True". The status for the condition
 may be: "True", "False" or "Unknown". An "Unknown" status is common while the
 adapter roll out is processed.
+
+The target's current image is shown alongside the latest image from the build.
+When the target is up to date, the time the revision for the latest image was
+created is shown as the last adaptation.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s knative adapter status my-adapter", c.Name),
@@ -86,6 +139,7 @@ adapter roll out is processed.
 	cli.Args(cmd,
 		cli.NameArg(&opts.Name),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListKnativeAdapters)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 
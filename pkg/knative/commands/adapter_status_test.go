@@ -23,6 +23,7 @@ import (
 	"github.com/projectriff/cli/pkg/knative/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
+	servingv1 "github.com/projectriff/system/pkg/apis/thirdparty/knative/serving/v1"
 	"github.com/vmware-labs/reconciler-runtime/apis"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -53,6 +54,8 @@ func TestAdapterStatusOptions(t *testing.T) {
 func TestAdapterStatusCommand(t *testing.T) {
 	defaultNamespace := "default"
 	adapterName := "my-adapter"
+	serviceName := "my-service"
+	configurationName := "my-config"
 
 	table := rifftesting.CommandTable{
 		{
@@ -98,6 +101,241 @@ status: "False"
 type: Ready
 `,
 		},
+		{
+			Name: "show target service",
+			Args: []string{adapterName},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Target: knativev1alpha1.AdapterTarget{
+							ServiceRef: serviceName,
+						},
+					},
+					Status: knativev1alpha1.AdapterStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+						LatestImage: "example.com/latest",
+					},
+				},
+				&servingv1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceName,
+						Namespace: defaultNamespace,
+					},
+					Spec: servingv1.ServiceSpec{
+						ConfigurationSpec: servingv1.ConfigurationSpec{
+							Template: servingv1.RevisionTemplateSpec{
+								Spec: servingv1.RevisionSpec{
+									PodSpec: corev1.PodSpec{
+										Containers: []corev1.Container{{Image: "example.com/latest"}},
+									},
+								},
+							},
+						},
+					},
+					Status: servingv1.ServiceStatus{
+						ConfigurationStatusFields: servingv1.ConfigurationStatusFields{
+							LatestCreatedRevisionName: "my-service-00002",
+						},
+					},
+				},
+				&servingv1.Revision{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-service-00002",
+						Namespace: defaultNamespace,
+						CreationTimestamp: metav1.Time{
+							Time: time.Date(2019, 6, 29, 01, 50, 00, 0, time.UTC),
+						},
+					},
+					Spec: servingv1.RevisionSpec{
+						PodSpec: corev1.PodSpec{
+							Containers: []corev1.Container{{Image: "example.com/latest"}},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+# my-adapter: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+# target
+---
+image: example.com/latest
+kind: Service
+lastAdapted: "2019-06-29T01:50:00Z"
+latestImage: example.com/latest
+name: my-service
+upToDate: true
+`,
+		},
+		{
+			Name: "show stale target configuration",
+			Args: []string{adapterName},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Target: knativev1alpha1.AdapterTarget{
+							ConfigurationRef: configurationName,
+						},
+					},
+					Status: knativev1alpha1.AdapterStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+						LatestImage: "example.com/latest",
+					},
+				},
+				&servingv1.Configuration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      configurationName,
+						Namespace: defaultNamespace,
+					},
+					Spec: servingv1.ConfigurationSpec{
+						Template: servingv1.RevisionTemplateSpec{
+							Spec: servingv1.RevisionSpec{
+								PodSpec: corev1.PodSpec{
+									Containers: []corev1.Container{{Image: "example.com/original"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+# my-adapter: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+# target
+---
+image: example.com/original
+kind: Configuration
+latestImage: example.com/latest
+name: my-config
+upToDate: false
+`,
+		},
+		{
+			Name: "show missing target",
+			Args: []string{adapterName},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Target: knativev1alpha1.AdapterTarget{
+							ServiceRef: serviceName,
+						},
+					},
+					Status: knativev1alpha1.AdapterStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+						LatestImage: "example.com/latest",
+					},
+				},
+			},
+			ExpectOutput: `
+# my-adapter: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+# target
+---
+kind: Service
+latestImage: example.com/latest
+name: my-service
+`,
+		},
+		{
+			Name: "get target error",
+			Args: []string{adapterName},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Adapter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adapterName,
+						Namespace: defaultNamespace,
+					},
+					Spec: knativev1alpha1.AdapterSpec{
+						Target: knativev1alpha1.AdapterTarget{
+							ServiceRef: serviceName,
+						},
+					},
+					Status: knativev1alpha1.AdapterStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+									LastTransitionTime: apis.VolatileTime{
+										Inner: metav1.Time{
+											Time: time.Date(2019, 6, 29, 01, 44, 05, 0, time.UTC),
+										},
+									},
+								},
+							},
+						},
+						LatestImage: "example.com/latest",
+					},
+				},
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "services"),
+			},
+			ExpectOutput: `
+# my-adapter: Ready
+---
+lastTransitionTime: "2019-06-29T01:44:05Z"
+status: "True"
+type: Ready
+`,
+			ShouldError: true,
+		},
 		{
 			Name: "not found",
 			Args: []string{adapterName},
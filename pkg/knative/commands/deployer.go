@@ -49,6 +49,7 @@ The hostname to access the deployer is available in the deployer listing.
 	cmd.AddCommand(NewDeployerDeleteCommand(ctx, c))
 	cmd.AddCommand(NewDeployerStatusCommand(ctx, c))
 	cmd.AddCommand(NewDeployerTailCommand(ctx, c))
+	cmd.AddCommand(NewDeployerRevisionsCommand(ctx, c))
 
 	return cmd
 }
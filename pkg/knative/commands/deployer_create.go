@@ -37,6 +37,9 @@ import (
 
 type DeployerCreateOptions struct {
 	options.ResourceOptions
+	options.WorkloadOptions
+	options.VolumeOptions
+	options.EnvFileOptions
 
 	Image          string
 	ApplicationRef string
@@ -48,8 +51,10 @@ type DeployerCreateOptions struct {
 
 	ContainerConcurrency int64
 
-	Env     []string
-	EnvFrom []string
+	Env               []string
+	EnvFrom           []string
+	EnvFromConfigMaps []string
+	EnvFromSecrets    []string
 
 	LimitCPU    string
 	LimitMemory string
@@ -60,13 +65,15 @@ type DeployerCreateOptions struct {
 	Tail        bool
 	WaitTimeout string
 
-	DryRun bool
+	DryRun      bool
+	Interactive bool
 }
 
 var (
 	_ cli.Validatable = (*DeployerCreateOptions)(nil)
 	_ cli.Executable  = (*DeployerCreateOptions)(nil)
 	_ cli.DryRunable  = (*DeployerCreateOptions)(nil)
+	_ cli.Promptable  = (*DeployerCreateOptions)(nil)
 )
 
 func (opts *DeployerCreateOptions) Validate(ctx context.Context) cli.FieldErrors {
@@ -115,7 +122,11 @@ func (opts *DeployerCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 	errs = errs.Also(validation.ContainerConcurrency(opts.ContainerConcurrency, cli.ContainerConcurrencyFlagName))
 
 	errs = errs.Also(validation.EnvVars(opts.Env, cli.EnvFlagName))
+	errs = errs.Also(opts.EnvFileOptions.Validate(ctx))
+	errs = errs.Also(validation.K8sNames(opts.EnvFromConfigMaps, cli.EnvFromConfigMapFlagName))
+	errs = errs.Also(validation.K8sNames(opts.EnvFromSecrets, cli.EnvFromSecretFlagName))
 	errs = errs.Also(validation.EnvVarFroms(opts.EnvFrom, cli.EnvFromFlagName))
+	errs = errs.Also(opts.VolumeOptions.Validate(ctx))
 
 	if opts.LimitCPU != "" {
 		errs = errs.Also(validation.Quantity(opts.LimitCPU, cli.LimitCPUFlagName))
@@ -142,6 +153,8 @@ func (opts *DeployerCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 		errs = errs.Also(validation.PortNumber(opts.TargetPort, cli.TargetPortFlagName))
 	}
 
+	errs = errs.Also(opts.WorkloadOptions.ValidateKnative(ctx))
+
 	if opts.Tail {
 		if opts.WaitTimeout == "" {
 			errs = errs.Also(cli.ErrMissingField(cli.WaitTimeoutFlagName))
@@ -157,6 +170,25 @@ func (opts *DeployerCreateOptions) Validate(ctx context.Context) cli.FieldErrors
 	return errs
 }
 
+func (opts *DeployerCreateOptions) Prompt(ctx context.Context, p *cli.Prompter) error {
+	if err := p.PromptName("Name", &opts.Name); err != nil {
+		return err
+	}
+	err := p.PromptFlagOneOf("Workload", opts.Namespace,
+		cli.PromptChoice{Label: "function", FlagName: cli.FunctionRefFlagName, List: cli.ListFunctions},
+		cli.PromptChoice{Label: "application", FlagName: cli.ApplicationRefFlagName, List: cli.ListApplications},
+		cli.PromptChoice{Label: "container", FlagName: cli.ContainerRefFlagName, List: cli.ListContainers},
+		cli.PromptChoice{Label: "image", FlagName: cli.ImageFlagName},
+	)
+	if err != nil {
+		return err
+	}
+	return p.PromptFlagChoice(cli.IngressPolicyFlagName, "Ingress policy", []string{
+		string(knativev1alpha1.IngressPolicyClusterLocal),
+		string(knativev1alpha1.IngressPolicyExternal),
+	})
+}
+
 func (opts *DeployerCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
 	deployer := &knativev1alpha1.Deployer{
 		ObjectMeta: metav1.ObjectMeta{
@@ -198,6 +230,25 @@ func (opts *DeployerCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 		deployer.Spec.ContainerConcurrency = &opts.ContainerConcurrency
 	}
 
+	env, err := opts.EnvFromFiles()
+	if err != nil {
+		return err
+	}
+	deployer.Spec.Template.Spec.Containers[0].Env = append(deployer.Spec.Template.Spec.Containers[0].Env, env...)
+	for _, name := range opts.EnvFromConfigMaps {
+		deployer.Spec.Template.Spec.Containers[0].EnvFrom = append(deployer.Spec.Template.Spec.Containers[0].EnvFrom, corev1.EnvFromSource{
+			ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			},
+		})
+	}
+	for _, name := range opts.EnvFromSecrets {
+		deployer.Spec.Template.Spec.Containers[0].EnvFrom = append(deployer.Spec.Template.Spec.Containers[0].EnvFrom, corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			},
+		})
+	}
 	for _, env := range opts.Env {
 		if deployer.Spec.Template.Spec.Containers[0].Env == nil {
 			deployer.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{}
@@ -227,6 +278,9 @@ func (opts *DeployerCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 			{Protocol: corev1.ProtocolTCP, ContainerPort: opts.TargetPort},
 		}
 	}
+	// knative routes probes to the container port
+	opts.WorkloadOptions.ApplyTo(&deployer.Spec.Template.Spec, 0)
+	opts.VolumeOptions.ApplyTo(&deployer.Spec.Template.Spec)
 	if opts.MaxScale > 0 {
 		deployer.Spec.Scale.Max = &opts.MaxScale
 	}
@@ -291,6 +345,23 @@ Knative Route referencing the Configuration.
 
 The runtime environment can be configured by ` + cli.EnvFlagName + ` for static key-value pairs
 and ` + cli.EnvFromFlagName + ` to map values from a ConfigMap or Secret.
+Variables may also be read from dotenv formatted files with ` + cli.EnvFileFlagName + `, or
+imported for every key of a ConfigMap or Secret with ` + cli.EnvFromConfigMapFlagName + ` and
+` + cli.EnvFromSecretFlagName + `. Values set by ` + cli.EnvFlagName + ` take precedence over values from a file.
+
+Health checks are defined by ` + cli.ReadinessProbeFlagName + ` and ` + cli.LivenessProbeFlagName + ` as an HTTP
+GET, a TCP connection or a command to exec in the container, along with
+optional timing and threshold flags for each probe. Knative sends probes to the
+workload's port, probe ports may not be set. An exec command is split on
+whitespace, its arguments may not contain spaces.
+
+Config maps and secrets are mounted into the workload as read only files with
+` + cli.MountConfigMapFlagName + ` and ` + cli.MountSecretFlagName + `. Mount paths must be absolute and may
+not overlap. The workload runs as the namespace's default service account
+unless ` + cli.ServiceAccountFlagName + ` is set.
+
+` + cli.InteractiveFlagName + ` prompts for missing values when stdin is a terminal and prints
+the equivalent command, other values are validated as usual.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s knative deployer create my-app-deployer %s my-app", c.Name, cli.ApplicationRefFlagName),
@@ -298,35 +369,46 @@ and ` + cli.EnvFromFlagName + ` to map values from a ConfigMap or Secret.
 			fmt.Sprintf("%s knative deployer create my-func-deployer %s my-container", c.Name, cli.ContainerRefFlagName),
 			fmt.Sprintf("%s knative deployer create my-image-deployer %s registry.example.com/my-image:latest", c.Name, cli.ImageFlagName),
 		}, "\n"),
-		PreRunE: cli.ValidateOptions(ctx, opts),
-		RunE:    cli.ExecOptions(ctx, c, opts),
+		PreRunE: cli.Sequence(
+			cli.PromptOptions(ctx, c, &opts.Interactive, opts),
+			cli.ValidateOptions(ctx, opts),
+		),
+		RunE: cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.Args(cmd,
-		cli.NameArg(&opts.Name),
+		cli.InteractiveNameArg(&opts.Name, &opts.Interactive),
 	)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().StringVar(&opts.Image, cli.StripDash(cli.ImageFlagName), "", "container `image` to deploy")
 	cmd.Flags().StringVar(&opts.ApplicationRef, cli.StripDash(cli.ApplicationRefFlagName), "", "`name` of application to deploy")
-	_ = cmd.MarkFlagCustom(cli.StripDash(cli.ApplicationRefFlagName), "__"+c.Name+"_list_applications")
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.ApplicationRefFlagName), cli.CompleteNames(c, cli.ListApplications))
 	cmd.Flags().StringVar(&opts.ContainerRef, cli.StripDash(cli.ContainerRefFlagName), "", "`name` of container to deploy")
-	_ = cmd.MarkFlagCustom(cli.StripDash(cli.ContainerRefFlagName), "__"+c.Name+"_list_containers")
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.ContainerRefFlagName), cli.CompleteNames(c, cli.ListContainers))
 	cmd.Flags().StringVar(&opts.FunctionRef, cli.StripDash(cli.FunctionRefFlagName), "", "`name` of function to deploy")
-	_ = cmd.MarkFlagCustom(cli.StripDash(cli.FunctionRefFlagName), "__"+c.Name+"_list_functions")
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.FunctionRefFlagName), cli.CompleteNames(c, cli.ListFunctions))
 	cmd.Flags().StringVar(&opts.IngressPolicy, cli.StripDash(cli.IngressPolicyFlagName), string(knativev1alpha1.IngressPolicyClusterLocal), fmt.Sprintf("ingress `policy` for network access to the workload, one of %q or %q", knativev1alpha1.IngressPolicyClusterLocal, knativev1alpha1.IngressPolicyExternal))
-	_ = cmd.MarkFlagCustom(cli.StripDash(cli.IngressPolicyFlagName), "__"+c.Name+"_ingress_policy")
+	cli.ConfigDefault(cmd, cli.IngressPolicyFlagName, cli.IngressPolicyConfigKey)
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.IngressPolicyFlagName), cli.CompleteValues(string(knativev1alpha1.IngressPolicyClusterLocal), string(knativev1alpha1.IngressPolicyExternal)))
 	cmd.Flags().Int64Var(&opts.ContainerConcurrency, cli.StripDash(cli.ContainerConcurrencyFlagName), 0, "the maximum `number` of concurrent requests to send to a replica at one time")
 	cmd.Flags().StringArrayVar(&opts.Env, cli.StripDash(cli.EnvFlagName), []string{}, fmt.Sprintf("environment `variable` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s MY_VAR=my-value", cli.EnvFlagName)))
 	cmd.Flags().StringArrayVar(&opts.EnvFrom, cli.StripDash(cli.EnvFromFlagName), []string{}, fmt.Sprintf("environment `variable` from a config map or secret, example %q, %q (may be set multiple times)", fmt.Sprintf("%s MY_SECRET_VALUE=secretKeyRef:my-secret-name:key-in-secret", cli.EnvFromFlagName), fmt.Sprintf("%s MY_CONFIG_MAP_VALUE=configMapKeyRef:my-config-map-name:key-in-config-map", cli.EnvFromFlagName)))
+	opts.EnvFileOptions.AddFlags(cmd)
+	cmd.Flags().StringArrayVar(&opts.EnvFromConfigMaps, cli.StripDash(cli.EnvFromConfigMapFlagName), []string{}, "`name` of a config map to import every key from as an environment variable (may be set multiple times)")
+	cmd.Flags().StringArrayVar(&opts.EnvFromSecrets, cli.StripDash(cli.EnvFromSecretFlagName), []string{}, "`name` of a secret to import every key from as an environment variable (may be set multiple times)")
 	cmd.Flags().StringVar(&opts.LimitCPU, cli.StripDash(cli.LimitCPUFlagName), "", "the maximum amount of cpu allowed, in CPU `cores` (500m = .5 cores)")
 	cmd.Flags().StringVar(&opts.LimitMemory, cli.StripDash(cli.LimitMemoryFlagName), "", "the maximum amount of memory allowed, in `bytes` (500Mi = 500MiB = 500 * 1024 * 1024)")
 	cmd.Flags().Int32Var(&opts.MaxScale, cli.StripDash(cli.MaxScaleFlagName), int32(0), "maximum `number` of replicas (default unbounded)")
 	cmd.Flags().Int32Var(&opts.MinScale, cli.StripDash(cli.MinScaleFlagName), int32(0), "minimum `number` of replicas (default 0)")
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch deployer logs")
 	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the deployer to become ready when watching logs")
+	cli.ConfigDefault(cmd, cli.WaitTimeoutFlagName, cli.WaitTimeoutConfigKey)
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+	cmd.Flags().BoolVar(&opts.Interactive, cli.StripDash(cli.InteractiveFlagName), false, "prompt for missing values when stdin is a terminal")
 	cmd.Flags().Int32Var(&opts.TargetPort, cli.StripDash(cli.TargetPortFlagName), 0, "`port` that the workload listens on for traffic. The value is exposed to the workload as the PORT environment variable")
+	opts.WorkloadOptions.AddKnativeFlags(cmd)
+	opts.VolumeOptions.AddKnativeFlags(cmd)
 
 	return cmd
 }
@@ -23,10 +23,12 @@ import (
 	"testing"
 
 	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
 	"github.com/projectriff/cli/pkg/k8s"
 	"github.com/projectriff/cli/pkg/knative/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	kailtesting "github.com/projectriff/cli/pkg/testing/kail"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
 	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
 	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
@@ -292,6 +294,22 @@ func TestDeployerCreateOptions(t *testing.T) {
 				cli.ErrInvalidValue("-1", cli.TargetPortFlagName),
 			),
 		},
+		{
+			Name: "with probe port",
+			Options: &commands.DeployerCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				Image:           "example.com/repo:tag",
+				IngressPolicy:   string(knativev1alpha1.IngressPolicyClusterLocal),
+				WorkloadOptions: options.WorkloadOptions{
+					ReadinessProbe: options.ProbeOptions{Handler: "http:8081:/ready"},
+					LivenessProbe:  options.ProbeOptions{Handler: "tcp:8081"},
+				},
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidValue("http:8081:/ready", cli.ReadinessProbeFlagName),
+				cli.ErrInvalidValue("tcp:8081", cli.LivenessProbeFlagName),
+			),
+		},
 		{
 			Name: "with tail",
 			Options: &commands.DeployerCreateOptions{
@@ -464,6 +482,102 @@ Created deployer "my-deployer"
 			},
 			ExpectOutput: `
 Created deployer "my-deployer"
+`,
+		},
+		{
+			Name: "interactive",
+			Args: []string{deployerName, cli.InteractiveFlagName},
+			GivenObjects: []runtime.Object{
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "another-func",
+					},
+				},
+				&buildv1alpha1.Function{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      functionRef,
+					},
+				},
+			},
+			Terminal: true,
+			Stdin:    []byte("\n2\nExternal\n"),
+			ExpectCreates: []runtime.Object{
+				&knativev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      deployerName,
+					},
+					Spec: knativev1alpha1.DeployerSpec{
+						Build: &knativev1alpha1.Build{
+							FunctionRef: functionRef,
+						},
+						IngressPolicy: knativev1alpha1.IngressPolicyExternal,
+					},
+				},
+			},
+			ExpectOutput: `
+Workload:
+  1) function
+  2) application
+  3) container
+  4) image
+Choose [function]: Function:
+  1) another-func
+  2) my-func
+Choose: Ingress policy:
+  1) ClusterLocal
+  2) External
+Choose [ClusterLocal]: 
+Equivalent command:
+  create my-deployer --function-ref my-func --ingress-policy External
+
+Created deployer "my-deployer"
+`,
+		},
+		{
+			Name:     "interactive, image",
+			Args:     []string{deployerName, cli.IngressPolicyFlagName, string(knativev1alpha1.IngressPolicyClusterLocal), cli.InteractiveFlagName},
+			Terminal: true,
+			Stdin:    []byte(fmt.Sprintf("image\n%s\n", image)),
+			ExpectCreates: []runtime.Object{
+				&knativev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      deployerName,
+					},
+					Spec: knativev1alpha1.DeployerSpec{
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{Image: image},
+								},
+							},
+						},
+						IngressPolicy: knativev1alpha1.IngressPolicyClusterLocal,
+					},
+				},
+			},
+			ExpectOutput: `
+Workload:
+  1) function
+  2) application
+  3) container
+  4) image
+Choose [function]: Image: 
+Equivalent command:
+  create my-deployer --image registry.example.com/repo@sha256:deadbeefdeadbeefdeadbeefdeadbeef --ingress-policy ClusterLocal
+
+Created deployer "my-deployer"
+`,
+		},
+		{
+			Name:        "interactive, not a terminal",
+			Args:        []string{deployerName, cli.InteractiveFlagName},
+			ShouldError: true,
+			ExpectOutput: `
+Warning: stdin is not a terminal, ignoring --interactive
 `,
 		},
 		{
@@ -775,6 +889,206 @@ Created deployer "my-deployer"
 			Args:        []string{deployerName, cli.ImageFlagName, image, cli.MaxScaleFlagName, "0"},
 			ShouldError: true,
 		},
+		{
+			Name: "create with probes and command",
+			Args: []string{deployerName, cli.ImageFlagName, image,
+				cli.CommandFlagName, "/bin/server", cli.ArgFlagName, "--verbose", cli.ArgFlagName, "--color=false",
+				cli.ReadinessProbeFlagName, "http:/ready", cli.ReadinessProbeInitialDelayFlagName, "5s", cli.ReadinessProbePeriodFlagName, "15s",
+				cli.ReadinessProbeTimeoutFlagName, "2s", cli.ReadinessProbeSuccessThresholdFlagName, "2", cli.ReadinessProbeFailureThresholdFlagName, "5",
+				cli.LivenessProbeFlagName, "tcp",
+			},
+			ExpectCreates: []runtime.Object{
+				&knativev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      deployerName,
+					},
+					Spec: knativev1alpha1.DeployerSpec{
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Image:   image,
+										Command: []string{"/bin/server"},
+										Args:    []string{"--verbose", "--color=false"},
+										ReadinessProbe: &corev1.Probe{
+											Handler: corev1.Handler{
+												HTTPGet: &corev1.HTTPGetAction{
+													Path: "/ready",
+												},
+											},
+											InitialDelaySeconds: 5,
+											PeriodSeconds:       15,
+											TimeoutSeconds:      2,
+											SuccessThreshold:    2,
+											FailureThreshold:    5,
+										},
+										LivenessProbe: &corev1.Probe{
+											Handler: corev1.Handler{
+												TCPSocket: &corev1.TCPSocketAction{},
+											},
+										},
+									},
+								},
+							},
+						},
+						IngressPolicy: knativev1alpha1.IngressPolicyClusterLocal,
+					},
+				},
+			},
+			ExpectOutput: `
+Created deployer "my-deployer"
+`,
+		},
+		{
+			Name: "create with probe and target port",
+			Args: []string{deployerName, cli.ImageFlagName, image, cli.TargetPortFlagName, "8888", cli.LivenessProbeFlagName, "http:/healthz"},
+			ExpectCreates: []runtime.Object{
+				&knativev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      deployerName,
+					},
+					Spec: knativev1alpha1.DeployerSpec{
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Image: image,
+										Ports: []corev1.ContainerPort{
+											{Protocol: corev1.ProtocolTCP, ContainerPort: containerPort},
+										},
+										LivenessProbe: &corev1.Probe{
+											Handler: corev1.Handler{
+												HTTPGet: &corev1.HTTPGetAction{
+													Path: "/healthz",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						IngressPolicy: knativev1alpha1.IngressPolicyClusterLocal,
+					},
+				},
+			},
+			ExpectOutput: `
+Created deployer "my-deployer"
+`,
+		},
+		{
+			Name:        "create with invalid probe",
+			Args:        []string{deployerName, cli.ImageFlagName, image, cli.ReadinessProbeFlagName, "grpc"},
+			ShouldError: true,
+		},
+		{
+			Name:        "create with probe port",
+			Args:        []string{deployerName, cli.ImageFlagName, image, cli.ReadinessProbeFlagName, "tcp:8081"},
+			ShouldError: true,
+		},
+		{
+			Name: "create with volumes and service account",
+			Args: []string{deployerName, cli.ImageFlagName, image, cli.MountConfigMapFlagName, "my-config:/etc/config", cli.MountSecretFlagName, "my-tls:/etc/tls", cli.ServiceAccountFlagName, "my-service-account"},
+			ExpectCreates: []runtime.Object{
+				&knativev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      deployerName,
+					},
+					Spec: knativev1alpha1.DeployerSpec{
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								ServiceAccountName: "my-service-account",
+								Volumes: []corev1.Volume{
+									{
+										Name: "configmap-0",
+										VolumeSource: corev1.VolumeSource{
+											ConfigMap: &corev1.ConfigMapVolumeSource{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+											},
+										},
+									},
+									{
+										Name: "secret-0",
+										VolumeSource: corev1.VolumeSource{
+											Secret: &corev1.SecretVolumeSource{SecretName: "my-tls"},
+										},
+									},
+								},
+								Containers: []corev1.Container{
+									{
+										Image: image,
+										VolumeMounts: []corev1.VolumeMount{
+											{Name: "configmap-0", MountPath: "/etc/config", ReadOnly: true},
+											{Name: "secret-0", MountPath: "/etc/tls", ReadOnly: true},
+										},
+									},
+								},
+							},
+						},
+						IngressPolicy: knativev1alpha1.IngressPolicyClusterLocal,
+					},
+				},
+			},
+			ExpectOutput: `
+Created deployer "my-deployer"
+`,
+		},
+		{
+			Name:        "create with conflicting mounts",
+			Args:        []string{deployerName, cli.ImageFlagName, image, cli.MountSecretFlagName, "my-tls:/etc/tls", cli.MountConfigMapFlagName, "my-config:/etc/tls/config"},
+			ShouldError: true,
+		},
+		{
+			Name:        "create with empty dir",
+			Args:        []string{deployerName, cli.ImageFlagName, image, cli.EmptyDirFlagName, "/tmp/cache"},
+			ShouldError: true,
+		},
+		{
+			Name: "create with env file and env from config map and secret",
+			Args: []string{deployerName, cli.ImageFlagName, image, cli.EnvFileFlagName, "../../parsers/testdata/app.env", cli.EnvFlagName, "MY_VAR3=value3", cli.EnvFromConfigMapFlagName, "my-configmap", cli.EnvFromSecretFlagName, "my-secret"},
+			ExpectCreates: []runtime.Object{
+				&knativev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      deployerName,
+					},
+					Spec: knativev1alpha1.DeployerSpec{
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Image: image,
+										Env: []corev1.EnvVar{
+											{Name: "MY_VAR1", Value: "value1"},
+											{Name: "MY_VAR2", Value: "multi\nline"},
+											{Name: "MY_VAR3", Value: "value3"},
+										},
+										EnvFrom: []corev1.EnvFromSource{
+											{
+												ConfigMapRef: &corev1.ConfigMapEnvSource{
+													LocalObjectReference: corev1.LocalObjectReference{Name: "my-configmap"},
+												},
+											},
+											{
+												SecretRef: &corev1.SecretEnvSource{
+													LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						IngressPolicy: knativev1alpha1.IngressPolicyClusterLocal,
+					},
+				},
+			},
+			ExpectOutput: `
+Created deployer "my-deployer"
+`,
+		},
 		{
 			Name: "error existing deployer",
 			Args: []string{deployerName, cli.ImageFlagName, image},
@@ -0,0 +1,198 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/projectriff/cli/pkg/k8s"
+	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
+	servingv1 "github.com/projectriff/system/pkg/apis/thirdparty/knative/serving/v1"
+	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const configurationLabelKey = "serving.knative.dev/configuration"
+
+type DeployerRevisionsOptions struct {
+	options.ResourceOptions
+}
+
+var (
+	_ cli.Validatable = (*DeployerRevisionsOptions)(nil)
+	_ cli.Executable  = (*DeployerRevisionsOptions)(nil)
+)
+
+func (opts *DeployerRevisionsOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+
+	return errs
+}
+
+func (opts *DeployerRevisionsOptions) Exec(ctx context.Context, c *cli.Config) error {
+	deployer, err := c.KnativeRuntime().Deployers(opts.Namespace).Get(opts.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			return err
+		}
+		c.Errorf("Deployer %q not found\n", fmt.Sprintf("%s/%s", opts.Namespace, opts.Name))
+		return cli.SilenceError(err)
+	}
+
+	revisions, err := listDeployerRevisions(c.KnativeServing(), deployer)
+	if err != nil {
+		return err
+	}
+	if len(revisions.Items) == 0 {
+		c.Infof("No revisions found.\n")
+		return nil
+	}
+
+	// the route status reflects the traffic actually being served
+	var traffic []servingv1.TrafficTarget
+	if deployer.Status.RouteRef != nil {
+		route, err := c.KnativeServing().Routes(opts.Namespace).Get(deployer.Status.RouteRef.Name, metav1.GetOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+		if route != nil {
+			traffic = route.Status.Traffic
+		}
+	}
+
+	tablePrinter := printers.NewTablePrinter(printers.PrintOptions{}).With(func(h printers.PrintHandler) {
+		columns := opts.printColumns()
+		h.TableHandler(columns, func(revisions *servingv1.RevisionList, printOpts printers.PrintOptions) ([]metav1beta1.TableRow, error) {
+			rows := make([]metav1beta1.TableRow, 0, len(revisions.Items))
+			for i := range revisions.Items {
+				r, err := opts.print(&revisions.Items[i], traffic)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, r...)
+			}
+			return rows, nil
+		})
+	})
+
+	return tablePrinter.PrintObj(revisions, c.Stdout)
+}
+
+func NewDeployerRevisionsCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &DeployerRevisionsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "revisions",
+		Short: "table listing of knative deployer revisions",
+		Long: strings.TrimSpace(`
+List the Knative revisions created for a deployer.
+
+Each update to a deployer's workload creates a new immutable revision. The
+traffic column shows the share of requests currently routed to each revision
+and the tags column lists the named URLs assigned to the revision.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s knative deployer revisions my-deployer", c.Name),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.NameArg(&opts.Name),
+	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListKnativeDeployers)
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+
+	return cmd
+}
+
+func (opts *DeployerRevisionsOptions) print(revision *servingv1.Revision, traffic []servingv1.TrafficTarget) ([]metav1beta1.TableRow, error) {
+	now := time.Now()
+	row := metav1beta1.TableRow{
+		Object: runtime.RawExtension{Object: revision},
+	}
+	var image string
+	if len(revision.Spec.Containers) != 0 {
+		image = revision.Spec.Containers[0].Image
+	}
+	percent := int64(0)
+	tags := []string{}
+	for _, target := range traffic {
+		if target.RevisionName != revision.Name {
+			continue
+		}
+		if target.Percent != nil {
+			percent += *target.Percent
+		}
+		if target.Tag != "" {
+			tags = append(tags, target.Tag)
+		}
+	}
+	row.Cells = append(row.Cells,
+		revision.Name,
+		cli.FormatEmptyString(image),
+		fmt.Sprintf("%d%%", percent),
+		cli.FormatEmptyString(strings.Join(tags, ",")),
+		cli.FormatConditionStatus(revision.Status.GetCondition(servingv1.RevisionConditionReady)),
+		cli.FormatTimestampSince(revision.CreationTimestamp, now),
+	)
+	return []metav1beta1.TableRow{row}, nil
+}
+
+func (opts *DeployerRevisionsOptions) printColumns() []metav1beta1.TableColumnDefinition {
+	return []metav1beta1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Image", Type: "string"},
+		{Name: "Traffic", Type: "string"},
+		{Name: "Tags", Type: "string"},
+		{Name: "Status", Type: "string"},
+		{Name: "Age", Type: "string"},
+	}
+}
+
+// listDeployerRevisions returns the revisions of the deployer's configuration, oldest first
+func listDeployerRevisions(client k8s.ServingV1Interface, deployer *knativev1alpha1.Deployer) (*servingv1.RevisionList, error) {
+	if deployer.Status.ConfigurationRef == nil {
+		return &servingv1.RevisionList{}, nil
+	}
+	revisions, err := client.Revisions(deployer.Namespace).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", configurationLabelKey, deployer.Status.ConfigurationRef.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	revisions = revisions.DeepCopy()
+	cli.SortByNamespaceAndName(revisions.Items)
+	sort.SliceStable(revisions.Items, func(i, j int) bool {
+		return revisions.Items[i].CreationTimestamp.Before(&revisions.Items[j].CreationTimestamp)
+	})
+	return revisions, nil
+}
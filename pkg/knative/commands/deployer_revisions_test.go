@@ -0,0 +1,221 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/projectriff/cli/pkg/knative/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
+	servingv1 "github.com/projectriff/system/pkg/apis/thirdparty/knative/serving/v1"
+	"github.com/projectriff/system/pkg/refs"
+	"github.com/vmware-labs/reconciler-runtime/apis"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestDeployerRevisionsOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "invalid resource",
+			Options: &commands.DeployerRevisionsOptions{
+				ResourceOptions: rifftesting.InvalidResourceOptions,
+			},
+			ExpectFieldErrors: rifftesting.InvalidResourceOptionsFieldError,
+		},
+		{
+			Name: "valid resource",
+			Options: &commands.DeployerRevisionsOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+			},
+			ShouldValidate: true,
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestDeployerRevisionsCommand(t *testing.T) {
+	defaultNamespace := "default"
+	deployerName := "my-deployer"
+	configurationName := "my-deployer-abc12"
+	routeName := "my-deployer-xyz34"
+
+	deployer := &knativev1alpha1.Deployer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployerName,
+			Namespace: defaultNamespace,
+		},
+		Status: knativev1alpha1.DeployerStatus{
+			ConfigurationRef: &refs.TypedLocalObjectReference{
+				Kind: "Configuration",
+				Name: configurationName,
+			},
+			RouteRef: &refs.TypedLocalObjectReference{
+				Kind: "Route",
+				Name: routeName,
+			},
+		},
+	}
+	revision := func(name, image string) *servingv1.Revision {
+		return &servingv1.Revision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: defaultNamespace,
+				Labels: map[string]string{
+					"serving.knative.dev/configuration": configurationName,
+				},
+			},
+			Spec: servingv1.RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Image: image},
+					},
+				},
+			},
+			Status: servingv1.RevisionStatus{
+				Status: apis.Status{
+					Conditions: apis.Conditions{
+						{Type: apis.ConditionReady, Status: corev1.ConditionTrue},
+					},
+				},
+			},
+		}
+	}
+	created := func(revision *servingv1.Revision, age time.Duration) *servingv1.Revision {
+		revision.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+		return revision
+	}
+	ninety := int64(90)
+	ten := int64(10)
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name: "list revisions with traffic",
+			Args: []string{deployerName},
+			GivenObjects: []runtime.Object{
+				deployer,
+				revision("my-deployer-00002", "example.com/repo:v2"),
+				revision("my-deployer-00001", "example.com/repo:v1"),
+				&servingv1.Revision{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "other-00001",
+						Namespace: defaultNamespace,
+						Labels: map[string]string{
+							"serving.knative.dev/configuration": "other",
+						},
+					},
+				},
+				&servingv1.Route{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      routeName,
+						Namespace: defaultNamespace,
+					},
+					Status: servingv1.RouteStatus{
+						RouteStatusFields: servingv1.RouteStatusFields{
+							Traffic: []servingv1.TrafficTarget{
+								{RevisionName: "my-deployer-00001", Percent: &ninety},
+								{RevisionName: "my-deployer-00002", Percent: &ten, Tag: "candidate"},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+NAME                IMAGE                 TRAFFIC   TAGS        STATUS   AGE
+my-deployer-00001   example.com/repo:v1   90%       <empty>     Ready    <unknown>
+my-deployer-00002   example.com/repo:v2   10%       candidate   Ready    <unknown>
+`,
+		},
+		{
+			Name: "list revisions oldest first",
+			Args: []string{deployerName},
+			GivenObjects: []runtime.Object{
+				deployer,
+				created(revision("my-deployer-00001", "example.com/repo:v1"), 48*time.Hour),
+				created(revision("my-deployer-00002", "example.com/repo:v2"), 72*time.Hour),
+			},
+			ExpectOutput: `
+NAME                IMAGE                 TRAFFIC   TAGS      STATUS   AGE
+my-deployer-00002   example.com/repo:v2   0%        <empty>   Ready    3d
+my-deployer-00001   example.com/repo:v1   0%        <empty>   Ready    2d
+`,
+		},
+		{
+			Name: "list revisions without route",
+			Args: []string{deployerName},
+			GivenObjects: []runtime.Object{
+				deployer,
+				revision("my-deployer-00001", "example.com/repo:v1"),
+			},
+			ExpectOutput: `
+NAME                IMAGE                 TRAFFIC   TAGS      STATUS   AGE
+my-deployer-00001   example.com/repo:v1   0%        <empty>   Ready    <unknown>
+`,
+		},
+		{
+			Name: "no revisions",
+			Args: []string{deployerName},
+			GivenObjects: []runtime.Object{
+				deployer,
+			},
+			ExpectOutput: `
+No revisions found.
+`,
+		},
+		{
+			Name: "not found",
+			Args: []string{deployerName},
+			ExpectOutput: `
+Deployer "default/my-deployer" not found
+`,
+			ShouldError: true,
+		},
+		{
+			Name: "get error",
+			Args: []string{deployerName},
+			GivenObjects: []runtime.Object{
+				deployer,
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "deployers"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "list revisions error",
+			Args: []string{deployerName},
+			GivenObjects: []runtime.Object{
+				deployer,
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("list", "revisions"),
+			},
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewDeployerRevisionsCommand)
+}
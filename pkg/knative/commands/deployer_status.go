@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/cli/options"
 	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
@@ -59,9 +60,32 @@ func (opts *DeployerStatusOptions) Exec(ctx context.Context, c *cli.Config) erro
 	ready := deployer.Status.GetCondition(knativev1alpha1.DeployerConditionReady)
 	cli.PrintResourceStatus(c, deployer.Name, ready)
 
+	config := deployerAutoscalingConfig{
+		ContainerConcurrency: deployer.Spec.ContainerConcurrency,
+		MinScale:             deployer.Spec.Scale.Min,
+		MaxScale:             deployer.Spec.Scale.Max,
+	}
+	if config == (deployerAutoscalingConfig{}) {
+		return nil
+	}
+	s, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	c.Printf("# autoscaling\n")
+	c.Printf("---\n")
+	c.Printf("%s", string(s))
+
 	return nil
 }
 
+// deployerAutoscalingConfig is the autoscaling configuration shown by status
+type deployerAutoscalingConfig struct {
+	ContainerConcurrency *int64 `json:"containerConcurrency,omitempty"`
+	MinScale             *int32 `json:"minScale,omitempty"`
+	MaxScale             *int32 `json:"maxScale,omitempty"`
+}
+
 func NewDeployerStatusCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	opts := &DeployerStatusOptions{}
 
@@ -75,6 +99,8 @@ The Ready condition is shown which should include a reason code and a
 descriptive message when the status is not "True". The status for the condition
 may be: "True", "False" or "Unknown". An "Unknown" status is common while the
 deployer roll out is processed.
+
+The autoscaling configuration of the deployer is also shown when set.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s knative deployer status my-deployer", c.Name),
@@ -86,6 +112,7 @@ deployer roll out is processed.
 	cli.Args(cmd,
 		cli.NameArg(&opts.Name),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListKnativeDeployers)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 
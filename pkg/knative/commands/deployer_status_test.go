@@ -53,6 +53,8 @@ func TestDeployerStatusOptions(t *testing.T) {
 func TestDeployerStatusCommand(t *testing.T) {
 	defaultNamespace := "default"
 	deployerName := "my-deployer"
+	minScale := int32(1)
+	maxScale := int32(5)
 
 	table := rifftesting.CommandTable{
 		{
@@ -96,6 +98,45 @@ message: a hopefully informative message about what went wrong
 reason: OopsieDoodle
 status: "False"
 type: Ready
+`,
+		},
+		{
+			Name: "show status with autoscaling",
+			Args: []string{deployerName},
+			GivenObjects: []runtime.Object{
+				&knativev1alpha1.Deployer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      deployerName,
+						Namespace: defaultNamespace,
+					},
+					Spec: knativev1alpha1.DeployerSpec{
+						Scale: knativev1alpha1.Scale{
+							Min: &minScale,
+							Max: &maxScale,
+						},
+					},
+					Status: knativev1alpha1.DeployerStatus{
+						Status: apis.Status{
+							Conditions: apis.Conditions{
+								{
+									Type:   apis.ConditionReady,
+									Status: corev1.ConditionTrue,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+# my-deployer: Ready
+---
+lastTransitionTime: null
+status: "True"
+type: Ready
+# autoscaling
+---
+maxScale: 5
+minScale: 1
 `,
 		},
 		{
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var dotEnvKeyPattern = regexp.MustCompile(`^[-._a-zA-Z][-._a-zA-Z0-9]*$`)
+
+// DotEnvError is a syntax error within a dotenv file
+type DotEnvError struct {
+	Line    int
+	Message string
+}
+
+func (e *DotEnvError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// EnvFile reads environment variables from a dotenv formatted file
+func EnvFile(path string) ([]corev1.EnvVar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return DotEnv(f)
+}
+
+// DotEnv parses environment variables in the dotenv format. Each variable is defined as
+// KEY=VALUE on its own line, optionally prefixed by "export". Blank lines and lines starting
+// with # are ignored. Unquoted values are trimmed and end at a # preceded by whitespace.
+// Single quoted values are literal, double quoted values support \n, \r, \t, \" and \\
+// escapes. Quoted values may span multiple lines.
+func DotEnv(r io.Reader) ([]corev1.EnvVar, error) {
+	scanner := bufio.NewScanner(r)
+	env := []corev1.EnvVar{}
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		start := line
+		text = strings.TrimPrefix(text, "export ")
+		parts := strings.SplitN(text, "=", 2)
+		if len(parts) != 2 {
+			return nil, &DotEnvError{Line: start, Message: fmt.Sprintf("expected KEY=VALUE, found %q", text)}
+		}
+		key := strings.TrimSpace(parts[0])
+		if !dotEnvKeyPattern.MatchString(key) {
+			return nil, &DotEnvError{Line: start, Message: fmt.Sprintf("invalid variable name %q", key)}
+		}
+		value := strings.TrimLeft(parts[1], " \t")
+
+		if len(value) == 0 || (value[0] != '"' && value[0] != '\'') {
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = value[:i]
+			}
+			if i := strings.Index(value, "\t#"); i >= 0 {
+				value = value[:i]
+			}
+			env = append(env, corev1.EnvVar{Name: key, Value: strings.TrimSpace(value)})
+			continue
+		}
+
+		// quoted values continue until the closing quote, possibly on a later line
+		quote := value[0]
+		raw := value[1:]
+		for {
+			if end := closingQuote(raw, quote); end >= 0 {
+				rest := strings.TrimSpace(raw[end+1:])
+				if rest != "" && !strings.HasPrefix(rest, "#") {
+					return nil, &DotEnvError{Line: line, Message: fmt.Sprintf("unexpected characters after closing quote for %q", key)}
+				}
+				raw = raw[:end]
+				break
+			}
+			if !scanner.Scan() {
+				return nil, &DotEnvError{Line: start, Message: fmt.Sprintf("unterminated quoted value for %q", key)}
+			}
+			line++
+			raw = raw + "\n" + scanner.Text()
+		}
+		if quote == '"' {
+			raw = unescapeDoubleQuoted(raw)
+		}
+		env = append(env, corev1.EnvVar{Name: key, Value: raw})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// closingQuote returns the index of the unescaped closing quote, or -1
+func closingQuote(str string, quote byte) int {
+	for i := 0; i < len(str); i++ {
+		if quote == '"' && str[i] == '\\' {
+			i++
+			continue
+		}
+		if str[i] == quote {
+			return i
+		}
+	}
+	return -1
+}
+
+func unescapeDoubleQuoted(str string) string {
+	var b strings.Builder
+	for i := 0; i < len(str); i++ {
+		if str[i] != '\\' || i == len(str)-1 {
+			b.WriteByte(str[i])
+			continue
+		}
+		i++
+		switch str[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '"', '\\':
+			b.WriteByte(str[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(str[i])
+		}
+	}
+	return b.String()
+}
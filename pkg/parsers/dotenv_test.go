@@ -0,0 +1,153 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/parsers"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDotEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    []corev1.EnvVar
+		expectedErr string
+	}{{
+		name:     "empty",
+		value:    "",
+		expected: []corev1.EnvVar{},
+	}, {
+		name: "values",
+		value: `
+# a comment
+MY_VAR=my-value
+export MY_EXPORTED_VAR = my-exported-value  
+MY_EMPTY_VAR=
+MY_COMMENTED_VAR=my-value # trailing comment
+MY_HASH_VAR=my#value
+`,
+		expected: []corev1.EnvVar{
+			{Name: "MY_VAR", Value: "my-value"},
+			{Name: "MY_EXPORTED_VAR", Value: "my-exported-value"},
+			{Name: "MY_EMPTY_VAR", Value: ""},
+			{Name: "MY_COMMENTED_VAR", Value: "my-value"},
+			{Name: "MY_HASH_VAR", Value: "my#value"},
+		},
+	}, {
+		name: "quoted values",
+		value: `MY_SINGLE='my value # not a comment \n'
+MY_DOUBLE="my \"value\"\tand\nmore" # a comment
+MY_MULTILINE="first line
+second line"
+MY_CERT='-----BEGIN CERTIFICATE-----
+MIIB
+-----END CERTIFICATE-----'
+`,
+		expected: []corev1.EnvVar{
+			{Name: "MY_SINGLE", Value: `my value # not a comment \n`},
+			{Name: "MY_DOUBLE", Value: "my \"value\"\tand\nmore"},
+			{Name: "MY_MULTILINE", Value: "first line\nsecond line"},
+			{Name: "MY_CERT", Value: "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"},
+		},
+	}, {
+		name: "missing equals",
+		value: `MY_VAR=my-value
+
+MY_OTHER_VAR
+`,
+		expectedErr: `line 3: expected KEY=VALUE, found "MY_OTHER_VAR"`,
+	}, {
+		name:        "invalid name",
+		value:       `MY VAR=my-value`,
+		expectedErr: `line 1: invalid variable name "MY VAR"`,
+	}, {
+		name: "unterminated quote",
+		value: `MY_VAR=my-value
+MY_OTHER_VAR="my-value
+`,
+		expectedErr: `line 2: unterminated quoted value for "MY_OTHER_VAR"`,
+	}, {
+		name:        "characters after quote",
+		value:       `MY_VAR="my-value" extra`,
+		expectedErr: `line 1: unexpected characters after closing quote for "MY_VAR"`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := parsers.DotEnv(strings.NewReader(test.value))
+			if test.expectedErr != "" {
+				if err == nil || err.Error() != test.expectedErr {
+					t.Errorf("%s() expected error %q, actual %v", test.name, test.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s() unexpected error: %v", test.name, err)
+			}
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestEnvFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		expected    []corev1.EnvVar
+		expectedErr string
+	}{{
+		name: "valid",
+		path: "./testdata/app.env",
+		expected: []corev1.EnvVar{
+			{Name: "MY_VAR1", Value: "value1"},
+			{Name: "MY_VAR2", Value: "multi\nline"},
+		},
+	}, {
+		name:        "invalid",
+		path:        "./testdata/invalid.env",
+		expectedErr: `line 2: expected KEY=VALUE, found "MY_VAR2"`,
+	}, {
+		name:        "missing",
+		path:        "./testdata/missing.env",
+		expectedErr: "open ./testdata/missing.env: no such file or directory",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := parsers.EnvFile(test.path)
+			if test.expectedErr != "" {
+				if err == nil || err.Error() != test.expectedErr {
+					t.Errorf("%s() expected error %q, actual %v", test.name, test.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s() unexpected error: %v", test.name, err)
+			}
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ProbeHandler parses a probe action in the form "http:[<port>:]<path>", "tcp[:<port>]" or
+// "exec:<command>". The default port is used when a port is not specified. The exec command is
+// split on whitespace, so its arguments may not contain spaces.
+func ProbeHandler(str string, defaultPort int32) corev1.Handler {
+	parts := strings.SplitN(str, ":", 2)
+
+	switch parts[0] {
+	case "http":
+		port, path := defaultPort, parts[1]
+		if !strings.HasPrefix(path, "/") {
+			portPath := strings.SplitN(path, ":", 2)
+			port, path = parsePort(portPath[0]), portPath[1]
+		}
+		return corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: path,
+				Port: intstr.FromInt(int(port)),
+			},
+		}
+	case "tcp":
+		port := defaultPort
+		if len(parts) == 2 {
+			port = parsePort(parts[1])
+		}
+		return corev1.Handler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(int(port)),
+			},
+		}
+	case "exec":
+		return corev1.Handler{
+			Exec: &corev1.ExecAction{
+				Command: strings.Fields(parts[1]),
+			},
+		}
+	}
+
+	return corev1.Handler{}
+}
+
+func parsePort(str string) int32 {
+	port, _ := strconv.ParseInt(str, 10, 32)
+	return int32(port)
+}
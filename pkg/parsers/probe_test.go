@@ -0,0 +1,86 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/parsers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestProbeHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected corev1.Handler
+		value    string
+	}{{
+		name:  "http path",
+		value: "http:/healthz",
+		expected: corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/healthz",
+				Port: intstr.FromInt(8080),
+			},
+		},
+	}, {
+		name:  "http port and path",
+		value: "http:9090:/ready",
+		expected: corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/ready",
+				Port: intstr.FromInt(9090),
+			},
+		},
+	}, {
+		name:  "tcp",
+		value: "tcp",
+		expected: corev1.Handler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(8080),
+			},
+		},
+	}, {
+		name:  "tcp port",
+		value: "tcp:9090",
+		expected: corev1.Handler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(9090),
+			},
+		},
+	}, {
+		name:  "exec",
+		value: "exec:cat /tmp/healthy",
+		expected: corev1.Handler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"cat", "/tmp/healthy"},
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := parsers.ProbeHandler(test.value, 8080)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
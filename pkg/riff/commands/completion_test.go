@@ -53,6 +53,20 @@ func TestCompletionOptions(t *testing.T) {
 			},
 			ShouldValidate: true,
 		},
+		{
+			Name: "valid shell fish",
+			Options: &commands.CompletionOptions{
+				Shell: "fish",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "valid shell powershell",
+			Options: &commands.CompletionOptions{
+				Shell: "powershell",
+			},
+			ShouldValidate: true,
+		},
 	}
 
 	table.Run(t)
@@ -99,6 +113,32 @@ func TestCompletionCommand(t *testing.T) {
 				}
 			},
 		},
+		{
+			Name: "fish",
+			Args: []string{cli.ShellFlagName, "fish"},
+			Verify: func(t *testing.T, output string, err error) {
+				for _, str := range []string{
+					"# fish completion for completion",
+				} {
+					if !strings.Contains(output, str) {
+						t.Errorf("expected completion output to contain %q\n", str)
+					}
+				}
+			},
+		},
+		{
+			Name: "powershell",
+			Args: []string{cli.ShellFlagName, "powershell"},
+			Verify: func(t *testing.T, output string, err error) {
+				for _, str := range []string{
+					"# powershell completion for completion",
+				} {
+					if !strings.Contains(output, str) {
+						t.Errorf("expected completion output to contain %q\n", str)
+					}
+				}
+			},
+		},
 	}
 
 	table.Run(t, commands.NewCompletionCommand)
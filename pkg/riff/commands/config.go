@@ -0,0 +1,118 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewConfigCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "defaults saved to the " + c.Name + " config file",
+		Long: strings.TrimSpace(`
+The ` + c.Name + ` config file holds per-user defaults for the namespace, image prefix,
+wait timeout, ingress policy, git revision, output format and preferred
+runtime. Flags set explicitly always win over the defaults.
+
+The user config file is $HOME/.` + c.Name + `.yaml, or the file set with ` + cli.ConfigFlagName + `. A
+project config file named .` + c.Name + `.yaml in the working directory, or any of its
+parents below the home directory, overrides the user config file. Settings for
+an environment are grouped into a named profile, the profile in use is selected
+with the "profile" key. Profile settings override the top level settings of the
+user config file, but not the settings of the project config file.
+
+Environment variables prefixed with ` + strings.ToUpper(c.Name) + `_ override any config file, for
+example ` + strings.ToUpper(c.Name) + `_NAMESPACE.
+`),
+	}
+
+	cmd.AddCommand(NewConfigGetCommand(ctx, c))
+	cmd.AddCommand(NewConfigSetCommand(ctx, c))
+	cmd.AddCommand(NewConfigUnsetCommand(ctx, c))
+	cmd.AddCommand(NewConfigViewCommand(ctx, c))
+
+	return cmd
+}
+
+// validateConfigKey checks the key, and the value when set, are valid
+func validateConfigKey(key, value string, setValue bool) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if key == "" {
+		return errs.Also(cli.ErrMissingField(cli.KeyArgumentName))
+	}
+	configKey, ok := cli.LookupConfigKey(key)
+	if !ok {
+		return errs.Also(cli.ErrInvalidValue(key, cli.KeyArgumentName))
+	}
+	if setValue {
+		if err := configKey.Validate(value); err != nil {
+			errs = errs.Also(cli.ErrInvalidValue(value, cli.ValueArgumentName))
+		}
+	}
+
+	return errs
+}
+
+// configKeyDescriptions describes the config keys for command help
+func configKeyDescriptions() string {
+	descriptions := []string{}
+	for _, key := range cli.ConfigKeys {
+		descriptions = append(descriptions, fmt.Sprintf("  %-15s %s", key.Name, key.Description))
+	}
+	return strings.Join(descriptions, "\n")
+}
+
+// configFilePath returns the path of the config file to modify, the project config file is
+// created in the working directory if none was found.
+func configFilePath(c *cli.Config, project bool) (string, error) {
+	if !project {
+		return c.UserConfigFile()
+	}
+	if c.ProjectConfigFile != "" {
+		return c.ProjectConfigFile, nil
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf(".%s.yaml", c.Name)), nil
+}
+
+// profileConfigKey qualifies the key by the profile, if any
+func profileConfigKey(key, profile string) string {
+	if profile == "" {
+		return key
+	}
+	return fmt.Sprintf("%s.%s.%s", cli.ProfilesConfigKey, profile, key)
+}
+
+// completeConfigKeys completes the config key as the first argument
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return cli.ConfigKeyNames(), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type ConfigGetOptions struct {
+	Key string
+}
+
+var (
+	_ cli.Validatable = (*ConfigGetOptions)(nil)
+	_ cli.Executable  = (*ConfigGetOptions)(nil)
+)
+
+func (opts *ConfigGetOptions) Validate(ctx context.Context) cli.FieldErrors {
+	return validateConfigKey(opts.Key, "", false)
+}
+
+func (opts *ConfigGetOptions) Exec(ctx context.Context, c *cli.Config) error {
+	if !viper.IsSet(opts.Key) {
+		c.Einfof("%s is not set\n", opts.Key)
+		return nil
+	}
+	c.Printf("%s\n", viper.GetString(opts.Key))
+	return nil
+}
+
+func NewConfigGetCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &ConfigGetOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "print the value of a setting",
+		Long: strings.TrimSpace(`
+Print the value of a setting in effect, after applying the project config file,
+the profile and environment variables. Nothing is printed to stdout when the
+setting is not set.
+
+Settings are:
+` + configKeyDescriptions() + `
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s config get namespace", c.Name),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.KeyArg(&opts.Key),
+	)
+	cmd.ValidArgsFunction = completeConfigKeys
+
+	return cmd
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"github.com/spf13/viper"
+)
+
+func TestConfigGetOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name:              "default",
+			Options:           &commands.ConfigGetOptions{},
+			ExpectFieldErrors: cli.ErrMissingField(cli.KeyArgumentName),
+		},
+		{
+			Name: "valid",
+			Options: &commands.ConfigGetOptions{
+				Key: cli.NamespaceConfigKey,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "unknown key",
+			Options: &commands.ConfigGetOptions{
+				Key: "bogus",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("bogus", cli.KeyArgumentName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestConfigGetCommand(t *testing.T) {
+	given := func(key, value string) func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+		return func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+			viper.Set(key, value)
+			return ctx, nil
+		}
+	}
+	reset := func(t *testing.T, ctx context.Context, c *cli.Config) error {
+		viper.Reset()
+		return nil
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name:    "get setting",
+			Args:    []string{cli.WaitTimeoutConfigKey},
+			Prepare: given(cli.WaitTimeoutConfigKey, "5m"),
+			CleanUp: reset,
+			ExpectOutput: `
+5m
+`,
+		},
+		{
+			Name:    "not set",
+			Args:    []string{cli.WaitTimeoutConfigKey},
+			CleanUp: reset,
+			ExpectOutput: `
+wait-timeout is not set
+`,
+		},
+	}
+
+	table.Run(t, commands.NewConfigGetCommand)
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/validation"
+	"github.com/spf13/cobra"
+)
+
+type ConfigSetOptions struct {
+	Key     string
+	Value   string
+	Profile string
+	Project bool
+}
+
+var (
+	_ cli.Validatable = (*ConfigSetOptions)(nil)
+	_ cli.Executable  = (*ConfigSetOptions)(nil)
+)
+
+func (opts *ConfigSetOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(validateConfigKey(opts.Key, opts.Value, true))
+
+	if opts.Profile != "" {
+		errs = errs.Also(validation.K8sName(opts.Profile, cli.ProfileFlagName))
+		if opts.Key == cli.ProfileConfigKey {
+			errs = errs.Also(cli.ErrDisallowedFields(cli.ProfileFlagName, "a profile may not select a profile"))
+		}
+	}
+
+	return errs
+}
+
+func (opts *ConfigSetOptions) Exec(ctx context.Context, c *cli.Config) error {
+	path, err := configFilePath(c, opts.Project)
+	if err != nil {
+		return err
+	}
+	var value interface{} = opts.Value
+	if b, err := strconv.ParseBool(opts.Value); err == nil && opts.Key == cli.NoColorConfigKey {
+		value = b
+	}
+	key := profileConfigKey(opts.Key, opts.Profile)
+	err = cli.WriteConfigFile(path, func(settings map[string]interface{}) {
+		cli.SetConfigValue(settings, key, value)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Successf("Set %s to %q in %s\n", key, opts.Value, path)
+	return nil
+}
+
+func NewConfigSetCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &ConfigSetOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "save a setting to a config file",
+		Long: strings.TrimSpace(`
+Save a setting to the user config file, or to the project config file with
+` + cli.ProjectFlagName + `. The project config file is created in the working directory if it
+does not exist. With ` + cli.ProfileFlagName + `, the setting is saved to the named profile,
+which applies once selected with "` + c.Name + ` config set profile <name>".
+
+Settings are:
+` + configKeyDescriptions() + `
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s config set image-prefix registry.example.com/me", c.Name),
+			fmt.Sprintf("%s config set namespace staging %s staging", c.Name, cli.ProfileFlagName),
+			fmt.Sprintf("%s config set profile staging %s", c.Name, cli.ProjectFlagName),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.KeyArg(&opts.Key),
+		cli.ValueArg(&opts.Value),
+	)
+	cmd.ValidArgsFunction = completeConfigKeys
+
+	cmd.Flags().StringVar(&opts.Profile, cli.StripDash(cli.ProfileFlagName), "", "`name` of the profile to save the setting to")
+	cmd.Flags().BoolVar(&opts.Project, cli.StripDash(cli.ProjectFlagName), false, "save to the project config file rather than the user config file")
+
+	return cmd
+}
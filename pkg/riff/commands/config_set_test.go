@@ -0,0 +1,158 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+)
+
+func TestConfigSetOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name:              "default",
+			Options:           &commands.ConfigSetOptions{},
+			ExpectFieldErrors: cli.ErrMissingField(cli.KeyArgumentName),
+		},
+		{
+			Name: "valid",
+			Options: &commands.ConfigSetOptions{
+				Key:   cli.IngressPolicyConfigKey,
+				Value: "External",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "valid profile",
+			Options: &commands.ConfigSetOptions{
+				Key:     cli.NamespaceConfigKey,
+				Value:   "staging",
+				Profile: "staging",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "unknown key",
+			Options: &commands.ConfigSetOptions{
+				Key:   "bogus",
+				Value: "value",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("bogus", cli.KeyArgumentName),
+		},
+		{
+			Name: "invalid value",
+			Options: &commands.ConfigSetOptions{
+				Key:   cli.WaitTimeoutConfigKey,
+				Value: "soon",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("soon", cli.ValueArgumentName),
+		},
+		{
+			Name: "invalid profile",
+			Options: &commands.ConfigSetOptions{
+				Key:     cli.NamespaceConfigKey,
+				Value:   "staging",
+				Profile: "Staging",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("Staging", cli.ProfileFlagName),
+		},
+		{
+			Name: "profile in profile",
+			Options: &commands.ConfigSetOptions{
+				Key:     cli.ProfileConfigKey,
+				Value:   "staging",
+				Profile: "staging",
+			},
+			ExpectFieldErrors: cli.ErrDisallowedFields(cli.ProfileFlagName, "a profile may not select a profile"),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestConfigSetCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "riff-config-set")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, ".riff.yaml")
+	projectConfigFile := filepath.Join(dir, "project.yaml")
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name:    "set",
+			Args:    []string{cli.ImagePrefixConfigKey, "registry.example.com/me"},
+			Prepare: prepareConfigFile(configFile, ""),
+			CleanUp: verifyConfigFile(configFile, "image-prefix: registry.example.com/me\n"),
+			ExpectOutput: `
+Set image-prefix to "registry.example.com/me" in ` + configFile + `
+`,
+		},
+		{
+			Name:    "preserves existing settings",
+			Args:    []string{cli.NoColorConfigKey, "true"},
+			Prepare: prepareConfigFile(configFile, "namespace: my-namespace\n"),
+			CleanUp: verifyConfigFile(configFile, "namespace: my-namespace\nno-color: true\n"),
+			ExpectOutput: `
+Set no-color to "true" in ` + configFile + `
+`,
+		},
+		{
+			Name:    "profile",
+			Args:    []string{cli.NamespaceConfigKey, "staging", cli.ProfileFlagName, "staging"},
+			Prepare: prepareConfigFile(configFile, "namespace: my-namespace\n"),
+			CleanUp: verifyConfigFile(configFile, "namespace: my-namespace\nprofiles:\n  staging:\n    namespace: staging\n"),
+			ExpectOutput: `
+Set profiles.staging.namespace to "staging" in ` + configFile + `
+`,
+		},
+		{
+			Name: "project",
+			Args: []string{cli.ProfileConfigKey, "staging", cli.ProjectFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				c.ProjectConfigFile = projectConfigFile
+				return prepareConfigFile(configFile, "")(t, ctx, c)
+			},
+			CleanUp: verifyConfigFile(projectConfigFile, "profile: staging\n"),
+			ExpectOutput: `
+Set profile to "staging" in ` + projectConfigFile + `
+`,
+		},
+		{
+			Name:        "invalid value",
+			Args:        []string{cli.OutputConfigKey, "yaml"},
+			Prepare:     prepareConfigFile(configFile, ""),
+			CleanUp:     verifyConfigFile(configFile, ""),
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewConfigSetCommand)
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"github.com/spf13/viper"
+)
+
+func TestConfigCommand(t *testing.T) {
+	table := rifftesting.CommandTable{
+		{
+			Name: "empty",
+			Args: []string{},
+		},
+	}
+
+	table.Run(t, commands.NewConfigCommand)
+}
+
+// prepareConfigFile uses the path as the user config file, with the content if not empty
+func prepareConfigFile(path, content string) func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+	return func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+		c.ViperConfigFile = path
+		if content == "" {
+			return ctx, nil
+		}
+		return ctx, ioutil.WriteFile(path, []byte(content), 0644)
+	}
+}
+
+// verifyConfigFile checks the content of the config file, and removes it
+func verifyConfigFile(path, expected string) func(t *testing.T, ctx context.Context, c *cli.Config) error {
+	return func(t *testing.T, ctx context.Context, c *cli.Config) error {
+		defer viper.Reset()
+		defer os.Remove(path)
+		actual, err := ioutil.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if diff := cmp.Diff(expected, string(actual)); diff != "" {
+			t.Errorf("Unexpected config file (-expected, +actual): %s", diff)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/validation"
+	"github.com/spf13/cobra"
+)
+
+type ConfigUnsetOptions struct {
+	Key     string
+	Profile string
+	Project bool
+}
+
+var (
+	_ cli.Validatable = (*ConfigUnsetOptions)(nil)
+	_ cli.Executable  = (*ConfigUnsetOptions)(nil)
+)
+
+func (opts *ConfigUnsetOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(validateConfigKey(opts.Key, "", false))
+
+	if opts.Profile != "" {
+		errs = errs.Also(validation.K8sName(opts.Profile, cli.ProfileFlagName))
+	}
+
+	return errs
+}
+
+func (opts *ConfigUnsetOptions) Exec(ctx context.Context, c *cli.Config) error {
+	path, err := configFilePath(c, opts.Project)
+	if err != nil {
+		return err
+	}
+	key := profileConfigKey(opts.Key, opts.Profile)
+	settings, err := cli.ReadConfigFile(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := cli.GetConfigValue(settings, key); !ok {
+		c.Infof("%s is not set in %s\n", key, path)
+		return nil
+	}
+	err = cli.WriteConfigFile(path, func(settings map[string]interface{}) {
+		cli.UnsetConfigValue(settings, key)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Successf("Unset %s in %s\n", key, path)
+	return nil
+}
+
+func NewConfigUnsetCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &ConfigUnsetOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "unset",
+		Short: "remove a setting from a config file",
+		Long: strings.TrimSpace(`
+Remove a setting from the user config file, or from the project config file with
+` + cli.ProjectFlagName + `. With ` + cli.ProfileFlagName + `, the setting is removed from the named profile.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s config unset image-prefix", c.Name),
+			fmt.Sprintf("%s config unset namespace %s staging", c.Name, cli.ProfileFlagName),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.KeyArg(&opts.Key),
+	)
+	cmd.ValidArgsFunction = completeConfigKeys
+
+	cmd.Flags().StringVar(&opts.Profile, cli.StripDash(cli.ProfileFlagName), "", "`name` of the profile to remove the setting from")
+	cmd.Flags().BoolVar(&opts.Project, cli.StripDash(cli.ProjectFlagName), false, "remove from the project config file rather than the user config file")
+
+	return cmd
+}
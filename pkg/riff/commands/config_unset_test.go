@@ -0,0 +1,108 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+)
+
+func TestConfigUnsetOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name:              "default",
+			Options:           &commands.ConfigUnsetOptions{},
+			ExpectFieldErrors: cli.ErrMissingField(cli.KeyArgumentName),
+		},
+		{
+			Name: "valid",
+			Options: &commands.ConfigUnsetOptions{
+				Key: cli.NamespaceConfigKey,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "unknown key",
+			Options: &commands.ConfigUnsetOptions{
+				Key: "bogus",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("bogus", cli.KeyArgumentName),
+		},
+		{
+			Name: "invalid profile",
+			Options: &commands.ConfigUnsetOptions{
+				Key:     cli.NamespaceConfigKey,
+				Profile: "Staging",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("Staging", cli.ProfileFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestConfigUnsetCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "riff-config-unset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, ".riff.yaml")
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name:    "unset",
+			Args:    []string{cli.NamespaceConfigKey},
+			Prepare: prepareConfigFile(configFile, "namespace: my-namespace\nno-color: true\n"),
+			CleanUp: verifyConfigFile(configFile, "no-color: true\n"),
+			ExpectOutput: `
+Unset namespace in ` + configFile + `
+`,
+		},
+		{
+			Name:    "profile",
+			Args:    []string{cli.NamespaceConfigKey, cli.ProfileFlagName, "staging"},
+			Prepare: prepareConfigFile(configFile, "namespace: my-namespace\nprofiles:\n  staging:\n    namespace: staging\n"),
+			CleanUp: verifyConfigFile(configFile, "namespace: my-namespace\n"),
+			ExpectOutput: `
+Unset profiles.staging.namespace in ` + configFile + `
+`,
+		},
+		{
+			Name:    "not set",
+			Args:    []string{cli.NamespaceConfigKey},
+			Prepare: prepareConfigFile(configFile, "no-color: true\n"),
+			CleanUp: verifyConfigFile(configFile, "no-color: true\n"),
+			ExpectOutput: `
+namespace is not set in ` + configFile + `
+`,
+		},
+	}
+
+	table.Run(t, commands.NewConfigUnsetCommand)
+}
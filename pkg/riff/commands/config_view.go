@@ -0,0 +1,87 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type ConfigViewOptions struct{}
+
+var (
+	_ cli.Validatable = (*ConfigViewOptions)(nil)
+	_ cli.Executable  = (*ConfigViewOptions)(nil)
+)
+
+func (opts *ConfigViewOptions) Validate(ctx context.Context) cli.FieldErrors {
+	return cli.FieldErrors{}
+}
+
+func (opts *ConfigViewOptions) Exec(ctx context.Context, c *cli.Config) error {
+	path, err := c.UserConfigFile()
+	if err != nil {
+		return err
+	}
+	c.Printf("# user config file: %s\n", path)
+	if c.ProjectConfigFile != "" {
+		c.Printf("# project config file: %s\n", c.ProjectConfigFile)
+	}
+
+	settings := map[string]interface{}{}
+	for _, key := range cli.ConfigKeys {
+		if viper.IsSet(key.Name) {
+			settings[key.Name] = viper.Get(key.Name)
+		}
+	}
+	if len(settings) == 0 {
+		c.Infof("No settings found.\n")
+		return nil
+	}
+	content, err := yaml.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	c.Printf("%s", content)
+	return nil
+}
+
+func NewConfigViewCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &ConfigViewOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "print the settings in effect",
+		Long: strings.TrimSpace(`
+Print the settings in effect as YAML, after applying the project config file,
+the profile and environment variables. The config files read are listed first.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s config view", c.Name),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	return cmd
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"github.com/spf13/viper"
+)
+
+func TestConfigViewOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name:           "valid",
+			Options:        &commands.ConfigViewOptions{},
+			ShouldValidate: true,
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestConfigViewCommand(t *testing.T) {
+	reset := func(t *testing.T, ctx context.Context, c *cli.Config) error {
+		viper.Reset()
+		return nil
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name: "settings",
+			Args: []string{},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				c.ViperConfigFile = "/home/me/.riff.yaml"
+				c.ProjectConfigFile = "/home/me/project/.riff.yaml"
+				viper.Set(cli.NamespaceConfigKey, "my-namespace")
+				viper.Set(cli.WaitTimeoutConfigKey, "5m")
+				// settings for other keys are not shown
+				viper.Set("kubeconfig", "/home/me/.kube/config")
+				return ctx, nil
+			},
+			CleanUp: reset,
+			ExpectOutput: `
+# user config file: /home/me/.riff.yaml
+# project config file: /home/me/project/.riff.yaml
+namespace: my-namespace
+wait-timeout: 5m
+`,
+		},
+		{
+			Name: "no settings",
+			Args: []string{},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				c.ViperConfigFile = "/home/me/.riff.yaml"
+				return ctx, nil
+			},
+			CleanUp: reset,
+			ExpectOutput: `
+# user config file: /home/me/.riff.yaml
+No settings found.
+`,
+		},
+	}
+
+	table.Run(t, commands.NewConfigViewCommand)
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewContextCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "kubectl config contexts used by " + c.Name,
+		Long: strings.TrimSpace(`
+Contexts from the kubectl config select the cluster, user and namespace that
+commands run against. By default, the current context of the kubectl config is
+used. A different default context and namespace for ` + c.Name + ` alone are
+saved to the ` + c.Name + ` config file by "` + c.Name + ` context use", leaving the kubectl
+config untouched.
+
+Any command may target another context with ` + cli.ContextFlagName + `, or override the
+cluster, user, server, token, impersonation and request timeout with the
+corresponding flags.
+`),
+		Aliases: []string{"contexts", "ctx"},
+	}
+
+	cmd.AddCommand(NewContextListCommand(ctx, c))
+	cmd.AddCommand(NewContextUseCommand(ctx, c))
+
+	return cmd
+}
+
+// completeKubeContexts completes the names of contexts in the kubectl config
+func completeKubeContexts(c *cli.Config) cli.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		raw, err := c.KubeConfig().RawConfig()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		names := []string{}
+		for name := range raw.Contexts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
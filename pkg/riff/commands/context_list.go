@@ -0,0 +1,99 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+type ContextListOptions struct{}
+
+var (
+	_ cli.Validatable = (*ContextListOptions)(nil)
+	_ cli.Executable  = (*ContextListOptions)(nil)
+)
+
+func (opts *ContextListOptions) Validate(ctx context.Context) cli.FieldErrors {
+	return cli.FieldErrors{}
+}
+
+func (opts *ContextListOptions) Exec(ctx context.Context, c *cli.Config) error {
+	raw, err := c.KubeConfig().RawConfig()
+	if err != nil {
+		return err
+	}
+
+	if len(raw.Contexts) == 0 {
+		c.Infof("No contexts found.\n")
+		return nil
+	}
+
+	current := c.KubeConfigOverrides.CurrentContext
+	if current == "" {
+		current = raw.CurrentContext
+	}
+	names := []string{}
+	for name := range raw.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := printers.GetNewTabWriter(c.Stdout)
+	defer w.Flush()
+	fmt.Fprintf(w, "CURRENT\tNAME\tCLUSTER\tUSER\tNAMESPACE\n")
+	for _, name := range names {
+		kubeContext := raw.Contexts[name]
+		marker := ""
+		namespace := kubeContext.Namespace
+		if name == current {
+			marker = "*"
+			if override := c.KubeConfigOverrides.Context.Namespace; override != "" {
+				namespace = override
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", marker, name, kubeContext.Cluster, kubeContext.AuthInfo, cli.FormatEmptyString(namespace))
+	}
+
+	return nil
+}
+
+func NewContextListCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &ContextListOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "table listing of kubectl config contexts",
+		Long: strings.TrimSpace(`
+List the contexts defined in the kubectl config. The context used by default is
+marked as current, along with its namespace.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s context list", c.Name),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	return cmd
+}
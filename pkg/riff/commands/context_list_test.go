@@ -0,0 +1,86 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestContextListOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name:           "valid",
+			Options:        &commands.ContextListOptions{},
+			ShouldValidate: true,
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestContextListCommand(t *testing.T) {
+	table := rifftesting.CommandTable{
+		{
+			Name: "empty",
+			Args: []string{},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				c.Client.(*rifftesting.FakeClient).FakeKubeConfig = clientcmd.NewDefaultClientConfig(*clientcmdapi.NewConfig(), &clientcmd.ConfigOverrides{})
+				return ctx, nil
+			},
+			ExpectOutput: `
+No contexts found.
+`,
+		},
+		{
+			Name: "lists contexts",
+			Args: []string{},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				c.Client.(*rifftesting.FakeClient).FakeKubeConfig = givenKubeConfig()
+				return ctx, nil
+			},
+			ExpectOutput: `
+CURRENT   NAME            CLUSTER         USER         NAMESPACE
+*         my-context      my-cluster      my-user      my-namespace
+          other-context   other-cluster   other-user   <empty>
+`,
+		},
+		{
+			Name: "context override",
+			Args: []string{},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				c.Client.(*rifftesting.FakeClient).FakeKubeConfig = givenKubeConfig()
+				c.KubeConfigOverrides.CurrentContext = "other-context"
+				c.KubeConfigOverrides.Context.Namespace = "my-namespace"
+				return ctx, nil
+			},
+			ExpectOutput: `
+CURRENT   NAME            CLUSTER         USER         NAMESPACE
+          my-context      my-cluster      my-user      my-namespace
+*         other-context   other-cluster   other-user   my-namespace
+`,
+		},
+	}
+
+	table.Run(t, commands.NewContextListCommand)
+}
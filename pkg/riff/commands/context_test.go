@@ -0,0 +1,49 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestContextCommand(t *testing.T) {
+	table := rifftesting.CommandTable{
+		{
+			Name: "empty",
+			Args: []string{},
+		},
+	}
+
+	table.Run(t, commands.NewContextCommand)
+}
+
+func givenKubeConfig() clientcmd.ClientConfig {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["my-cluster"] = &clientcmdapi.Cluster{Server: "https://192.168.1.1:8443"}
+	config.Clusters["other-cluster"] = &clientcmdapi.Cluster{Server: "https://192.168.1.2:8443"}
+	config.AuthInfos["my-user"] = &clientcmdapi.AuthInfo{}
+	config.AuthInfos["other-user"] = &clientcmdapi.AuthInfo{}
+	config.Contexts["my-context"] = &clientcmdapi.Context{Cluster: "my-cluster", AuthInfo: "my-user", Namespace: "my-namespace"}
+	config.Contexts["other-context"] = &clientcmdapi.Context{Cluster: "other-cluster", AuthInfo: "other-user"}
+	config.CurrentContext = "my-context"
+	return clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{})
+}
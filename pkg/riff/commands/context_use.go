@@ -0,0 +1,121 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/validation"
+	"github.com/spf13/cobra"
+)
+
+type ContextUseOptions struct {
+	Name      string
+	Namespace string
+}
+
+var (
+	_ cli.Validatable = (*ContextUseOptions)(nil)
+	_ cli.Executable  = (*ContextUseOptions)(nil)
+)
+
+func (opts *ContextUseOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if opts.Name == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.NameArgumentName))
+	}
+
+	if opts.Namespace != "" {
+		errs = errs.Also(validation.K8sName(opts.Namespace, cli.NamespaceFlagName))
+	}
+
+	return errs
+}
+
+func (opts *ContextUseOptions) Exec(ctx context.Context, c *cli.Config) error {
+	raw, err := c.KubeConfig().RawConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := raw.Contexts[opts.Name]; !ok {
+		return fmt.Errorf("context %q not found in kubectl config", opts.Name)
+	}
+
+	path, err := c.UserConfigFile()
+	if err != nil {
+		return err
+	}
+	err = cli.WriteConfigFile(path, func(settings map[string]interface{}) {
+		settings[cli.ContextConfigKey] = opts.Name
+		if opts.Namespace != "" {
+			settings[cli.NamespaceConfigKey] = opts.Namespace
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.Namespace != "" {
+		c.Successf("Using context %q with namespace %q\n", opts.Name, opts.Namespace)
+	} else {
+		c.Successf("Using context %q\n", opts.Name)
+	}
+	c.Infof("Saved to %s\n", path)
+	return nil
+}
+
+func NewContextUseCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &ContextUseOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "use",
+		Short: "set the kubectl config context used by default",
+		Long: strings.TrimSpace(`
+Set the context from the kubectl config that ` + c.Name + ` uses by default, optionally
+with a namespace to use in that context. The choice is saved to the ` + c.Name + ` config
+file, the kubectl config is not modified.
+
+The saved namespace is only changed when ` + cli.NamespaceFlagName + ` is set. It only applies
+when the saved context is used, it is ignored when another context is set with
+` + cli.ContextFlagName + `.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s context use my-context", c.Name),
+			fmt.Sprintf("%s context use my-context %s my-namespace", c.Name, cli.NamespaceFlagName),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.NameArg(&opts.Name),
+	)
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeKubeContexts(c)(cmd, args, toComplete)
+	}
+
+	cmd.Flags().StringVarP(&opts.Namespace, cli.StripDash(cli.NamespaceFlagName), "n", "", "kubernetes `name`space to use by default in the context (default is the context's namespace)")
+
+	return cmd
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"github.com/spf13/viper"
+)
+
+func TestContextUseOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name:              "default",
+			Options:           &commands.ContextUseOptions{},
+			ExpectFieldErrors: cli.ErrMissingField(cli.NameArgumentName),
+		},
+		{
+			Name: "valid",
+			Options: &commands.ContextUseOptions{
+				Name: "my-context",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "valid namespace",
+			Options: &commands.ContextUseOptions{
+				Name:      "my-context",
+				Namespace: "my-namespace",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid namespace",
+			Options: &commands.ContextUseOptions{
+				Name:      "my-context",
+				Namespace: "my.namespace",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("my.namespace", cli.NamespaceFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestContextUseCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "riff-context-use")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, ".riff.yaml")
+
+	prepare := func(existing string) func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+		return func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+			c.Client.(*rifftesting.FakeClient).FakeKubeConfig = givenKubeConfig()
+			c.ViperConfigFile = configFile
+			if existing == "" {
+				return ctx, nil
+			}
+			return ctx, ioutil.WriteFile(configFile, []byte(existing), 0644)
+		}
+	}
+	expectConfigFile := func(expected string) func(t *testing.T, ctx context.Context, c *cli.Config) error {
+		return func(t *testing.T, ctx context.Context, c *cli.Config) error {
+			defer viper.Reset()
+			defer os.Remove(configFile)
+			actual, err := ioutil.ReadFile(configFile)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if diff := cmp.Diff(expected, string(actual)); diff != "" {
+				t.Errorf("Unexpected config file (-expected, +actual): %s", diff)
+			}
+			return nil
+		}
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name:    "use context",
+			Args:    []string{"other-context"},
+			Prepare: prepare(""),
+			CleanUp: expectConfigFile("context: other-context\n"),
+			ExpectOutput: `
+Using context "other-context"
+Saved to ` + configFile + `
+`,
+		},
+		{
+			Name:    "use context with namespace",
+			Args:    []string{"other-context", cli.NamespaceFlagName, "other-namespace"},
+			Prepare: prepare(""),
+			CleanUp: expectConfigFile("context: other-context\nnamespace: other-namespace\n"),
+			ExpectOutput: `
+Using context "other-context" with namespace "other-namespace"
+Saved to ` + configFile + `
+`,
+		},
+		{
+			Name:    "preserves existing settings",
+			Args:    []string{"my-context"},
+			Prepare: prepare("context: other-context\nnamespace: other-namespace\nno-color: true\n"),
+			CleanUp: expectConfigFile("context: my-context\nnamespace: other-namespace\nno-color: true\n"),
+			ExpectOutput: `
+Using context "my-context"
+Saved to ` + configFile + `
+`,
+		},
+		{
+			Name:        "unknown context",
+			Args:        []string{"missing-context"},
+			Prepare:     prepare(""),
+			CleanUp:     expectConfigFile(""),
+			ShouldError: true,
+			ExpectOutput: `
+`,
+		},
+	}
+
+	table.Run(t, commands.NewContextUseCommand)
+}
@@ -31,8 +31,17 @@ import (
 
 const riffSystemNamespace = "riff-system"
 
+const (
+	doctorOutputTable = "table"
+	doctorOutputJSON  = "json"
+)
+
 type DoctorOptions struct {
-	Namespace string
+	Namespace    string
+	Health       bool
+	Output       string
+	GenerateRBAC bool
+	Subjects     []string
 }
 
 var (
@@ -47,10 +56,41 @@ func (opts *DoctorOptions) Validate(ctx context.Context) cli.FieldErrors {
 		errs = errs.Also(cli.ErrMissingField(cli.NamespaceFlagName))
 	}
 
+	switch opts.Output {
+	case "", doctorOutputTable:
+	case doctorOutputJSON:
+		if !opts.Health {
+			// access checks are only printed as a table
+			errs = errs.Also(cli.ErrInvalidValue(opts.Output, cli.OutputFlagName))
+		}
+	default:
+		errs = errs.Also(cli.ErrInvalidValue(opts.Output, cli.OutputFlagName))
+	}
+
+	if opts.Health && opts.GenerateRBAC {
+		errs = errs.Also(cli.ErrMultipleOneOf(cli.HealthFlagName, cli.GenerateRBACFlagName))
+	}
+	if len(opts.Subjects) != 0 && !opts.GenerateRBAC {
+		errs = errs.Also(cli.ErrDisallowedFields(cli.SubjectFlagName, fmt.Sprintf("requires %s", cli.GenerateRBACFlagName)))
+	}
+	for i, subject := range opts.Subjects {
+		if _, err := parseRBACSubject(subject, opts.Namespace); err != nil {
+			errs = errs.Also(cli.ErrInvalidArrayValue(subject, cli.SubjectFlagName, i))
+		}
+	}
+
 	return errs
 }
 
 func (opts *DoctorOptions) Exec(ctx context.Context, c *cli.Config) error {
+	c.LoadRuntimes()
+	if opts.Health {
+		return opts.checkHealth(c)
+	}
+	if opts.GenerateRBAC {
+		return opts.generateRBAC(ctx, c)
+	}
+
 	riffNamespaces := []string{
 		opts.Namespace,
 		riffSystemNamespace,
@@ -60,45 +100,52 @@ func (opts *DoctorOptions) Exec(ctx context.Context, c *cli.Config) error {
 		return err
 	}
 
+	accessChecks := opts.accessChecks(c)
+	err = opts.checkAccess(c, accessChecks)
+	if err != nil {
+		return err
+	}
+	if !accessChecks.IsHealthy() {
+		opts.printAccessImpacts(c, accessChecks)
+	}
+
+	return nil
+}
+
+func (opts *DoctorOptions) accessChecks(c *cli.Config) doctorAccessChecks {
 	verbs := []string{"get", "list", "create", "update", "delete", "patch", "watch"}
 	readVerbs := []string{"get", "list", "watch"}
 	accessChecks := doctorAccessChecks{
-		{Attributes: &authv1.ResourceAttributes{Namespace: riffSystemNamespace, Group: "core", Resource: "configmaps", Name: "builders"}, Verbs: readVerbs},
-		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "core", Resource: "configmaps"}, Verbs: verbs},
-		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "core", Resource: "secrets"}, Verbs: verbs},
-		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "core", Resource: "pods"}, Verbs: readVerbs},
-		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "core", Resource: "pods", Subresource: "log"}, Verbs: readVerbs},
-		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "build.projectriff.io", Resource: "applications"}, Verbs: verbs},
-		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "build.projectriff.io", Resource: "containers"}, Verbs: verbs},
-		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "build.projectriff.io", Resource: "functions"}, Verbs: verbs},
+		{Attributes: &authv1.ResourceAttributes{Namespace: riffSystemNamespace, Group: "core", Resource: "configmaps", Name: "builders"}, Verbs: readVerbs, Impact: doctorAccessImpact{Read: "builders for functions and applications are unresolved"}},
+		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "core", Resource: "configmaps"}, Verbs: verbs, Impact: doctorAccessImpact{Read: "the default image prefix is unresolved for builds", Write: fmt.Sprintf("\"%s credential apply\" is unable to set the default image prefix", c.Name)}},
+		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "core", Resource: "secrets"}, Verbs: verbs, Impact: doctorCommandImpact(c, "credential")},
+		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "core", Resource: "pods"}, Verbs: readVerbs, Impact: doctorAccessImpact{Read: "logs are unable to be tailed"}},
+		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "core", Resource: "pods", Subresource: "log"}, Verbs: readVerbs, Impact: doctorAccessImpact{Read: "logs are unable to be tailed"}},
+		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "build.projectriff.io", Resource: "applications"}, Verbs: verbs, Impact: doctorCommandImpact(c, "application")},
+		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "build.projectriff.io", Resource: "containers"}, Verbs: verbs, Impact: doctorCommandImpact(c, "container")},
+		{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "build.projectriff.io", Resource: "functions"}, Verbs: verbs, Impact: doctorCommandImpact(c, "function")},
 	}
 	if c.Runtimes[cli.CoreRuntime] {
 		accessChecks = append(accessChecks,
-			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "core.projectriff.io", Resource: "deployers"}, Verbs: verbs},
+			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "core.projectriff.io", Resource: "deployers"}, Verbs: verbs, Impact: doctorCommandImpact(c, "core deployer")},
 		)
 	}
 	if c.Runtimes[cli.StreamingRuntime] {
 		accessChecks = append(accessChecks,
-			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "streaming.projectriff.io", Resource: "processors"}, Verbs: verbs},
-			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "streaming.projectriff.io", Resource: "streams"}, Verbs: verbs},
-			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "streaming.projectriff.io", Resource: "inmemorygateways"}, Verbs: verbs},
-			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "streaming.projectriff.io", Resource: "kafkagateways"}, Verbs: verbs},
-			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "streaming.projectriff.io", Resource: "pulsargateways"}, Verbs: verbs},
+			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "streaming.projectriff.io", Resource: "processors"}, Verbs: verbs, Impact: doctorCommandImpact(c, "streaming processor")},
+			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "streaming.projectriff.io", Resource: "streams"}, Verbs: verbs, Impact: doctorCommandImpact(c, "streaming stream")},
+			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "streaming.projectriff.io", Resource: "inmemorygateways"}, Verbs: verbs, Impact: doctorCommandImpact(c, "streaming inmemory-gateway")},
+			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "streaming.projectriff.io", Resource: "kafkagateways"}, Verbs: verbs, Impact: doctorCommandImpact(c, "streaming kafka-gateway")},
+			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "streaming.projectriff.io", Resource: "pulsargateways"}, Verbs: verbs, Impact: doctorCommandImpact(c, "streaming pulsar-gateway")},
 		)
 	}
 	if c.Runtimes[cli.KnativeRuntime] {
 		accessChecks = append(accessChecks,
-			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "knative.projectriff.io", Resource: "adapters"}, Verbs: verbs},
-			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "knative.projectriff.io", Resource: "deployers"}, Verbs: verbs},
+			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "knative.projectriff.io", Resource: "adapters"}, Verbs: verbs, Impact: doctorCommandImpact(c, "knative adapter")},
+			&doctorAccessCheck{Attributes: &authv1.ResourceAttributes{Namespace: opts.Namespace, Group: "knative.projectriff.io", Resource: "deployers"}, Verbs: verbs, Impact: doctorCommandImpact(c, "knative deployer")},
 		)
 	}
-
-	err = opts.checkAccess(c, accessChecks)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return accessChecks
 }
 
 func NewDoctorCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -107,20 +154,52 @@ func NewDoctorCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "doctor",
 		Aliases: []string{"doc"},
-		Short:   "check " + c.Name + "'s permissions",
+		Short:   "check " + c.Name + "'s permissions and installation",
 		Long: strings.TrimSpace(`
 The doctor checks that the current user has permission to access ` + c.Name + `, and ` + c.Name + `
 related, resources in a namespace.
 
-The doctor is not a tool for monitoring the health of the cluster or the ` + c.Name + `
-install.
+With ` + cli.HealthFlagName + `, the doctor instead checks the health of the ` + c.Name + ` install:
+- the ` + c.Name + ` custom resources are installed at the expected version
+- the builders are defined for functions and applications
+- the controllers in the "` + riffSystemNamespace + `" namespace are available
+- the dependencies of each enabled runtime are installed
+- the namespace's default image prefix and credentials are usable
+
+Each check passes, warns or fails. The command fails when any check fails.
+Results are printed as JSON with "` + cli.OutputFlagName + ` json".
+
+With ` + cli.GenerateRBACFlagName + `, the doctor prints Roles granting the access ` + c.Name + `
+needs in the namespace for the enabled runtimes, and read access to the builders
+in the "` + riffSystemNamespace + `" namespace. RoleBindings are also printed for each
+` + cli.SubjectFlagName + `, in the form "User:<name>", "Group:<name>" or
+"ServiceAccount:[<namespace>/]<name>".
 `),
-		Example: "riff doctor",
-		PreRunE: cli.ValidateOptions(ctx, opts),
-		RunE:    cli.ExecOptions(ctx, c, opts),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s doctor", c.Name),
+			fmt.Sprintf("%s doctor %s", c.Name, cli.HealthFlagName),
+			fmt.Sprintf("%s doctor %s %s json", c.Name, cli.HealthFlagName, cli.OutputFlagName),
+			fmt.Sprintf("%s doctor %s %s User:jane", c.Name, cli.GenerateRBACFlagName, cli.SubjectFlagName),
+		}, "\n"),
+		PreRunE: cli.Sequence(
+			func(cmd *cobra.Command, args []string) error {
+				// the output format from the config file only applies to health checks
+				if !opts.Health && !cmd.Flags().Changed(cli.StripDash(cli.OutputFlagName)) {
+					opts.Output = doctorOutputTable
+				}
+				return nil
+			},
+			cli.ValidateOptions(ctx, opts),
+		),
+		RunE: cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cmd.Flags().BoolVar(&opts.Health, cli.StripDash(cli.HealthFlagName), false, "check the health of the "+c.Name+" install rather than permissions")
+	cmd.Flags().BoolVar(&opts.GenerateRBAC, cli.StripDash(cli.GenerateRBACFlagName), false, "print RBAC resources granting the access "+c.Name+" needs rather than checking permissions")
+	cmd.Flags().StringArrayVar(&opts.Subjects, cli.StripDash(cli.SubjectFlagName), []string{}, "`subject` to bind the generated roles to, in the form <kind>:[<namespace>/]<name> (may be set multiple times)")
+	cmd.Flags().StringVar(&opts.Output, cli.StripDash(cli.OutputFlagName), doctorOutputTable, "output `format` for health checks, one of \"table\" or \"json\"")
+	cli.ConfigDefault(cmd, cli.OutputFlagName, cli.OutputConfigKey)
 
 	return cmd
 }
@@ -153,29 +232,83 @@ func (*DoctorOptions) checkAccess(c *cli.Config, accessChecks doctorAccessChecks
 	defer printer.Flush()
 	fmt.Fprintf(printer, "RESOURCE\tNAMESPACE\tNAME\tREAD\tWRITE\n")
 	for _, check := range accessChecks {
-		resource := check.Attributes.Resource
-		if check.Attributes.Group != "core" {
-			resource = fmt.Sprintf("%s.%s", resource, check.Attributes.Group)
+		fmt.Fprintf(printer, "%s\t%s\t%s\t%s\t%s\n", check.ResourceName(), check.Attributes.Namespace, check.Name(), check.ReadStatus.String(), check.WriteStatus.String())
+	}
+	return nil
+}
+
+func (*DoctorOptions) printAccessImpacts(c *cli.Config, accessChecks doctorAccessChecks) {
+	c.Printf("\n")
+	printer := printers.GetNewTabWriter(c.Stdout)
+	fmt.Fprintf(printer, "RESOURCE\tNAMESPACE\tNAME\tACCESS\tIMPACT\n")
+	denied := false
+	for _, check := range accessChecks {
+		readHealthy, writeHealthy := check.ReadStatus.IsHealthy(), check.WriteStatus.IsHealthy()
+		if readHealthy && writeHealthy {
+			continue
 		}
-		if check.Attributes.Subresource != "" {
-			resource = fmt.Sprintf("%s/%s", resource, check.Attributes.Subresource)
+		denied = denied || (check.ReadStatus != doctorAccessMissing && check.WriteStatus != doctorAccessMissing)
+		access, impact := "write", check.Impact.Write
+		if !readHealthy {
+			access, impact = "read", check.Impact.Read
+			if !writeHealthy && check.WriteStatus != doctorAccessUndefined {
+				access = "read, write"
+			}
 		}
-		name := check.Attributes.Name
-		if name == "" {
-			name = "*"
+		if check.ReadStatus == doctorAccessMissing {
+			impact = fmt.Sprintf("not installed, %s", impact)
 		}
-		fmt.Fprintf(printer, "%s\t%s\t%s\t%s\t%s\n", resource, check.Attributes.Namespace, name, check.ReadStatus.String(), check.WriteStatus.String())
+		fmt.Fprintf(printer, "%s\t%s\t%s\t%s\t%s\n", check.ResourceName(), check.Attributes.Namespace, check.Name(), access, impact)
+	}
+	printer.Flush()
+	if denied {
+		c.Printf("\n")
+		c.Infof("Roles granting the access %s needs are printed by \"%s doctor %s\"\n", c.Name, c.Name, cli.GenerateRBACFlagName)
 	}
-	return nil
 }
 
 type doctorAccessCheck struct {
 	Attributes  *authv1.ResourceAttributes
 	Verbs       []string
+	Impact      doctorAccessImpact
 	ReadStatus  doctorAccessStatus
 	WriteStatus doctorAccessStatus
 }
 
+// doctorAccessImpact describes the features that are unavailable without read or write access to
+// a resource
+type doctorAccessImpact struct {
+	Read  string
+	Write string
+}
+
+func doctorCommandImpact(c *cli.Config, command string) doctorAccessImpact {
+	return doctorAccessImpact{
+		Read:  fmt.Sprintf("\"%s %s\" commands are unavailable", c.Name, command),
+		Write: fmt.Sprintf("\"%s %s\" is unable to create, update or delete resources", c.Name, command),
+	}
+}
+
+// ResourceName formats the checked resource as <resource>[.<group>][/<subresource>]
+func (check *doctorAccessCheck) ResourceName() string {
+	resource := check.Attributes.Resource
+	if check.Attributes.Group != "core" {
+		resource = fmt.Sprintf("%s.%s", resource, check.Attributes.Group)
+	}
+	if check.Attributes.Subresource != "" {
+		resource = fmt.Sprintf("%s/%s", resource, check.Attributes.Subresource)
+	}
+	return resource
+}
+
+// Name returns the name of the checked resource, or "*" for all resources
+func (check *doctorAccessCheck) Name() string {
+	if check.Attributes.Name == "" {
+		return "*"
+	}
+	return check.Attributes.Name
+}
+
 func (check *doctorAccessCheck) ResolveStatus(c *cli.Config) error {
 	if strings.Contains(check.Attributes.Group, ".") {
 		missing, err := check.isCustomResourceMissing(c, fmt.Sprintf("%s.%s", check.Attributes.Resource, check.Attributes.Group))
@@ -240,10 +373,7 @@ func (checks doctorAccessChecks) ResolveStatus(c *cli.Config) error {
 
 func (checks doctorAccessChecks) IsHealthy() bool {
 	for _, check := range checks {
-		if check.ReadStatus != doctorAccessAllowed && check.ReadStatus != doctorAccessUndefined {
-			return false
-		}
-		if check.WriteStatus != doctorAccessAllowed && check.WriteStatus != doctorAccessUndefined {
+		if !check.ReadStatus.IsHealthy() || !check.WriteStatus.IsHealthy() {
 			return false
 		}
 	}
@@ -277,6 +407,10 @@ func (das doctorAccessStatus) Combine(new doctorAccessStatus) doctorAccessStatus
 	return doctorAccessDenied
 }
 
+func (das doctorAccessStatus) IsHealthy() bool {
+	return das == doctorAccessAllowed || das == doctorAccessUndefined
+}
+
 func (das doctorAccessStatus) String() string {
 	switch das {
 	case doctorAccessAllowed:
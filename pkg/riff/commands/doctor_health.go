@@ -0,0 +1,352 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const riffVersion = "v1alpha1"
+
+type doctorHealthStatus string
+
+const (
+	doctorHealthPass doctorHealthStatus = "pass"
+	doctorHealthWarn doctorHealthStatus = "warn"
+	doctorHealthFail doctorHealthStatus = "fail"
+)
+
+func (dhs doctorHealthStatus) String() string {
+	switch dhs {
+	case doctorHealthPass:
+		return cli.Ssuccessf(string(dhs))
+	case doctorHealthWarn:
+		return cli.Swarnf(string(dhs))
+	}
+	return cli.Serrorf(string(dhs))
+}
+
+type doctorHealthCheck struct {
+	Check   string             `json:"check"`
+	Status  doctorHealthStatus `json:"status"`
+	Message string             `json:"message,omitempty"`
+}
+
+type doctorHealthChecks []doctorHealthCheck
+
+func (checks doctorHealthChecks) IsHealthy() bool {
+	for _, check := range checks {
+		if check.Status == doctorHealthFail {
+			return false
+		}
+	}
+	return true
+}
+
+// doctorRuntime describes the resources installed for a riff runtime
+type doctorRuntime struct {
+	// CustomResources are the riff resources installed, as <resource>.<group>
+	CustomResources []string
+	// Controller is the name of the deployment reconciling the custom resources
+	Controller string
+	// Dependencies are resources installed by third party systems the runtime depends on
+	Dependencies []doctorDependency
+}
+
+type doctorDependency struct {
+	// Resource is a custom resource installed by the system, as <resource>.<group>
+	Resource string
+	// System is the display name of the system
+	System string
+}
+
+func (opts *DoctorOptions) doctorRuntimes(c *cli.Config) []doctorRuntime {
+	runtimes := []doctorRuntime{
+		{
+			CustomResources: []string{
+				"applications.build.projectriff.io",
+				"containers.build.projectriff.io",
+				"functions.build.projectriff.io",
+			},
+			Controller: "riff-build-controller-manager",
+			Dependencies: []doctorDependency{
+				{Resource: "images.build.pivotal.io", System: "kpack"},
+			},
+		},
+	}
+	if c.Runtimes[cli.CoreRuntime] {
+		runtimes = append(runtimes, doctorRuntime{
+			CustomResources: []string{
+				"deployers.core.projectriff.io",
+			},
+			Controller: "riff-core-controller-manager",
+		})
+	}
+	if c.Runtimes[cli.StreamingRuntime] {
+		runtimes = append(runtimes, doctorRuntime{
+			CustomResources: []string{
+				"gateways.streaming.projectriff.io",
+				"inmemorygateways.streaming.projectriff.io",
+				"kafkagateways.streaming.projectriff.io",
+				"processors.streaming.projectriff.io",
+				"pulsargateways.streaming.projectriff.io",
+				"streams.streaming.projectriff.io",
+			},
+			Controller: "riff-streaming-controller-manager",
+			Dependencies: []doctorDependency{
+				{Resource: "scaledobjects.keda.k8s.io", System: "KEDA"},
+			},
+		})
+	}
+	if c.Runtimes[cli.KnativeRuntime] {
+		runtimes = append(runtimes, doctorRuntime{
+			CustomResources: []string{
+				"adapters.knative.projectriff.io",
+				"deployers.knative.projectriff.io",
+			},
+			Controller: "riff-knative-controller-manager",
+			Dependencies: []doctorDependency{
+				{Resource: "configurations.serving.knative.dev", System: "Knative Serving"},
+				{Resource: "services.serving.knative.dev", System: "Knative Serving"},
+			},
+		})
+	}
+	return runtimes
+}
+
+func (opts *DoctorOptions) checkHealth(c *cli.Config) error {
+	checks := doctorHealthChecks{}
+	for _, runtime := range opts.doctorRuntimes(c) {
+		for _, name := range runtime.CustomResources {
+			check, err := opts.checkCustomResource(c, name)
+			if err != nil {
+				return err
+			}
+			checks = append(checks, check)
+		}
+		check, err := opts.checkController(c, runtime.Controller)
+		if err != nil {
+			return err
+		}
+		checks = append(checks, check)
+		for _, dependency := range runtime.Dependencies {
+			check, err := opts.checkDependency(c, dependency)
+			if err != nil {
+				return err
+			}
+			checks = append(checks, check)
+		}
+	}
+	builderChecks, err := opts.checkBuilders(c)
+	if err != nil {
+		return err
+	}
+	checks = append(checks, builderChecks...)
+	buildChecks, err := opts.checkBuildConfig(c)
+	if err != nil {
+		return err
+	}
+	checks = append(checks, buildChecks...)
+
+	if opts.Output == doctorOutputJSON {
+		out, err := json.MarshalIndent(struct {
+			Healthy bool               `json:"healthy"`
+			Checks  doctorHealthChecks `json:"checks"`
+		}{
+			Healthy: checks.IsHealthy(),
+			Checks:  checks,
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		c.Printf("%s\n", out)
+	} else {
+		printer := printers.GetNewTabWriter(c.Stdout)
+		fmt.Fprintf(printer, "CHECK\tSTATUS\tMESSAGE\n")
+		for _, check := range checks {
+			fmt.Fprintf(printer, "%s\t%s\t%s\n", check.Check, check.Status, check.Message)
+		}
+		printer.Flush()
+	}
+
+	if !checks.IsHealthy() {
+		return cli.SilenceError(fmt.Errorf("%s installation is unhealthy", c.Name))
+	}
+	return nil
+}
+
+func (*DoctorOptions) checkCustomResource(c *cli.Config, name string) (doctorHealthCheck, error) {
+	check := doctorHealthCheck{Check: fmt.Sprintf("crd %s", name)}
+	crd, err := c.APIExtension().CustomResourceDefinitions().Get(name, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return check, err
+		}
+		check.Status = doctorHealthFail
+		check.Message = "not installed"
+		return check, nil
+	}
+	if !crdServesVersion(crd, riffVersion) {
+		check.Status = doctorHealthFail
+		check.Message = fmt.Sprintf("version %s is not served", riffVersion)
+		return check, nil
+	}
+	check.Status = doctorHealthPass
+	check.Message = riffVersion
+	return check, nil
+}
+
+func (*DoctorOptions) checkDependency(c *cli.Config, dependency doctorDependency) (doctorHealthCheck, error) {
+	check := doctorHealthCheck{Check: fmt.Sprintf("dependency %s", dependency.Resource)}
+	_, err := c.APIExtension().CustomResourceDefinitions().Get(dependency.Resource, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return check, err
+		}
+		check.Status = doctorHealthFail
+		check.Message = fmt.Sprintf("%s is not installed", dependency.System)
+		return check, nil
+	}
+	check.Status = doctorHealthPass
+	check.Message = dependency.System
+	return check, nil
+}
+
+func (*DoctorOptions) checkController(c *cli.Config, name string) (doctorHealthCheck, error) {
+	check := doctorHealthCheck{Check: fmt.Sprintf("deployment %s/%s", riffSystemNamespace, name)}
+	deployment, err := c.Apps().Deployments(riffSystemNamespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return check, err
+		}
+		check.Status = doctorHealthFail
+		check.Message = "not installed"
+		return check, nil
+	}
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	available := deployment.Status.AvailableReplicas
+	check.Message = fmt.Sprintf("%d/%d replicas available", available, desired)
+	switch {
+	case available == 0:
+		check.Status = doctorHealthFail
+	case available < desired:
+		check.Status = doctorHealthWarn
+	default:
+		check.Status = doctorHealthPass
+	}
+	return check, nil
+}
+
+func (*DoctorOptions) checkBuilders(c *cli.Config) (doctorHealthChecks, error) {
+	name := fmt.Sprintf("configmap %s/builders", riffSystemNamespace)
+	builders, err := c.Core().ConfigMaps(riffSystemNamespace).Get("builders", metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		return doctorHealthChecks{{Check: name, Status: doctorHealthFail, Message: "not found"}}, nil
+	}
+	checks := doctorHealthChecks{}
+	for _, key := range []string{"riff-application", "riff-function"} {
+		check := doctorHealthCheck{Check: fmt.Sprintf("%s %s", name, key)}
+		if builder := builders.Data[key]; builder == "" {
+			check.Status = doctorHealthFail
+			check.Message = "builder is not defined"
+		} else {
+			check.Status = doctorHealthPass
+			check.Message = builder
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+func (opts *DoctorOptions) checkBuildConfig(c *cli.Config) (doctorHealthChecks, error) {
+	checks := doctorHealthChecks{}
+
+	check := doctorHealthCheck{Check: fmt.Sprintf("configmap %s/riff-build", opts.Namespace)}
+	config, err := c.Core().ConfigMaps(opts.Namespace).Get("riff-build", metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		check.Status = doctorHealthWarn
+		check.Message = "not found, images must be set explicitly"
+	} else if prefix := config.Data["default-image-prefix"]; prefix == "" {
+		check.Status = doctorHealthWarn
+		check.Message = "no default image prefix, images must be set explicitly"
+	} else {
+		check.Status = doctorHealthPass
+		check.Message = prefix
+	}
+	checks = append(checks, check)
+
+	credentials, err := c.Core().Secrets(opts.Namespace).List(metav1.ListOptions{
+		LabelSelector: buildv1alpha1.CredentialLabelKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(credentials.Items) == 0 {
+		checks = append(checks, doctorHealthCheck{
+			Check:   fmt.Sprintf("credentials %s", opts.Namespace),
+			Status:  doctorHealthWarn,
+			Message: "none found, builds are unable to push images",
+		})
+	}
+	for _, credential := range credentials.Items {
+		check := doctorHealthCheck{Check: fmt.Sprintf("credential %s/%s", credential.Namespace, credential.Name)}
+		registry := credential.Annotations["kpack.io/docker"]
+		switch {
+		case registry == "":
+			check.Status = doctorHealthFail
+			check.Message = "registry is not defined"
+		case len(credential.Data["username"]) == 0 || len(credential.Data["password"]) == 0:
+			check.Status = doctorHealthFail
+			check.Message = "username or password is not defined"
+		default:
+			check.Status = doctorHealthPass
+			check.Message = registry
+		}
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+func crdServesVersion(crd *apiextensionsv1beta1.CustomResourceDefinition, version string) bool {
+	if len(crd.Spec.Versions) == 0 {
+		return crd.Spec.Version == version
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version && v.Served {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/validation"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// parseRBACSubject parses a subject in the form "<kind>:[<namespace>/]<name>". The kind is one of
+// User, Group or ServiceAccount. Only service accounts are namespaced, defaulting to the given
+// namespace.
+func parseRBACSubject(subject, defaultNamespace string) (rbacv1.Subject, error) {
+	chunks := strings.SplitN(subject, ":", 2)
+	if len(chunks) != 2 || chunks[1] == "" {
+		return rbacv1.Subject{}, fmt.Errorf("invalid subject %q", subject)
+	}
+	kind, name := chunks[0], chunks[1]
+	switch strings.ToLower(kind) {
+	case strings.ToLower(rbacv1.UserKind):
+		return rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: name}, nil
+	case strings.ToLower(rbacv1.GroupKind):
+		return rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: name}, nil
+	case strings.ToLower(rbacv1.ServiceAccountKind):
+		namespace := defaultNamespace
+		if i := strings.Index(name, "/"); i != -1 {
+			namespace, name = name[:i], name[i+1:]
+		}
+		if len(validation.K8sName(namespace, cli.CurrentField)) != 0 || len(validation.K8sName(name, cli.CurrentField)) != 0 {
+			return rbacv1.Subject{}, fmt.Errorf("invalid subject %q", subject)
+		}
+		return rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: namespace, Name: name}, nil
+	}
+	return rbacv1.Subject{}, fmt.Errorf("invalid subject %q", subject)
+}
+
+// generateRBAC prints Roles granting the access checked by the doctor, and RoleBindings for the
+// subjects
+func (opts *DoctorOptions) generateRBAC(ctx context.Context, c *cli.Config) error {
+	subjects := []rbacv1.Subject{}
+	for _, s := range opts.Subjects {
+		subject, err := parseRBACSubject(s, opts.Namespace)
+		if err != nil {
+			return err
+		}
+		subjects = append(subjects, subject)
+	}
+
+	// the resources are the output of the command rather than a dry run of changes to the cluster
+	ctx = cli.WithStdout(ctx, c.Stdout)
+
+	roles := []*rbacv1.Role{}
+	roleNames := map[string]string{
+		opts.Namespace:      fmt.Sprintf("%s-user", c.Name),
+		riffSystemNamespace: fmt.Sprintf("%s-builders-reader", c.Name),
+	}
+	for _, check := range opts.accessChecks(c) {
+		var role *rbacv1.Role
+		for _, r := range roles {
+			if r.Namespace == check.Attributes.Namespace {
+				role = r
+			}
+		}
+		if role == nil {
+			role = &rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: check.Attributes.Namespace,
+					Name:      roleNames[check.Attributes.Namespace],
+				},
+			}
+			roles = append(roles, role)
+		}
+		rule := rbacv1.PolicyRule{
+			Verbs:     check.Verbs,
+			APIGroups: []string{check.Attributes.Group},
+			Resources: []string{check.Attributes.Resource},
+		}
+		if check.Attributes.Group == "core" {
+			rule.APIGroups = []string{""}
+		}
+		if check.Attributes.Subresource != "" {
+			rule.Resources = []string{fmt.Sprintf("%s/%s", check.Attributes.Resource, check.Attributes.Subresource)}
+		}
+		if check.Attributes.Name != "" {
+			rule.ResourceNames = []string{check.Attributes.Name}
+		}
+		role.Rules = append(role.Rules, rule)
+	}
+
+	for _, role := range roles {
+		cli.DryRunResource(ctx, role, rbacv1.SchemeGroupVersion.WithKind("Role"))
+	}
+	if len(subjects) == 0 {
+		return nil
+	}
+	for _, role := range roles {
+		name := role.Name
+		if role.Namespace != opts.Namespace {
+			// roles outside of the namespace are shared, bind them for each namespace
+			name = fmt.Sprintf("%s-%s", role.Name, opts.Namespace)
+		}
+		binding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: role.Namespace,
+				Name:      name,
+			},
+			Subjects: subjects,
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     role.Name,
+			},
+		}
+		cli.DryRunResource(ctx, binding, rbacv1.SchemeGroupVersion.WithKind("RoleBinding"))
+	}
+	return nil
+}
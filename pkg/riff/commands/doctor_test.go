@@ -23,6 +23,7 @@ import (
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/riff/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
+	appsv1 "k8s.io/api/apps/v1"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
@@ -45,6 +46,70 @@ func TestDoctorOptions(t *testing.T) {
 			Options:           &commands.DoctorOptions{},
 			ExpectFieldErrors: cli.ErrMissingField(cli.NamespaceFlagName),
 		},
+		{
+			Name: "health json",
+			Options: &commands.DoctorOptions{
+				Namespace: "default",
+				Health:    true,
+				Output:    "json",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "json without health",
+			Options: &commands.DoctorOptions{
+				Namespace: "default",
+				Output:    "json",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("json", cli.OutputFlagName),
+		},
+		{
+			Name: "invalid output",
+			Options: &commands.DoctorOptions{
+				Namespace: "default",
+				Health:    true,
+				Output:    "yaml",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("yaml", cli.OutputFlagName),
+		},
+		{
+			Name: "generate rbac",
+			Options: &commands.DoctorOptions{
+				Namespace:    "default",
+				GenerateRBAC: true,
+				Subjects:     []string{"User:jane", "Group:developers", "ServiceAccount:ci", "serviceaccount:build/ci"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "health and generate rbac",
+			Options: &commands.DoctorOptions{
+				Namespace:    "default",
+				Health:       true,
+				GenerateRBAC: true,
+			},
+			ExpectFieldErrors: cli.ErrMultipleOneOf(cli.HealthFlagName, cli.GenerateRBACFlagName),
+		},
+		{
+			Name: "subject without generate rbac",
+			Options: &commands.DoctorOptions{
+				Namespace: "default",
+				Subjects:  []string{"User:jane"},
+			},
+			ExpectFieldErrors: cli.ErrDisallowedFields(cli.SubjectFlagName, "requires "+cli.GenerateRBACFlagName),
+		},
+		{
+			Name: "invalid subject",
+			Options: &commands.DoctorOptions{
+				Namespace:    "default",
+				GenerateRBAC: true,
+				Subjects:     []string{"User:jane", "Robot:r2d2", "ServiceAccount:"},
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidArrayValue("Robot:r2d2", cli.SubjectFlagName, 1),
+				cli.ErrInvalidArrayValue("ServiceAccount:", cli.SubjectFlagName, 2),
+			),
+		},
 	}
 
 	table.Run(t)
@@ -82,6 +147,11 @@ pods/log                            default       *          allowed   n/a
 applications.build.projectriff.io   default       *          missing   missing
 containers.build.projectriff.io     default       *          missing   missing
 functions.build.projectriff.io      default       *          missing   missing
+
+RESOURCE                            NAMESPACE   NAME   ACCESS        IMPACT
+applications.build.projectriff.io   default     *      read, write   not installed, "riff application" commands are unavailable
+containers.build.projectriff.io     default     *      read, write   not installed, "riff container" commands are unavailable
+functions.build.projectriff.io      default     *      read, write   not installed, "riff function" commands are unavailable
 `,
 		},
 		{
@@ -414,6 +484,15 @@ pods/log                            default       *          allowed   n/a
 applications.build.projectriff.io   default       *          allowed   denied
 containers.build.projectriff.io     default       *          allowed   denied
 functions.build.projectriff.io      default       *          allowed   denied
+
+RESOURCE                            NAMESPACE   NAME   ACCESS   IMPACT
+configmaps                          default     *      write    "riff credential apply" is unable to set the default image prefix
+secrets                             default     *      write    "riff credential" is unable to create, update or delete resources
+applications.build.projectriff.io   default     *      write    "riff application" is unable to create, update or delete resources
+containers.build.projectriff.io     default     *      write    "riff container" is unable to create, update or delete resources
+functions.build.projectriff.io      default     *      write    "riff function" is unable to create, update or delete resources
+
+Roles granting the access riff needs are printed by "riff doctor --generate-rbac"
 `,
 		},
 		{
@@ -455,6 +534,18 @@ pods/log                            default       *          mixed   n/a
 applications.build.projectriff.io   default       *          mixed   allowed
 containers.build.projectriff.io     default       *          mixed   allowed
 functions.build.projectriff.io      default       *          mixed   allowed
+
+RESOURCE                            NAMESPACE     NAME       ACCESS   IMPACT
+configmaps                          riff-system   builders   read     builders for functions and applications are unresolved
+configmaps                          default       *          read     the default image prefix is unresolved for builds
+secrets                             default       *          read     "riff credential" commands are unavailable
+pods                                default       *          read     logs are unable to be tailed
+pods/log                            default       *          read     logs are unable to be tailed
+applications.build.projectriff.io   default       *          read     "riff application" commands are unavailable
+containers.build.projectriff.io     default       *          read     "riff container" commands are unavailable
+functions.build.projectriff.io      default       *          read     "riff function" commands are unavailable
+
+Roles granting the access riff needs are printed by "riff doctor --generate-rbac"
 `,
 		},
 		{
@@ -593,7 +684,262 @@ pods/log                            default       *          unknown   n/a
 applications.build.projectriff.io   default       *          unknown   unknown
 containers.build.projectriff.io     default       *          unknown   unknown
 functions.build.projectriff.io      default       *          unknown   unknown
+
+RESOURCE                            NAMESPACE     NAME       ACCESS        IMPACT
+configmaps                          riff-system   builders   read          builders for functions and applications are unresolved
+configmaps                          default       *          read, write   the default image prefix is unresolved for builds
+secrets                             default       *          read, write   "riff credential" commands are unavailable
+pods                                default       *          read          logs are unable to be tailed
+pods/log                            default       *          read          logs are unable to be tailed
+applications.build.projectriff.io   default       *          read, write   "riff application" commands are unavailable
+containers.build.projectriff.io     default       *          read, write   "riff container" commands are unavailable
+functions.build.projectriff.io      default       *          read, write   "riff function" commands are unavailable
+
+Roles granting the access riff needs are printed by "riff doctor --generate-rbac"
+`,
+		},
+	}
+
+	table.Run(t, commands.NewDoctorCommand)
+}
+
+func TestDoctorHealthCommand(t *testing.T) {
+	crd := func(name string, versions ...string) *apiextensionsv1beta1.CustomResourceDefinition {
+		crd := &apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		for _, version := range versions {
+			crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1beta1.CustomResourceDefinitionVersion{Name: version, Served: true})
+		}
+		return crd
+	}
+	controller := func(name string, replicas, available int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "riff-system", Name: name},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{AvailableReplicas: available},
+		}
+	}
+	builders := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "riff-system", Name: "builders"},
+		Data: map[string]string{
+			"riff-application": "projectriff/builder:application",
+			"riff-function":    "projectriff/builder:function",
+		},
+	}
+	riffBuild := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "riff-build"},
+		Data: map[string]string{
+			"default-image-prefix": "docker.io/projectriff",
+		},
+	}
+	credential := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "docker-hub",
+			Labels:      map[string]string{"build.projectriff.io/credential": "docker-hub"},
+			Annotations: map[string]string{"kpack.io/docker": "https://index.docker.io/v1/"},
+		},
+		Data: map[string][]byte{
+			"username": []byte("projectriff"),
+			"password": []byte("secret"),
+		},
+	}
+	healthy := []runtime.Object{
+		crd("applications.build.projectriff.io", "v1alpha1"),
+		crd("containers.build.projectriff.io", "v1alpha1"),
+		crd("functions.build.projectriff.io", "v1alpha1"),
+		crd("images.build.pivotal.io", "v1alpha1"),
+		controller("riff-build-controller-manager", 1, 1),
+		builders,
+		riffBuild,
+		credential,
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name:     "not installed",
+			Args:     []string{cli.HealthFlagName},
+			Runtimes: &[]string{},
+			ExpectOutput: `
+CHECK                                                  STATUS   MESSAGE
+crd applications.build.projectriff.io                  fail     not installed
+crd containers.build.projectriff.io                    fail     not installed
+crd functions.build.projectriff.io                     fail     not installed
+deployment riff-system/riff-build-controller-manager   fail     not installed
+dependency images.build.pivotal.io                     fail     kpack is not installed
+configmap riff-system/builders                         fail     not found
+configmap default/riff-build                           warn     not found, images must be set explicitly
+credentials default                                    warn     none found, builds are unable to push images
+`,
+			ShouldError: true,
+		},
+		{
+			Name:         "healthy",
+			Args:         []string{cli.HealthFlagName},
+			Runtimes:     &[]string{},
+			GivenObjects: healthy,
+			ExpectOutput: `
+CHECK                                                  STATUS   MESSAGE
+crd applications.build.projectriff.io                  pass     v1alpha1
+crd containers.build.projectriff.io                    pass     v1alpha1
+crd functions.build.projectriff.io                     pass     v1alpha1
+deployment riff-system/riff-build-controller-manager   pass     1/1 replicas available
+dependency images.build.pivotal.io                     pass     kpack
+configmap riff-system/builders riff-application        pass     projectriff/builder:application
+configmap riff-system/builders riff-function           pass     projectriff/builder:function
+configmap default/riff-build                           pass     docker.io/projectriff
+credential default/docker-hub                          pass     https://index.docker.io/v1/
+`,
+		},
+		{
+			Name:         "json",
+			Args:         []string{cli.HealthFlagName, cli.OutputFlagName, "json"},
+			Runtimes:     &[]string{},
+			GivenObjects: healthy,
+			ExpectOutput: `
+{
+  "healthy": true,
+  "checks": [
+    {
+      "check": "crd applications.build.projectriff.io",
+      "status": "pass",
+      "message": "v1alpha1"
+    },
+    {
+      "check": "crd containers.build.projectriff.io",
+      "status": "pass",
+      "message": "v1alpha1"
+    },
+    {
+      "check": "crd functions.build.projectriff.io",
+      "status": "pass",
+      "message": "v1alpha1"
+    },
+    {
+      "check": "deployment riff-system/riff-build-controller-manager",
+      "status": "pass",
+      "message": "1/1 replicas available"
+    },
+    {
+      "check": "dependency images.build.pivotal.io",
+      "status": "pass",
+      "message": "kpack"
+    },
+    {
+      "check": "configmap riff-system/builders riff-application",
+      "status": "pass",
+      "message": "projectriff/builder:application"
+    },
+    {
+      "check": "configmap riff-system/builders riff-function",
+      "status": "pass",
+      "message": "projectriff/builder:function"
+    },
+    {
+      "check": "configmap default/riff-build",
+      "status": "pass",
+      "message": "docker.io/projectriff"
+    },
+    {
+      "check": "credential default/docker-hub",
+      "status": "pass",
+      "message": "https://index.docker.io/v1/"
+    }
+  ]
+}
+`,
+		},
+		{
+			Name:     "degraded",
+			Args:     []string{cli.HealthFlagName},
+			Runtimes: &[]string{},
+			GivenObjects: []runtime.Object{
+				crd("applications.build.projectriff.io", "v1alpha1"),
+				crd("containers.build.projectriff.io", "v1alpha2"),
+				crd("functions.build.projectriff.io", "v1alpha1"),
+				crd("images.build.pivotal.io", "v1alpha1"),
+				controller("riff-build-controller-manager", 2, 1),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "riff-system", Name: "builders"},
+					Data: map[string]string{
+						"riff-function": "projectriff/builder:function",
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "riff-build"},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "default",
+						Name:        "my-registry",
+						Labels:      map[string]string{"build.projectriff.io/credential": "basic-auth"},
+						Annotations: map[string]string{"kpack.io/docker": "registry.example.com"},
+					},
+				},
+			},
+			ExpectOutput: `
+CHECK                                                  STATUS   MESSAGE
+crd applications.build.projectriff.io                  pass     v1alpha1
+crd containers.build.projectriff.io                    fail     version v1alpha1 is not served
+crd functions.build.projectriff.io                     pass     v1alpha1
+deployment riff-system/riff-build-controller-manager   warn     1/2 replicas available
+dependency images.build.pivotal.io                     pass     kpack
+configmap riff-system/builders riff-application        fail     builder is not defined
+configmap riff-system/builders riff-function           pass     projectriff/builder:function
+configmap default/riff-build                           warn     no default image prefix, images must be set explicitly
+credential default/my-registry                         fail     username or password is not defined
 `,
+			ShouldError: true,
+		},
+		{
+			Name:     "runtimes",
+			Args:     []string{cli.HealthFlagName},
+			Runtimes: &[]string{"core", "knative", "streaming"},
+			GivenObjects: merge(healthy, []runtime.Object{
+				crd("deployers.core.projectriff.io", "v1alpha1"),
+				controller("riff-core-controller-manager", 1, 0),
+				crd("adapters.knative.projectriff.io", "v1alpha1"),
+				crd("deployers.knative.projectriff.io", "v1alpha1"),
+				controller("riff-knative-controller-manager", 1, 1),
+				crd("services.serving.knative.dev", "v1"),
+			}),
+			ExpectOutput: `
+CHECK                                                      STATUS   MESSAGE
+crd applications.build.projectriff.io                      pass     v1alpha1
+crd containers.build.projectriff.io                        pass     v1alpha1
+crd functions.build.projectriff.io                         pass     v1alpha1
+deployment riff-system/riff-build-controller-manager       pass     1/1 replicas available
+dependency images.build.pivotal.io                         pass     kpack
+crd deployers.core.projectriff.io                          pass     v1alpha1
+deployment riff-system/riff-core-controller-manager        fail     0/1 replicas available
+crd gateways.streaming.projectriff.io                      fail     not installed
+crd inmemorygateways.streaming.projectriff.io              fail     not installed
+crd kafkagateways.streaming.projectriff.io                 fail     not installed
+crd processors.streaming.projectriff.io                    fail     not installed
+crd pulsargateways.streaming.projectriff.io                fail     not installed
+crd streams.streaming.projectriff.io                       fail     not installed
+deployment riff-system/riff-streaming-controller-manager   fail     not installed
+dependency scaledobjects.keda.k8s.io                       fail     KEDA is not installed
+crd adapters.knative.projectriff.io                        pass     v1alpha1
+crd deployers.knative.projectriff.io                       pass     v1alpha1
+deployment riff-system/riff-knative-controller-manager     pass     1/1 replicas available
+dependency configurations.serving.knative.dev              fail     Knative Serving is not installed
+dependency services.serving.knative.dev                    pass     Knative Serving
+configmap riff-system/builders riff-application            pass     projectriff/builder:application
+configmap riff-system/builders riff-function               pass     projectriff/builder:function
+configmap default/riff-build                               pass     docker.io/projectriff
+credential default/docker-hub                              pass     https://index.docker.io/v1/
+`,
+			ShouldError: true,
+		},
+		{
+			Name:         "get error",
+			Args:         []string{cli.HealthFlagName},
+			Runtimes:     &[]string{},
+			GivenObjects: healthy,
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("get", "deployments"),
+			},
+			ShouldError: true,
 		},
 	}
 
@@ -697,3 +1043,477 @@ func passAccessReview() func(action clientgotesting.Action) (handled bool, ret r
 		return true, review, nil
 	}
 }
+
+func TestDoctorGenerateRBACCommand(t *testing.T) {
+	table := rifftesting.CommandTable{
+		{
+			Name:     "roles",
+			Args:     []string{cli.GenerateRBACFlagName},
+			Runtimes: &[]string{},
+			ExpectOutput: `
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  creationTimestamp: null
+  name: riff-builders-reader
+  namespace: riff-system
+rules:
+- apiGroups:
+  - ""
+  resourceNames:
+  - builders
+  resources:
+  - configmaps
+  verbs:
+  - get
+  - list
+  - watch
+
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  creationTimestamp: null
+  name: riff-user
+  namespace: default
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - configmaps
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - secrets
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - pods/log
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - applications
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - containers
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - functions
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+
+`,
+		},
+		{
+			Name:     "role bindings",
+			Args:     []string{cli.GenerateRBACFlagName, cli.SubjectFlagName, "User:jane", cli.SubjectFlagName, "ServiceAccount:ci/builder"},
+			Runtimes: &[]string{},
+			ExpectOutput: `
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  creationTimestamp: null
+  name: riff-builders-reader
+  namespace: riff-system
+rules:
+- apiGroups:
+  - ""
+  resourceNames:
+  - builders
+  resources:
+  - configmaps
+  verbs:
+  - get
+  - list
+  - watch
+
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  creationTimestamp: null
+  name: riff-user
+  namespace: default
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - configmaps
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - secrets
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - pods/log
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - applications
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - containers
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - functions
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  creationTimestamp: null
+  name: riff-builders-reader-default
+  namespace: riff-system
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: riff-builders-reader
+subjects:
+- apiGroup: rbac.authorization.k8s.io
+  kind: User
+  name: jane
+- kind: ServiceAccount
+  name: builder
+  namespace: ci
+
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  creationTimestamp: null
+  name: riff-user
+  namespace: default
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: riff-user
+subjects:
+- apiGroup: rbac.authorization.k8s.io
+  kind: User
+  name: jane
+- kind: ServiceAccount
+  name: builder
+  namespace: ci
+
+`,
+		},
+		{
+			Name:     "runtimes",
+			Args:     []string{cli.GenerateRBACFlagName, cli.NamespaceFlagName, "my-namespace"},
+			Runtimes: &[]string{"core", "streaming", "knative"},
+			ExpectOutput: `
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  creationTimestamp: null
+  name: riff-builders-reader
+  namespace: riff-system
+rules:
+- apiGroups:
+  - ""
+  resourceNames:
+  - builders
+  resources:
+  - configmaps
+  verbs:
+  - get
+  - list
+  - watch
+
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  creationTimestamp: null
+  name: riff-user
+  namespace: my-namespace
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - configmaps
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - secrets
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - pods/log
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - applications
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - containers
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - build.projectriff.io
+  resources:
+  - functions
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - core.projectriff.io
+  resources:
+  - deployers
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - streaming.projectriff.io
+  resources:
+  - processors
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - streaming.projectriff.io
+  resources:
+  - streams
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - streaming.projectriff.io
+  resources:
+  - inmemorygateways
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - streaming.projectriff.io
+  resources:
+  - kafkagateways
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - streaming.projectriff.io
+  resources:
+  - pulsargateways
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - knative.projectriff.io
+  resources:
+  - adapters
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+- apiGroups:
+  - knative.projectriff.io
+  resources:
+  - deployers
+  verbs:
+  - get
+  - list
+  - create
+  - update
+  - delete
+  - patch
+  - watch
+
+`,
+		},
+	}
+
+	table.Run(t, commands.NewDoctorCommand)
+}
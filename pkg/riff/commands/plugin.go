@@ -0,0 +1,234 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func NewPluginCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "commands provided by executables on the PATH",
+		Long: strings.TrimSpace(`
+Plugins extend ` + c.Name + ` with commands that are not built in. Any executable on the
+PATH named ` + c.Name + `-<name> is invoked as "` + c.Name + ` <name>", receiving all remaining
+arguments unchanged. Global flags before the plugin name are applied to the
+settings below and are not passed to the plugin.
+
+Plugins receive the resolved settings through environment variables:
+  ` + pluginEnvName(c, "NAMESPACE") + `        namespace to use, if resolved
+  ` + pluginEnvName(c, "CONTEXT") + `          kubectl config context to use, if set
+  ` + pluginEnvName(c, "CONFIG") + `           path of the ` + c.Name + ` config file
+  ` + pluginEnvName(c, "PROJECT_CONFIG") + `   path of the project config file, if found
+  KUBECONFIG            path of the kubectl config file, if set
+
+Built-in commands always win over a plugin with the same name. When several
+executables share a name, the first one on the PATH is used.
+`),
+	}
+
+	cmd.AddCommand(NewPluginListCommand(ctx, c))
+
+	return cmd
+}
+
+// AddPluginCommand adds a command to the root command that invokes the plugin named by the first
+// argument after any global flags, when the argument is not a built-in command. The global flags
+// are applied to the config, all arguments after the plugin name are passed to the plugin.
+func AddPluginCommand(ctx context.Context, c *cli.Config, root *cobra.Command, args []string) {
+	flags, name, args := splitPluginArgs(root.PersistentFlags(), args)
+	if name == "" || isBuiltinCommand(root, name) {
+		return
+	}
+	for _, plugin := range findPlugins(c) {
+		if plugin.Name != name {
+			continue
+		}
+		path := plugin.Path
+		// flags are not parsed for the plugin command, the global flags are parsed before the
+		// config is initialized
+		err := root.PersistentFlags().Parse(flags)
+		root.AddCommand(&cobra.Command{
+			Use:                name,
+			Short:              fmt.Sprintf("plugin provided by %s", path),
+			Hidden:             true,
+			DisableFlagParsing: true,
+			SilenceUsage:       true,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				if err != nil {
+					return err
+				}
+				return execPlugin(ctx, c, path, args)
+			},
+		})
+		return
+	}
+}
+
+// splitPluginArgs splits the arguments into the leading global flags, the plugin name and the
+// arguments for the plugin. The name is empty when a flag that is not global comes first.
+func splitPluginArgs(global *pflag.FlagSet, args []string) ([]string, string, []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			return args[:i], arg, args[i+1:]
+		}
+		if !strings.HasPrefix(arg, "--") || arg == "--" {
+			return nil, "", nil
+		}
+		flag := global.Lookup(strings.SplitN(arg[2:], "=", 2)[0])
+		if flag == nil {
+			return nil, "", nil
+		}
+		if !strings.Contains(arg, "=") && flag.NoOptDefVal == "" {
+			// the value is the next argument
+			i++
+		}
+	}
+	return nil, "", nil
+}
+
+func execPlugin(ctx context.Context, c *cli.Config, path string, args []string) error {
+	cmd := c.Exec(ctx, path, args...)
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	env, err := pluginEnv(c)
+	if err != nil {
+		return err
+	}
+	cmd.Env = append(cmd.Env, env...)
+	cmd.Stdin = c.Stdin
+	cmd.Stdout = c.Stdout
+	cmd.Stderr = c.Stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			// the plugin is responsible for reporting its own errors
+			exitCode := exitErr.ExitCode()
+			if exitCode < 0 {
+				// the plugin was terminated by a signal
+				exitCode = cli.ExitCodeError
+			}
+			return cli.SilenceError(cli.WithExitCode(err, exitCode))
+		}
+		return err
+	}
+	return nil
+}
+
+// pluginEnv resolves the environment variables passed to plugins
+func pluginEnv(c *cli.Config) ([]string, error) {
+	configFile, err := c.UserConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	env := []string{
+		fmt.Sprintf("%s=%s", pluginEnvName(c, "CONFIG"), configFile),
+	}
+	// plugins may not need a cluster, the namespace is omitted without a usable kubectl config
+	if namespace, _, err := c.KubeConfig().Namespace(); err == nil {
+		env = append(env, fmt.Sprintf("%s=%s", pluginEnvName(c, "NAMESPACE"), namespace))
+	}
+	if context := c.KubeConfigOverrides.CurrentContext; context != "" {
+		env = append(env, fmt.Sprintf("%s=%s", pluginEnvName(c, "CONTEXT"), context))
+	}
+	if c.ProjectConfigFile != "" {
+		env = append(env, fmt.Sprintf("%s=%s", pluginEnvName(c, "PROJECT_CONFIG"), c.ProjectConfigFile))
+	}
+	if c.KubeConfigFile != "" {
+		env = append(env, fmt.Sprintf("KUBECONFIG=%s", c.KubeConfigFile))
+	}
+	return env, nil
+}
+
+func pluginEnvName(c *cli.Config, name string) string {
+	return fmt.Sprintf("%s_%s", strings.ToUpper(c.Name), name)
+}
+
+// isBuiltinCommand returns true if the name or alias of a root sub-command matches
+func isBuiltinCommand(root *cobra.Command, name string) bool {
+	switch name {
+	case "help", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+type plugin struct {
+	Name string
+	Path string
+}
+
+// findPlugins lists the executables on the PATH named for the cli, in PATH order
+func findPlugins(c *cli.Config) []plugin {
+	prefix := c.Name + "-"
+	plugins := []plugin{}
+	seen := map[string]bool{}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			name := file.Name()
+			if !strings.HasPrefix(name, prefix) || file.IsDir() || !isExecutable(file) {
+				continue
+			}
+			name = strings.TrimPrefix(name, prefix)
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			if name == "" {
+				continue
+			}
+			plugins = append(plugins, plugin{Name: name, Path: filepath.Join(dir, file.Name())})
+		}
+	}
+	return plugins
+}
+
+func isExecutable(file os.FileInfo) bool {
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(file.Name()))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd"
+	}
+	return file.Mode()&0111 != 0
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+type PluginListOptions struct {
+	// root is the command built-in commands are resolved against
+	root *cobra.Command
+}
+
+var (
+	_ cli.Validatable = (*PluginListOptions)(nil)
+	_ cli.Executable  = (*PluginListOptions)(nil)
+)
+
+func (opts *PluginListOptions) Validate(ctx context.Context) cli.FieldErrors {
+	return cli.FieldErrors{}
+}
+
+func (opts *PluginListOptions) Exec(ctx context.Context, c *cli.Config) error {
+	plugins := findPlugins(c)
+	if len(plugins) == 0 {
+		c.Infof("No plugins found.\n")
+		return nil
+	}
+
+	warnings := []string{}
+	found := map[string]string{}
+	w := printers.GetNewTabWriter(c.Stdout)
+	fmt.Fprintf(w, "NAME\tPATH\n")
+	for _, plugin := range plugins {
+		fmt.Fprintf(w, "%s\t%s\n", plugin.Name, plugin.Path)
+		if opts.root != nil && isBuiltinCommand(opts.root, plugin.Name) {
+			warnings = append(warnings, fmt.Sprintf("%s is overshadowed by the built-in %q command", plugin.Path, plugin.Name))
+		} else if path, ok := found[plugin.Name]; ok {
+			warnings = append(warnings, fmt.Sprintf("%s is overshadowed by %s", plugin.Path, path))
+		} else {
+			found[plugin.Name] = plugin.Path
+		}
+	}
+	w.Flush()
+
+	if len(warnings) != 0 {
+		c.Printf("\n")
+		for _, warning := range warnings {
+			c.Errorf("Warning: %s\n", warning)
+		}
+	}
+
+	return nil
+}
+
+func NewPluginListCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &PluginListOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "table listing of plugins",
+		Long: strings.TrimSpace(`
+List the plugins found on the PATH. Plugins that will never be invoked, because
+a built-in command or an earlier plugin on the PATH has the same name, are
+reported with a warning.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s plugin list", c.Name),
+		}, "\n"),
+		PreRunE: cli.Sequence(
+			func(cmd *cobra.Command, args []string) error {
+				opts.root = cmd.Root()
+				return nil
+			},
+			cli.ValidateOptions(ctx, opts),
+		),
+		RunE: cli.ExecOptions(ctx, c, opts),
+	}
+
+	return cmd
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+)
+
+func TestPluginListOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name:           "valid",
+			Options:        &commands.PluginListOptions{},
+			ShouldValidate: true,
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestPluginListCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "riff-plugin-list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	bin := filepath.Join(dir, "bin")
+	local := filepath.Join(dir, "local")
+	empty := filepath.Join(dir, "empty")
+	for _, d := range []string{bin, local, empty} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := givenPlugins(bin, "riff-hello", "riff-doctor", "other"); err != nil {
+		t.Fatal(err)
+	}
+	if err := givenPlugins(local, "riff-hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(local, "riff-notexecutable"), []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := os.Getenv("PATH")
+	givenPath := func(dirs ...string) func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+		return func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+			return ctx, os.Setenv("PATH", filepath.Join(dirs...))
+		}
+	}
+	cleanUp := func(t *testing.T, ctx context.Context, c *cli.Config) error {
+		return os.Setenv("PATH", path)
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name:    "plugins",
+			Args:    []string{"plugin", "list"},
+			Prepare: givenPath(bin + string(os.PathListSeparator) + local),
+			CleanUp: cleanUp,
+			ExpectOutput: `
+NAME     PATH
+doctor   ` + bin + `/riff-doctor
+hello    ` + bin + `/riff-hello
+hello    ` + local + `/riff-hello
+
+Warning: ` + bin + `/riff-doctor is overshadowed by the built-in "doctor" command
+Warning: ` + local + `/riff-hello is overshadowed by ` + bin + `/riff-hello
+`,
+		},
+		{
+			Name:    "no plugins",
+			Args:    []string{"plugin", "list"},
+			Prepare: givenPath(empty),
+			CleanUp: cleanUp,
+			ExpectOutput: `
+No plugins found.
+`,
+		},
+	}
+
+	table.Run(t, commands.NewRootCommand)
+}
@@ -0,0 +1,238 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestPluginCommand(t *testing.T) {
+	table := rifftesting.CommandTable{
+		{
+			Name: "empty",
+			Args: []string{},
+		},
+	}
+
+	table.Run(t, commands.NewPluginCommand)
+}
+
+func TestAddPluginCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "riff-plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := givenPlugins(dir, "riff-hello", "riff-doctor"); err != nil {
+		t.Fatal(err)
+	}
+
+	rootWithPlugin := func(args ...string) func(ctx context.Context, c *cli.Config) *cobra.Command {
+		return func(ctx context.Context, c *cli.Config) *cobra.Command {
+			cmd := commands.NewRootCommand(ctx, c)
+			// flags are not parsed for plugins, set after the root flags are defined
+			c.ViperConfigFile = "/home/me/.riff.yaml"
+			c.KubeConfigFile = "/home/me/.kube/config"
+			c.KubeConfigOverrides.CurrentContext = "my-context"
+			commands.AddPluginCommand(ctx, c, cmd, args)
+			return cmd
+		}
+	}
+	prepare := func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+		c.Client.(*rifftesting.FakeClient).FakeKubeConfig = givenKubeConfig()
+		return ctx, os.Setenv("PATH", dir)
+	}
+	path := os.Getenv("PATH")
+	noColor := color.NoColor
+	cleanUp := func(t *testing.T, ctx context.Context, c *cli.Config) error {
+		return os.Setenv("PATH", path)
+	}
+
+	rifftesting.CommandTableRecord{
+		Name:       "invoke plugin",
+		Args:       []string{"hello", "world", "--flag", "value"},
+		ExecHelper: "Plugin",
+		Prepare:    prepare,
+		CleanUp:    cleanUp,
+		ExpectOutput: `
+plugin: riff-hello
+args: world --flag value
+RIFF_NAMESPACE=my-namespace
+RIFF_CONTEXT=my-context
+RIFF_CONFIG=/home/me/.riff.yaml
+KUBECONFIG=/home/me/.kube/config
+`,
+	}.Run(t, rootWithPlugin("hello", "world", "--flag", "value"))
+
+	rifftesting.CommandTableRecord{
+		Name:       "global flags before plugin",
+		Args:       []string{"--context", "other-context", "--kubeconfig=/home/you/.kube/config", "--no-color", "hello", "world", "--context", "value"},
+		ExecHelper: "Plugin",
+		Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+			// resolve the kubectl config with the overrides from the flags
+			kubeConfig, err := givenKubeConfig().RawConfig()
+			if err != nil {
+				return ctx, err
+			}
+			c.Client.(*rifftesting.FakeClient).FakeKubeConfig = clientcmd.NewDefaultClientConfig(kubeConfig, &c.KubeConfigOverrides)
+			return ctx, os.Setenv("PATH", dir)
+		},
+		CleanUp: func(t *testing.T, ctx context.Context, c *cli.Config) error {
+			color.NoColor = noColor
+			return cleanUp(t, ctx, c)
+		},
+		ExpectOutput: `
+plugin: riff-hello
+args: world --context value
+RIFF_NAMESPACE=default
+RIFF_CONTEXT=other-context
+RIFF_CONFIG=/home/me/.riff.yaml
+KUBECONFIG=/home/you/.kube/config
+`,
+	}.Run(t, rootWithPlugin("--context", "other-context", "--kubeconfig=/home/you/.kube/config", "--no-color", "hello", "world", "--context", "value"))
+
+	rifftesting.CommandTableRecord{
+		Name:        "unknown flag before plugin",
+		Args:        []string{"--unknown", "hello"},
+		ExecHelper:  "Plugin",
+		Prepare:     prepare,
+		CleanUp:     cleanUp,
+		ShouldError: true,
+		Verify: func(t *testing.T, output string, err error) {
+			if strings.Contains(output, "plugin:") {
+				t.Errorf("Expected unknown flag error, actually invoked plugin: %s", output)
+			}
+		},
+	}.Run(t, rootWithPlugin("--unknown", "hello"))
+
+	rifftesting.CommandTableRecord{
+		Name:        "plugin failed",
+		Args:        []string{"hello", "fail"},
+		ExecHelper:  "Plugin",
+		Prepare:     prepare,
+		CleanUp:     cleanUp,
+		ShouldError: true,
+		Verify: func(t *testing.T, output string, err error) {
+			if expected, actual := 3, cli.ExitCode(err); expected != actual {
+				t.Errorf("Expected exit code %d, actually %d", expected, actual)
+			}
+		},
+		ExpectOutput: `
+plugin: riff-hello
+args: fail
+RIFF_NAMESPACE=my-namespace
+RIFF_CONTEXT=my-context
+RIFF_CONFIG=/home/me/.riff.yaml
+KUBECONFIG=/home/me/.kube/config
+`,
+	}.Run(t, rootWithPlugin("hello", "fail"))
+
+	rifftesting.CommandTableRecord{
+		Name:        "plugin killed",
+		Args:        []string{"hello", "kill"},
+		ExecHelper:  "Plugin",
+		Prepare:     prepare,
+		CleanUp:     cleanUp,
+		ShouldError: true,
+		Verify: func(t *testing.T, output string, err error) {
+			if expected, actual := cli.ExitCodeError, cli.ExitCode(err); expected != actual {
+				t.Errorf("Expected exit code %d, actually %d", expected, actual)
+			}
+		},
+		ExpectOutput: `
+plugin: riff-hello
+args: kill
+RIFF_NAMESPACE=my-namespace
+RIFF_CONTEXT=my-context
+RIFF_CONFIG=/home/me/.riff.yaml
+KUBECONFIG=/home/me/.kube/config
+`,
+	}.Run(t, rootWithPlugin("hello", "kill"))
+
+	rifftesting.CommandTableRecord{
+		Name:        "unknown command",
+		Args:        []string{"goodbye"},
+		ExecHelper:  "Plugin",
+		Prepare:     prepare,
+		CleanUp:     cleanUp,
+		ShouldError: true,
+	}.Run(t, rootWithPlugin("goodbye"))
+
+	rifftesting.CommandTableRecord{
+		Name:       "built-in command wins",
+		Args:       []string{"doctor", "--help"},
+		ExecHelper: "Plugin",
+		Prepare:    prepare,
+		CleanUp:    cleanUp,
+		Verify: func(t *testing.T, output string, err error) {
+			if strings.Contains(output, "plugin:") {
+				t.Errorf("Expected built-in command, actually invoked plugin: %s", output)
+			}
+		},
+	}.Run(t, rootWithPlugin("doctor", "--help"))
+}
+
+func TestHelperProcess_Plugin(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	// drop the separator and the plugin path
+	fmt.Printf("plugin: %s\n", filepath.Base(args[1]))
+	args = args[2:]
+	fmt.Printf("args: %s\n", strings.Join(args, " "))
+	for _, name := range []string{"RIFF_NAMESPACE", "RIFF_CONTEXT", "RIFF_CONFIG", "RIFF_PROJECT_CONFIG", "KUBECONFIG"} {
+		if value, ok := os.LookupEnv(name); ok {
+			fmt.Printf("%s=%s\n", name, value)
+		}
+	}
+	if len(args) != 0 && args[0] == "fail" {
+		os.Exit(3)
+	}
+	if len(args) != 0 && args[0] == "kill" {
+		if p, err := os.FindProcess(os.Getpid()); err == nil {
+			p.Kill()
+		}
+	}
+	os.Exit(0)
+}
+
+// givenPlugins creates executables in the directory
+func givenPlugins(dir string, names ...string) error {
+	for _, name := range names {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	bindingcommands "github.com/projectriff/cli/pkg/binding/commands"
@@ -41,8 +42,20 @@ See https://projectriff.io/docs/getting-started/
 The application, function and container commands define build plans and the
 credential commands to authenticate builds to container registries.
 
+Commands exit with status 0 on success, otherwise with the status for the
+category of the error:
+
+` + exitCodes() + `
+
+Errors are printed as text, or with "` + cli.ErrorFormatFlagName + ` json" as a JSON document on
+stderr with the error code, message, invalid fields and Kubernetes API status
+reason.
+
 Runtimes provide ways to execute the workloads. Different runtimes provide
-alternate execution models and capabilities.
+alternate execution models and capabilities. The runtimes enabled are set with
+"` + c.Name + ` config set runtimes", either listed or detected from the cluster with
+"auto". Runtimes are only detected by commands that use the cluster, falling
+back to the compiled runtimes when the cluster is unreachable.
 `),
 	}
 
@@ -55,3 +68,11 @@ alternate execution models and capabilities.
 
 	return cmd
 }
+
+func exitCodes() string {
+	lines := []string{}
+	for _, category := range cli.ErrorCategories {
+		lines = append(lines, fmt.Sprintf("  %d  %-16s %s", category.ExitCode, category.Code, category.Description))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -27,6 +27,7 @@ import (
 	knativecommands "github.com/projectriff/cli/pkg/knative/commands"
 	streamingcommands "github.com/projectriff/cli/pkg/streaming/commands"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 // NewRootCommand wraps the riff command with flags and commands that should only be defined on
@@ -45,13 +46,44 @@ func NewRootCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	}
 	cmd.Flags().Bool("version", false, "display CLI version")
 
+	// validate the kube config overrides before a client is created
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if timeout := c.KubeConfigOverrides.Timeout; timeout != "" {
+			if _, err := clientcmd.ParseTimeout(timeout); err != nil {
+				return cli.ErrInvalidValue(timeout, cli.RequestTimeoutFlagName).ToAggregate()
+			}
+		}
+		if err := cli.ApplyConfigDefaults(cmd); err != nil {
+			return err
+		}
+		if c.ErrorFormat != cli.TextErrorFormat && c.ErrorFormat != cli.JSONErrorFormat {
+			return cli.ErrInvalidValue(c.ErrorFormat, cli.ErrorFormatFlagName).ToAggregate()
+		}
+		return nil
+	}
+	// flag parsing errors are usage errors
+	cmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return cli.WithExitCode(err, cli.ExitCodeInvalid)
+	})
+
 	// add root persistent flags
 	cmd.PersistentFlags().StringVar(&c.ViperConfigFile, cli.StripDash(cli.ConfigFlagName), "", fmt.Sprintf("config `file` (default is $HOME/.%s.yaml)", c.Name))
 	_ = cmd.MarkFlagFilename(cli.StripDash(cli.ConfigFlagName), "yaml", "yml")
 	cmd.PersistentFlags().StringVar(&c.KubeConfigFile, cli.StripDash(cli.KubeConfigFlagName), "", "kubectl config `file` (default is $HOME/.kube/config)")
 	cmd.PersistentFlags().StringVar(&c.KubeConfigFile, cli.StripDash(cli.KubeConfigFlagNameDeprecated), "", "kubectl config `file` (default is $HOME/.kube/config)")
 	cmd.PersistentFlags().MarkDeprecated(cli.StripDash(cli.KubeConfigFlagNameDeprecated), fmt.Sprintf("renamed to %s", cli.KubeConfigFlagName))
+	cmd.PersistentFlags().StringVar(&c.KubeConfigOverrides.CurrentContext, cli.StripDash(cli.ContextFlagName), "", fmt.Sprintf("kubectl config `context` to use (default is the context set by \"%s context use\" or the current context)", c.Name))
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.ContextFlagName), completeKubeContexts(c))
+	cmd.PersistentFlags().StringVar(&c.KubeConfigOverrides.Context.Cluster, cli.StripDash(cli.ClusterFlagName), "", "kubectl config `cluster` to use")
+	cmd.PersistentFlags().StringVar(&c.KubeConfigOverrides.Context.AuthInfo, cli.StripDash(cli.UserFlagName), "", "kubectl config `user` to use")
+	cmd.PersistentFlags().StringVar(&c.KubeConfigOverrides.ClusterInfo.Server, cli.StripDash(cli.ServerFlagName), "", "`address` and port of the Kubernetes API server")
+	cmd.PersistentFlags().StringVar(&c.KubeConfigOverrides.AuthInfo.Token, cli.StripDash(cli.TokenFlagName), "", "bearer `token` for authentication to the API server")
+	cmd.PersistentFlags().StringVar(&c.KubeConfigOverrides.AuthInfo.Impersonate, cli.StripDash(cli.AsFlagName), "", "`username` to impersonate for the operation")
+	cmd.PersistentFlags().StringArrayVar(&c.KubeConfigOverrides.AuthInfo.ImpersonateGroups, cli.StripDash(cli.AsGroupFlagName), []string{}, "`group` to impersonate for the operation, may be set multiple times")
+	cmd.PersistentFlags().StringVar(&c.KubeConfigOverrides.Timeout, cli.StripDash(cli.RequestTimeoutFlagName), "0", "`duration` to wait for a single request to the API server, zero means no timeout (e.g. 1s, 2m)")
 	cmd.PersistentFlags().BoolVar(&color.NoColor, cli.StripDash(cli.NoColorFlagName), color.NoColor, "disable color output in terminals")
+	cmd.PersistentFlags().StringVar(&c.ErrorFormat, cli.StripDash(cli.ErrorFormatFlagName), cli.TextErrorFormat, fmt.Sprintf("`format` of errors for failed commands, one of %q or %q", cli.TextErrorFormat, cli.JSONErrorFormat))
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.ErrorFormatFlagName), cli.CompleteValues(cli.TextErrorFormat, cli.JSONErrorFormat))
 
 	// add runtimes
 	runtimes := []struct {
@@ -79,19 +111,58 @@ The Knative runtime uses Knative Serving to expose the workload over HTTP with
 zero-to-n autoscaling and managed ingress.
 `),
 	}}
-	for _, runtime := range runtimes {
-		if c.Runtimes[runtime.name] {
-			cmd.Long = cmd.Long + "\n\n" + runtime.doc
-		} else {
-			runtime.command.Hidden = true
+	long := cmd.Long
+	// the enabled runtimes may change once the config file is read
+	enableRuntimes := func() {
+		cmd.Long = long
+		for _, runtime := range runtimes {
+			runtime.command.Hidden = !c.Runtimes[runtime.name]
+			if !runtime.command.Hidden {
+				cmd.Long = cmd.Long + "\n\n" + runtime.doc
+			}
+		}
+	}
+	isRuntimeCommand := func(cmd *cobra.Command) bool {
+		for _, runtime := range runtimes {
+			for parent := cmd; parent != nil; parent = parent.Parent() {
+				if parent == runtime.command {
+					return true
+				}
+			}
 		}
+		return false
+	}
+	for _, runtime := range runtimes {
+		explainMissingRuntime(c, runtime.command, runtime.name)
 		cmd.AddCommand(runtime.command)
 	}
+	enableRuntimes()
+	cmd.PersistentPreRunE = cli.Sequence(
+		func(cmd *cobra.Command, args []string) error {
+			// only commands that talk to the cluster detect the runtimes it serves
+			if isRuntimeCommand(cmd) {
+				c.LoadRuntimes()
+			}
+			enableRuntimes()
+			return nil
+		},
+		cmd.PersistentPreRunE,
+	)
+	help := cmd.HelpFunc()
+	cmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		c.EnsureInitialized()
+		enableRuntimes()
+		help(cmd, args)
+	})
 
 	// add root-only commands
 	cmd.AddCommand(NewCompletionCommand(ctx, c))
+	cmd.AddCommand(NewConfigCommand(ctx, c))
+	cmd.AddCommand(NewContextCommand(ctx, c))
 	cmd.AddCommand(NewDocsCommand(ctx, c))
 	cmd.AddCommand(NewDoctorCommand(ctx, c))
+	cmd.AddCommand(NewPluginCommand(ctx, c))
+	cmd.AddCommand(NewWaitCommand(ctx, c))
 
 	// override usage template to add arguments
 	cmd.SetUsageTemplate(strings.ReplaceAll(cmd.UsageTemplate(), "{{.UseLine}}", "{{useLine .}}"))
@@ -107,3 +178,16 @@ zero-to-n autoscaling and managed ingress.
 
 	return cmd
 }
+
+// explainMissingRuntime wraps the runtime's commands to explain not found errors caused by the
+// runtime missing from the cluster
+func explainMissingRuntime(c *cli.Config, cmd *cobra.Command, runtime string) {
+	if runE := cmd.RunE; runE != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			return c.ExplainMissingRuntime(runE(cmd, args), runtime)
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		explainMissingRuntime(c, sub, runtime)
+	}
+}
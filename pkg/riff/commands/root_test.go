@@ -17,11 +17,19 @@
 package commands_test
 
 import (
+	"context"
 	"strings"
 	"testing"
 
+	"github.com/projectriff/cli/pkg/cli"
 	"github.com/projectriff/cli/pkg/riff/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clientgotesting "k8s.io/client-go/testing"
 )
 
 func TestRootCommand(t *testing.T) {
@@ -48,7 +56,105 @@ func TestRootCommand(t *testing.T) {
 				}
 			},
 		},
+		{
+			Name:        "invalid request timeout",
+			Args:        []string{"function", "list", cli.RequestTimeoutFlagName, "soon"},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected, actual := cli.ErrInvalidValue("soon", cli.RequestTimeoutFlagName).ToAggregate().Error(), err.Error(); expected != actual {
+					t.Errorf("expected error %q, actually %q", expected, actual)
+				}
+			},
+		},
+		{
+			Name:        "invalid error format",
+			Args:        []string{"function", "list", cli.ErrorFormatFlagName, "xml"},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected, actual := cli.ErrInvalidValue("xml", cli.ErrorFormatFlagName).ToAggregate().Error(), err.Error(); expected != actual {
+					t.Errorf("expected error %q, actually %q", expected, actual)
+				}
+				if expected, actual := cli.ExitCodeInvalid, cli.ExitCode(err); expected != actual {
+					t.Errorf("expected exit code %d, actually %d", expected, actual)
+				}
+			},
+		},
+		{
+			Name:        "unknown flag",
+			Args:        []string{"function", "list", "--unknown"},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected, actual := cli.ExitCodeInvalid, cli.ExitCode(err); expected != actual {
+					t.Errorf("expected exit code %d, actually %d", expected, actual)
+				}
+			},
+		},
+		{
+			Name:        "missing argument",
+			Args:        []string{"function", "delete"},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected, actual := cli.ExitCodeInvalid, cli.ExitCode(err); expected != actual {
+					t.Errorf("expected exit code %d, actually %d", expected, actual)
+				}
+			},
+		},
+		{
+			Name:     "help for enabled runtimes",
+			Args:     []string{"--help"},
+			Runtimes: &[]string{cli.KnativeRuntime},
+			Verify: func(t *testing.T, output string, err error) {
+				if !strings.Contains(output, "The Knative runtime") {
+					t.Errorf("expected help to describe the knative runtime")
+				}
+				if strings.Contains(output, "The core runtime") {
+					t.Errorf("expected help to not describe the core runtime")
+				}
+			},
+		},
+		{
+			Name:         "runtime not installed",
+			Args:         []string{"core", "deployer", "list"},
+			Runtimes:     &[]string{cli.CoreRuntime},
+			WithReactors: []rifftesting.ReactionFunc{missingResource("list", "core.projectriff.io", "deployers")},
+			ShouldError:  true,
+			Verify: func(t *testing.T, output string, err error) {
+				if expected, actual := `the core runtime is not installed in the cluster, the "core.projectriff.io" API group was not found`, err.Error(); !strings.HasPrefix(actual, expected) {
+					t.Errorf("expected error to start with %q, actually %q", expected, actual)
+				}
+				if expected, actual := cli.ExitCodeNotFound, cli.ExitCode(err); expected != actual {
+					t.Errorf("expected exit code %d, actually %d", expected, actual)
+				}
+			},
+		},
+		{
+			Name:     "runtime installed",
+			Args:     []string{"core", "deployer", "list"},
+			Runtimes: &[]string{cli.CoreRuntime},
+			Prepare: func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+				discovery := c.Client.Discovery().(*fakediscovery.FakeDiscovery)
+				discovery.Resources = []*metav1.APIResourceList{{GroupVersion: "core.projectriff.io/v1alpha1"}}
+				return ctx, nil
+			},
+			WithReactors: []rifftesting.ReactionFunc{missingResource("list", "core.projectriff.io", "deployers")},
+			ShouldError:  true,
+			Verify: func(t *testing.T, output string, err error) {
+				if !apierrs.IsNotFound(err) {
+					t.Errorf("expected not found error, actually %q", err)
+				}
+			},
+		},
 	}
 
 	table.Run(t, commands.NewRootCommand)
 }
+
+// missingResource responds as the API server does for a resource that is not served
+func missingResource(verb, group, resource string) rifftesting.ReactionFunc {
+	return func(action clientgotesting.Action) (handled bool, ret runtime.Object, err error) {
+		if !action.Matches(verb, resource) {
+			return false, nil, nil
+		}
+		return true, nil, apierrs.NewGenericServerResponse(404, verb, schema.GroupResource{Group: group, Resource: resource}, "", "", 0, false)
+	}
+}
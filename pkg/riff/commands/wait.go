@@ -0,0 +1,420 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/validation"
+	"github.com/spf13/cobra"
+	"github.com/vmware-labs/reconciler-runtime/apis"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	waitForDelete          = "delete"
+	waitForConditionPrefix = "condition="
+)
+
+type WaitOptions struct {
+	Namespace string
+	Resources []string
+	For       string
+	Timeout   string
+}
+
+var (
+	_ cli.Validatable = (*WaitOptions)(nil)
+	_ cli.Executable  = (*WaitOptions)(nil)
+)
+
+func (opts *WaitOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if opts.Namespace == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.NamespaceFlagName))
+	}
+
+	if len(opts.Resources) == 0 {
+		errs = errs.Also(cli.ErrMissingField(cli.ResourcesArgumentName))
+	}
+	for i, resource := range opts.Resources {
+		kind, name, err := parseWaitResource(resource)
+		if err != nil {
+			errs = errs.Also(cli.ErrInvalidArrayValue(resource, cli.ResourcesArgumentName, i))
+			continue
+		}
+		if _, err := resolveWaitKind(kind); err != nil {
+			errs = errs.Also(cli.ErrInvalidArrayValue(resource, cli.ResourcesArgumentName, i))
+			continue
+		}
+		errs = errs.Also(validation.K8sName(name, cli.CurrentField).ViaFieldIndex(cli.ResourcesArgumentName, i))
+	}
+
+	if _, _, err := parseWaitFor(opts.For); err != nil {
+		errs = errs.Also(cli.ErrInvalidValue(opts.For, cli.ForFlagName))
+	}
+
+	if opts.Timeout == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.TimeoutFlagName))
+	} else if _, err := time.ParseDuration(opts.Timeout); err != nil {
+		errs = errs.Also(cli.ErrInvalidValue(opts.Timeout, cli.TimeoutFlagName))
+	}
+
+	return errs
+}
+
+func (opts *WaitOptions) Exec(ctx context.Context, c *cli.Config) error {
+	// errors guarded by Validate()
+	conditionType, status, _ := parseWaitFor(opts.For)
+	timeout, _ := time.ParseDuration(opts.Timeout)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	kinds := make([]*waitKind, len(opts.Resources))
+	names := make([]string, len(opts.Resources))
+	for i, resource := range opts.Resources {
+		// errors guarded by Validate()
+		kind, name, _ := parseWaitResource(resource)
+		kinds[i], _ = resolveWaitKind(kind)
+		names[i] = name
+		// clients are loaded lazily, load them before waiting concurrently
+		kinds[i].Client(c)
+	}
+
+	results := make([]error, len(opts.Resources))
+	var wg sync.WaitGroup
+	for i := range opts.Resources {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = kinds[i].wait(ctx, c, opts.Namespace, names[i], conditionType, status)
+		}(i)
+	}
+	wg.Wait()
+
+	exitCode := 0
+	for i, err := range results {
+		resource := opts.Resources[i]
+		switch {
+		case err == nil:
+			if opts.For == waitForDelete {
+				c.Successf("%s deleted\n", resource)
+			} else {
+				c.Successf("%s condition met\n", resource)
+			}
+		case errors.Is(err, k8s.ErrWaitTimeout):
+			c.Errorf("Timeout after %q waiting for %s\n", opts.Timeout, resource)
+			if exitCode == 0 {
+				exitCode = cli.ExitCodeTimeout
+			}
+		case errors.Is(err, k8s.ErrConditionFailed):
+			c.Errorf("Condition failed for %s: %s\n", resource, err)
+			exitCode = cli.ExitCodeConditionFailed
+		default:
+			c.Errorf("Error waiting for %s: %s\n", resource, err)
+			if exitCode == 0 || exitCode == cli.ExitCodeTimeout {
+				exitCode = cli.ExitCode(err)
+			}
+		}
+	}
+	if exitCode != 0 {
+		return cli.WithExitCode(cli.SilenceError(fmt.Errorf("wait failed")), exitCode)
+	}
+
+	return nil
+}
+
+func NewWaitCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &WaitOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "wait for resources to reach a condition",
+		Long: strings.TrimSpace(`
+Wait for one or more ` + c.Name + ` resources to reach a condition.
+
+Resources are referenced as <kind>/<name>. The kind may be qualified by its API
+group when the name alone is ambiguous, for example "deployer.core" or
+"deployer.knative.projectriff.io". Ambiguous kinds resolve to the runtime set
+with "` + c.Name + ` config set runtime", if any. All resources are watched in parallel.
+
+By default, each resource must become ready. An arbitrary status condition is
+selected with ` + cli.ForFlagName + ` condition=<type>, optionally followed by the desired
+status, =True, =False or =Unknown. Use ` + cli.ForFlagName + ` delete to wait for the resources
+to be removed.
+
+Every resource is reported. The command exits with status ` + fmt.Sprint(cli.ExitCodeTimeout) + ` when the timeout
+elapses and with status ` + fmt.Sprint(cli.ExitCodeConditionFailed) + ` when a resource fails to become ready, a
+condition waited for to be True becomes False or a resource is deleted while
+waiting for a condition. Other errors exit with the status for their category.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s wait function/my-function", c.Name),
+			fmt.Sprintf("%s wait deployer.knative/my-deployer stream/my-stream %s 5m", c.Name, cli.TimeoutFlagName),
+			fmt.Sprintf("%s wait application/my-application %s condition=ImageResolved=False", c.Name, cli.ForFlagName),
+			fmt.Sprintf("%s wait processor/my-processor %s delete", c.Name, cli.ForFlagName),
+		}, "\n"),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.ResourcesArg(&opts.Resources),
+	)
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cmd.Flags().StringVar(&opts.For, cli.StripDash(cli.ForFlagName), waitForConditionPrefix+string(apis.ConditionReady), fmt.Sprintf("`condition` to wait for, either %q or %q", "condition=<type>[=<status>]", waitForDelete))
+	cmd.Flags().StringVar(&opts.Timeout, cli.StripDash(cli.TimeoutFlagName), "30s", "`duration` to wait before giving up")
+
+	return cmd
+}
+
+// parseWaitResource splits a <kind>/<name> reference.
+func parseWaitResource(resource string) (string, string, error) {
+	parts := strings.SplitN(resource, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("resource must be in the form <kind>/<name>")
+	}
+	return strings.ToLower(parts[0]), parts[1], nil
+}
+
+// parseWaitFor resolves the condition type and status to wait for. An empty condition type
+// indicates a wait for deletion.
+func parseWaitFor(value string) (apis.ConditionType, corev1.ConditionStatus, error) {
+	if value == waitForDelete {
+		return "", "", nil
+	}
+	if !strings.HasPrefix(value, waitForConditionPrefix) {
+		return "", "", fmt.Errorf("unknown wait %q", value)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, waitForConditionPrefix), "=", 2)
+	if parts[0] == "" {
+		return "", "", fmt.Errorf("missing condition type")
+	}
+	status := corev1.ConditionTrue
+	if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "true":
+			status = corev1.ConditionTrue
+		case "false":
+			status = corev1.ConditionFalse
+		case "unknown":
+			status = corev1.ConditionUnknown
+		default:
+			return "", "", fmt.Errorf("unknown condition status %q", parts[1])
+		}
+	}
+	return apis.ConditionType(parts[0]), status, nil
+}
+
+type waitKind struct {
+	Kind     string
+	Group    string
+	Resource string
+	Aliases  []string
+	Client   func(c *cli.Config) rest.Interface
+	Get      func(c *cli.Config, namespace, name string) (k8s.Object, error)
+}
+
+func (wk *waitKind) matches(kind string) bool {
+	names := append([]string{strings.ToLower(wk.Kind), wk.Resource}, wk.Aliases...)
+	shortGroup := strings.TrimSuffix(wk.Group, ".projectriff.io")
+	for _, name := range names {
+		if kind == name || kind == name+"."+shortGroup || kind == name+"."+wk.Group {
+			return true
+		}
+	}
+	return false
+}
+
+func (wk *waitKind) wait(ctx context.Context, c *cli.Config, namespace, name string, conditionType apis.ConditionType, status corev1.ConditionStatus) error {
+	target, err := wk.Get(c, namespace, name)
+	if err != nil {
+		if apierrs.IsNotFound(err) && conditionType == "" {
+			// already deleted
+			return nil
+		}
+		return err
+	}
+	if target.GetObjectKind().GroupVersionKind().Kind == "" {
+		target.GetObjectKind().SetGroupVersionKind(target.GetGroupVersionKind())
+	}
+	if conditionType == "" {
+		err = k8s.WaitUntilDeleted(ctx, wk.Client(c), wk.Resource, target)
+	} else {
+		err = k8s.WaitUntilCondition(ctx, wk.Client(c), wk.Resource, target, conditionType, status)
+	}
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		// the deadline may be exceeded while syncing the watch cache
+		return k8s.ErrWaitTimeout
+	}
+	return err
+}
+
+// resolveWaitKind finds the kind matching the name, an error is returned if the kind is unknown
+// or ambiguous. Ambiguous kinds are resolved by the preferred runtime from the config file.
+func resolveWaitKind(kind string) (*waitKind, error) {
+	matches := []string{}
+	var match, preferred *waitKind
+	for i := range waitKinds {
+		if waitKinds[i].matches(kind) {
+			match = &waitKinds[i]
+			matches = append(matches, fmt.Sprintf("%s.%s", strings.ToLower(match.Kind), match.Group))
+			if runtime := cli.PreferredRuntime(); runtime != "" && match.Group == fmt.Sprintf("%s.projectriff.io", runtime) {
+				preferred = match
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("unknown kind %q", kind)
+	case 1:
+		return match, nil
+	default:
+		if preferred != nil {
+			return preferred, nil
+		}
+		sort.Strings(matches)
+		return nil, fmt.Errorf("ambiguous kind %q, one of: %s", kind, strings.Join(matches, ", "))
+	}
+}
+
+var waitKinds = []waitKind{
+	{
+		Kind:     "Application",
+		Group:    "build.projectriff.io",
+		Resource: "applications",
+		Aliases:  []string{"app", "apps"},
+		Client:   func(c *cli.Config) rest.Interface { return c.Build().RESTClient() },
+		Get: func(c *cli.Config, namespace, name string) (k8s.Object, error) {
+			return c.Build().Applications(namespace).Get(name, metav1.GetOptions{})
+		},
+	},
+	{
+		Kind:     "Container",
+		Group:    "build.projectriff.io",
+		Resource: "containers",
+		Client:   func(c *cli.Config) rest.Interface { return c.Build().RESTClient() },
+		Get: func(c *cli.Config, namespace, name string) (k8s.Object, error) {
+			return c.Build().Containers(namespace).Get(name, metav1.GetOptions{})
+		},
+	},
+	{
+		Kind:     "Function",
+		Group:    "build.projectriff.io",
+		Resource: "functions",
+		Aliases:  []string{"func", "funcs", "fn", "fns"},
+		Client:   func(c *cli.Config) rest.Interface { return c.Build().RESTClient() },
+		Get: func(c *cli.Config, namespace, name string) (k8s.Object, error) {
+			return c.Build().Functions(namespace).Get(name, metav1.GetOptions{})
+		},
+	},
+	{
+		Kind:     "Deployer",
+		Group:    "core.projectriff.io",
+		Resource: "deployers",
+		Client:   func(c *cli.Config) rest.Interface { return c.CoreRuntime().RESTClient() },
+		Get: func(c *cli.Config, namespace, name string) (k8s.Object, error) {
+			return c.CoreRuntime().Deployers(namespace).Get(name, metav1.GetOptions{})
+		},
+	},
+	{
+		Kind:     "Adapter",
+		Group:    "knative.projectriff.io",
+		Resource: "adapters",
+		Client:   func(c *cli.Config) rest.Interface { return c.KnativeRuntime().RESTClient() },
+		Get: func(c *cli.Config, namespace, name string) (k8s.Object, error) {
+			return c.KnativeRuntime().Adapters(namespace).Get(name, metav1.GetOptions{})
+		},
+	},
+	{
+		Kind:     "Deployer",
+		Group:    "knative.projectriff.io",
+		Resource: "deployers",
+		Client:   func(c *cli.Config) rest.Interface { return c.KnativeRuntime().RESTClient() },
+		Get: func(c *cli.Config, namespace, name string) (k8s.Object, error) {
+			return c.KnativeRuntime().Deployers(namespace).Get(name, metav1.GetOptions{})
+		},
+	},
+	{
+		Kind:     "Gateway",
+		Group:    "streaming.projectriff.io",
+		Resource: "gateways",
+		Client:   func(c *cli.Config) rest.Interface { return c.StreamingRuntime().RESTClient() },
+		Get: func(c *cli.Config, namespace, name string) (k8s.Object, error) {
+			return c.StreamingRuntime().Gateways(namespace).Get(name, metav1.GetOptions{})
+		},
+	},
+	{
+		Kind:     "InMemoryGateway",
+		Group:    "streaming.projectriff.io",
+		Resource: "inmemorygateways",
+		Client:   func(c *cli.Config) rest.Interface { return c.StreamingRuntime().RESTClient() },
+		Get: func(c *cli.Config, namespace, name string) (k8s.Object, error) {
+			return c.StreamingRuntime().InMemoryGateways(namespace).Get(name, metav1.GetOptions{})
+		},
+	},
+	{
+		Kind:     "KafkaGateway",
+		Group:    "streaming.projectriff.io",
+		Resource: "kafkagateways",
+		Client:   func(c *cli.Config) rest.Interface { return c.StreamingRuntime().RESTClient() },
+		Get: func(c *cli.Config, namespace, name string) (k8s.Object, error) {
+			return c.StreamingRuntime().KafkaGateways(namespace).Get(name, metav1.GetOptions{})
+		},
+	},
+	{
+		Kind:     "PulsarGateway",
+		Group:    "streaming.projectriff.io",
+		Resource: "pulsargateways",
+		Client:   func(c *cli.Config) rest.Interface { return c.StreamingRuntime().RESTClient() },
+		Get: func(c *cli.Config, namespace, name string) (k8s.Object, error) {
+			return c.StreamingRuntime().PulsarGateways(namespace).Get(name, metav1.GetOptions{})
+		},
+	},
+	{
+		Kind:     "Processor",
+		Group:    "streaming.projectriff.io",
+		Resource: "processors",
+		Client:   func(c *cli.Config) rest.Interface { return c.StreamingRuntime().RESTClient() },
+		Get: func(c *cli.Config, namespace, name string) (k8s.Object, error) {
+			return c.StreamingRuntime().Processors(namespace).Get(name, metav1.GetOptions{})
+		},
+	},
+	{
+		Kind:     "Stream",
+		Group:    "streaming.projectriff.io",
+		Resource: "streams",
+		Client:   func(c *cli.Config) rest.Interface { return c.StreamingRuntime().RESTClient() },
+		Get: func(c *cli.Config, namespace, name string) (k8s.Object, error) {
+			return c.StreamingRuntime().Streams(namespace).Get(name, metav1.GetOptions{})
+		},
+	},
+}
@@ -0,0 +1,339 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/k8s"
+	"github.com/projectriff/cli/pkg/riff/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	buildv1alpha1 "github.com/projectriff/system/pkg/apis/build/v1alpha1"
+	knativev1alpha1 "github.com/projectriff/system/pkg/apis/knative/v1alpha1"
+	"github.com/spf13/viper"
+	"github.com/vmware-labs/reconciler-runtime/apis"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	cachetesting "k8s.io/client-go/tools/cache/testing"
+)
+
+func TestWaitOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "valid",
+			Options: &commands.WaitOptions{
+				Namespace: "default",
+				Resources: []string{"function/my-function", "deployer.knative/my-deployer"},
+				For:       "condition=Ready",
+				Timeout:   "30s",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name:    "missing everything",
+			Options: &commands.WaitOptions{},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrMissingField(cli.NamespaceFlagName),
+				cli.ErrMissingField(cli.ResourcesArgumentName),
+				cli.ErrInvalidValue("", cli.ForFlagName),
+				cli.ErrMissingField(cli.TimeoutFlagName),
+			),
+		},
+		{
+			Name: "invalid resources",
+			Options: &commands.WaitOptions{
+				Namespace: "default",
+				Resources: []string{"my-function", "bogus/my-bogus", "deployer/my-deployer", "stream/My_Stream"},
+				For:       "condition=Ready",
+				Timeout:   "30s",
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidArrayValue("my-function", cli.ResourcesArgumentName, 0),
+				cli.ErrInvalidArrayValue("bogus/my-bogus", cli.ResourcesArgumentName, 1),
+				cli.ErrInvalidArrayValue("deployer/my-deployer", cli.ResourcesArgumentName, 2),
+				cli.ErrInvalidValue("My_Stream", cli.CurrentField).ViaFieldIndex(cli.ResourcesArgumentName, 3),
+			),
+		},
+		{
+			Name: "qualified kinds",
+			Options: &commands.WaitOptions{
+				Namespace: "default",
+				Resources: []string{"deployers.core.projectriff.io/my-deployer", "Stream.streaming/my-stream", "fn/my-function"},
+				For:       "condition=Ready",
+				Timeout:   "30s",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "for condition status",
+			Options: &commands.WaitOptions{
+				Namespace: "default",
+				Resources: []string{"function/my-function"},
+				For:       "condition=ImageResolved=False",
+				Timeout:   "30s",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "for delete",
+			Options: &commands.WaitOptions{
+				Namespace: "default",
+				Resources: []string{"function/my-function"},
+				For:       "delete",
+				Timeout:   "30s",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid for",
+			Options: &commands.WaitOptions{
+				Namespace: "default",
+				Resources: []string{"function/my-function"},
+				For:       "condition=Ready=Maybe",
+				Timeout:   "30s",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("condition=Ready=Maybe", cli.ForFlagName),
+		},
+		{
+			Name: "invalid timeout",
+			Options: &commands.WaitOptions{
+				Namespace: "default",
+				Resources: []string{"function/my-function"},
+				For:       "condition=Ready",
+				Timeout:   "soon",
+			},
+			ExpectFieldErrors: cli.ErrInvalidValue("soon", cli.TimeoutFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestWaitOptions_PreferredRuntime(t *testing.T) {
+	defer viper.Reset()
+	viper.Set(cli.RuntimeConfigKey, cli.KnativeRuntime)
+
+	opts := &commands.WaitOptions{
+		Namespace: "default",
+		Resources: []string{"deployer/my-deployer"},
+		For:       "condition=Ready",
+		Timeout:   "30s",
+	}
+	if errs := opts.Validate(context.Background()); len(errs) != 0 {
+		t.Errorf("Unexpected errors: %v", errs)
+	}
+}
+
+func TestWaitCommand(t *testing.T) {
+	defaultNamespace := "default"
+	functionName := "my-function"
+	deployerName := "my-deployer"
+
+	function := &buildv1alpha1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      functionName,
+			UID:       "ae5a2d19-12cc-4a0c-8f1e-5d0e0e6e1e01",
+		},
+		Status: buildv1alpha1.FunctionStatus{
+			Status: apis.Status{
+				Conditions: apis.Conditions{
+					{Type: apis.ConditionReady, Status: corev1.ConditionUnknown},
+					{Type: buildv1alpha1.FunctionConditionImageResolved, Status: corev1.ConditionTrue},
+				},
+			},
+		},
+	}
+	functionReady := function.DeepCopy()
+	functionReady.Status.Conditions[0].Status = corev1.ConditionTrue
+	functionFailed := function.DeepCopy()
+	functionFailed.Status.Conditions[0].Status = corev1.ConditionFalse
+	functionFailed.Status.Conditions[0].Message = "build failed"
+
+	deployerReady := &knativev1alpha1.Deployer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      deployerName,
+			UID:       "ae5a2d19-12cc-4a0c-8f1e-5d0e0e6e1e02",
+		},
+		Status: knativev1alpha1.DeployerStatus{
+			Status: apis.Status{
+				Conditions: apis.Conditions{
+					{Type: apis.ConditionReady, Status: corev1.ConditionTrue},
+				},
+			},
+		},
+	}
+
+	watching := func(objects ...runtime.Object) func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+		return func(t *testing.T, ctx context.Context, c *cli.Config) (context.Context, error) {
+			lw := cachetesting.NewFakeControllerSource()
+			for _, obj := range objects {
+				lw.Add(obj.DeepCopyObject())
+			}
+			return k8s.WithListerWatcher(ctx, lw), nil
+		}
+	}
+	shutdown := func(t *testing.T, ctx context.Context, c *cli.Config) error {
+		if lw, ok := k8s.GetListerWatcher(ctx, nil, "", nil).(*cachetesting.FakeControllerSource); ok {
+			lw.Shutdown()
+		}
+		return nil
+	}
+	expectExitCode := func(code int) func(t *testing.T, output string, err error) {
+		return func(t *testing.T, output string, err error) {
+			if !errors.Is(err, cli.SilentError) {
+				t.Errorf("expected error to be silent, actual %#v", err)
+			}
+			if expected, actual := code, cli.ExitCode(err); expected != actual {
+				t.Errorf("expected exit code %d, actual %d", expected, actual)
+			}
+		}
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name: "ready",
+			Args: []string{"function/" + functionName},
+			GivenObjects: []runtime.Object{
+				functionReady,
+			},
+			Prepare: watching(functionReady),
+			CleanUp: shutdown,
+			ExpectOutput: `
+function/my-function condition met
+`,
+		},
+		{
+			Name: "ready, multiple resources",
+			Args: []string{"function/" + functionName, "deployer.knative/" + deployerName},
+			GivenObjects: []runtime.Object{
+				functionReady,
+				deployerReady,
+			},
+			Prepare: watching(functionReady, deployerReady),
+			CleanUp: shutdown,
+			ExpectOutput: `
+function/my-function condition met
+deployer.knative/my-deployer condition met
+`,
+		},
+		{
+			Name: "custom condition",
+			Args: []string{"function/" + functionName, cli.ForFlagName, "condition=ImageResolved"},
+			GivenObjects: []runtime.Object{
+				function,
+			},
+			Prepare: watching(function),
+			CleanUp: shutdown,
+			ExpectOutput: `
+function/my-function condition met
+`,
+		},
+		{
+			Name: "failed condition",
+			Args: []string{"function/" + functionName, "deployer.knative/" + deployerName},
+			GivenObjects: []runtime.Object{
+				functionFailed,
+				deployerReady,
+			},
+			Prepare: watching(functionFailed, deployerReady),
+			CleanUp: shutdown,
+			ExpectOutput: `
+Condition failed for function/my-function: failed to become ready: build failed
+deployer.knative/my-deployer condition met
+`,
+			ShouldError: true,
+			Verify:      expectExitCode(cli.ExitCodeConditionFailed),
+		},
+		{
+			Name: "timeout",
+			Args: []string{"function/" + functionName, cli.TimeoutFlagName, "5ms"},
+			GivenObjects: []runtime.Object{
+				function,
+			},
+			Prepare: watching(function),
+			CleanUp: shutdown,
+			ExpectOutput: `
+Timeout after "5ms" waiting for function/my-function
+`,
+			ShouldError: true,
+			Verify:      expectExitCode(cli.ExitCodeTimeout),
+		},
+		{
+			Name: "not found",
+			Args: []string{"function/" + functionName, "deployer.knative/" + deployerName},
+			GivenObjects: []runtime.Object{
+				deployerReady,
+			},
+			Prepare: watching(deployerReady),
+			CleanUp: shutdown,
+			ExpectOutput: `
+Error waiting for function/my-function: functions.build.projectriff.io "my-function" not found
+deployer.knative/my-deployer condition met
+`,
+			ShouldError: true,
+			Verify:      expectExitCode(cli.ExitCodeNotFound),
+		},
+		{
+			Name:    "delete, not found",
+			Args:    []string{"function/" + functionName, cli.ForFlagName, "delete"},
+			Prepare: watching(),
+			CleanUp: shutdown,
+			ExpectOutput: `
+function/my-function deleted
+`,
+		},
+		{
+			Name: "delete, removed from watch",
+			Args: []string{"function/" + functionName, cli.ForFlagName, "delete"},
+			GivenObjects: []runtime.Object{
+				function,
+			},
+			Prepare: watching(),
+			CleanUp: shutdown,
+			ExpectOutput: `
+function/my-function deleted
+`,
+		},
+		{
+			Name: "delete, timeout",
+			Args: []string{"function/" + functionName, cli.ForFlagName, "delete", cli.TimeoutFlagName, "5ms"},
+			GivenObjects: []runtime.Object{
+				function,
+			},
+			Prepare: watching(function),
+			CleanUp: shutdown,
+			ExpectOutput: `
+Timeout after "5ms" waiting for function/my-function
+`,
+			ShouldError: true,
+			Verify:      expectExitCode(cli.ExitCodeTimeout),
+		},
+	}
+
+	table.Run(t, commands.NewWaitCommand)
+}
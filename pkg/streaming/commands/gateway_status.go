@@ -86,6 +86,7 @@ gateway rollout is being processed.
 	cli.Args(cmd,
 		cli.NameArg(&opts.Name),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListStreamingGateways)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 
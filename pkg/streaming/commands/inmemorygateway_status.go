@@ -86,6 +86,7 @@ in-memory gateway rollout is being processed.
 	cli.Args(cmd,
 		cli.NameArg(&opts.Name),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListStreamingInMemoryGateways)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 
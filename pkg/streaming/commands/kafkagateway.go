@@ -41,6 +41,7 @@ broker.
 
 	cmd.AddCommand(NewKafkaGatewayListCommand(ctx, c))
 	cmd.AddCommand(NewKafkaGatewayCreateCommand(ctx, c))
+	cmd.AddCommand(NewKafkaGatewayUpdateCommand(ctx, c))
 	cmd.AddCommand(NewKafkaGatewayDeleteCommand(ctx, c))
 	cmd.AddCommand(NewKafkaGatewayStatusCommand(ctx, c))
 
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type KafkaGatewayUpdateOptions struct {
+	options.ResourceOptions
+
+	BootstrapServers string
+}
+
+var (
+	_ cli.Validatable = (*KafkaGatewayUpdateOptions)(nil)
+	_ cli.Executable  = (*KafkaGatewayUpdateOptions)(nil)
+)
+
+func (opts *KafkaGatewayUpdateOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+
+	if opts.BootstrapServers == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.BootstrapServersFlagName))
+	}
+
+	return errs
+}
+
+func (opts *KafkaGatewayUpdateOptions) Exec(ctx context.Context, c *cli.Config) error {
+	gateway, err := c.StreamingRuntime().KafkaGateways(opts.Namespace).Get(opts.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			return err
+		}
+		c.Errorf("Kafka gateway %q not found\n", fmt.Sprintf("%s/%s", opts.Namespace, opts.Name))
+		return cli.SilenceError(err)
+	}
+
+	gateway = gateway.DeepCopy()
+	gateway.Spec.BootstrapServers = opts.BootstrapServers
+	gateway, err = c.StreamingRuntime().KafkaGateways(opts.Namespace).Update(gateway)
+	if err != nil {
+		return err
+	}
+	c.Successf("Updated kafka gateway %q\n", gateway.Name)
+
+	return nil
+}
+
+func NewKafkaGatewayUpdateCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &KafkaGatewayUpdateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "update the address of a kafka gateway",
+		Long: strings.TrimSpace(`
+Updates the address of the Kafka broker for an existing gateway.
+
+The bootstrap servers are required, the gateway always needs a broker to
+connect to.
+`),
+		Example: fmt.Sprintf("%s streaming kafka-gateway update my-kafka-gateway %s kafka.local:9092", c.Name, cli.BootstrapServersFlagName),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.NameArg(&opts.Name),
+	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListStreamingKafkaGateways)
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cmd.Flags().StringVar(&opts.BootstrapServers, cli.StripDash(cli.BootstrapServersFlagName), "", "`address` of the kafka broker")
+
+	return cmd
+}
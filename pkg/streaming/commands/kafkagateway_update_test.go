@@ -0,0 +1,145 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/streaming/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestKafkaGatewayUpdateOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "invalid resource",
+			Options: &commands.KafkaGatewayUpdateOptions{
+				ResourceOptions: rifftesting.InvalidResourceOptions,
+			},
+			ExpectFieldErrors: rifftesting.InvalidResourceOptionsFieldError.Also(
+				cli.ErrMissingField(cli.BootstrapServersFlagName),
+			),
+		},
+		{
+			Name: "valid resource",
+			Options: &commands.KafkaGatewayUpdateOptions{
+				ResourceOptions:  rifftesting.ValidResourceOptions,
+				BootstrapServers: "localhost:9092",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "missing bootstrap servers",
+			Options: &commands.KafkaGatewayUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+			},
+			ExpectFieldErrors: cli.ErrMissingField(cli.BootstrapServersFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestKafkaGatewayUpdateCommand(t *testing.T) {
+	defaultNamespace := "default"
+	kafkaGatewayName := "my-kafka-gateway"
+
+	gateway := &streamv1alpha1.KafkaGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      kafkaGatewayName,
+		},
+		Spec: streamv1alpha1.KafkaGatewaySpec{
+			BootstrapServers: "localhost:9092",
+		},
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name:        "no bootstrap servers",
+			Args:        []string{kafkaGatewayName},
+			ShouldError: true,
+		},
+		{
+			Name:        "empty bootstrap servers",
+			Args:        []string{kafkaGatewayName, cli.BootstrapServersFlagName, ""},
+			ShouldError: true,
+		},
+		{
+			Name: "update bootstrap servers",
+			Args: []string{kafkaGatewayName, cli.BootstrapServersFlagName, "kafka.local:9093"},
+			GivenObjects: []runtime.Object{
+				gateway,
+			},
+			ExpectUpdates: []runtime.Object{
+				&streamv1alpha1.KafkaGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      kafkaGatewayName,
+					},
+					Spec: streamv1alpha1.KafkaGatewaySpec{
+						BootstrapServers: "kafka.local:9093",
+					},
+				},
+			},
+			ExpectOutput: `
+Updated kafka gateway "my-kafka-gateway"
+`,
+		},
+		{
+			Name: "not found",
+			Args: []string{kafkaGatewayName, cli.BootstrapServersFlagName, "kafka.local:9093"},
+			ExpectOutput: `
+Kafka gateway "default/my-kafka-gateway" not found
+`,
+			ShouldError: true,
+		},
+		{
+			Name: "update error",
+			Args: []string{kafkaGatewayName, cli.BootstrapServersFlagName, "kafka.local:9093"},
+			GivenObjects: []runtime.Object{
+				gateway,
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("update", "kafkagatewaies"),
+			},
+			ExpectUpdates: []runtime.Object{
+				&streamv1alpha1.KafkaGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      kafkaGatewayName,
+					},
+					Spec: streamv1alpha1.KafkaGatewaySpec{
+						BootstrapServers: "kafka.local:9093",
+					},
+				},
+			},
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewKafkaGatewayUpdateCommand)
+}
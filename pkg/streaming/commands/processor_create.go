@@ -33,32 +33,42 @@ import (
 	streamingv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type ProcessorCreateOptions struct {
 	options.ResourceOptions
+	options.VolumeOptions
+	options.EnvFileOptions
 
 	Image        string
 	ContainerRef string
 	FunctionRef  string
 
-	Env     []string
-	EnvFrom []string
+	Env               []string
+	EnvFrom           []string
+	EnvFromConfigMaps []string
+	EnvFromSecrets    []string
 
 	Inputs  []string
 	Outputs []string
 
+	LimitCPU    string
+	LimitMemory string
+
 	Tail        bool
 	WaitTimeout string
 
-	DryRun bool
+	DryRun      bool
+	Interactive bool
 }
 
 var (
 	_ cli.Validatable = (*ProcessorCreateOptions)(nil)
 	_ cli.Executable  = (*ProcessorCreateOptions)(nil)
 	_ cli.DryRunable  = (*ProcessorCreateOptions)(nil)
+	_ cli.Promptable  = (*ProcessorCreateOptions)(nil)
 )
 
 func (opts *ProcessorCreateOptions) Validate(ctx context.Context) cli.FieldErrors {
@@ -83,7 +93,11 @@ func (opts *ProcessorCreateOptions) Validate(ctx context.Context) cli.FieldError
 	}
 
 	errs = errs.Also(validation.EnvVars(opts.Env, cli.EnvFlagName))
+	errs = errs.Also(opts.EnvFileOptions.Validate(ctx))
+	errs = errs.Also(validation.K8sNames(opts.EnvFromConfigMaps, cli.EnvFromConfigMapFlagName))
+	errs = errs.Also(validation.K8sNames(opts.EnvFromSecrets, cli.EnvFromSecretFlagName))
 	errs = errs.Also(validation.EnvVarFroms(opts.EnvFrom, cli.EnvFromFlagName))
+	errs = errs.Also(opts.VolumeOptions.Validate(ctx))
 
 	if opts.Image != "" {
 		used = append(used, cli.ImageFlagName)
@@ -101,6 +115,13 @@ func (opts *ProcessorCreateOptions) Validate(ctx context.Context) cli.FieldError
 		errs = errs.Also(cli.ErrMissingField(cli.InputFlagName))
 	}
 
+	if opts.LimitCPU != "" {
+		errs = errs.Also(validation.Quantity(opts.LimitCPU, cli.LimitCPUFlagName))
+	}
+	if opts.LimitMemory != "" {
+		errs = errs.Also(validation.Quantity(opts.LimitMemory, cli.LimitMemoryFlagName))
+	}
+
 	if opts.Tail {
 		if opts.WaitTimeout == "" {
 			errs = errs.Also(cli.ErrMissingField(cli.WaitTimeoutFlagName))
@@ -116,6 +137,24 @@ func (opts *ProcessorCreateOptions) Validate(ctx context.Context) cli.FieldError
 	return errs
 }
 
+func (opts *ProcessorCreateOptions) Prompt(ctx context.Context, p *cli.Prompter) error {
+	if err := p.PromptName("Name", &opts.Name); err != nil {
+		return err
+	}
+	err := p.PromptFlagOneOf("Workload", opts.Namespace,
+		cli.PromptChoice{Label: "function", FlagName: cli.FunctionRefFlagName, List: cli.ListFunctions},
+		cli.PromptChoice{Label: "container", FlagName: cli.ContainerRefFlagName, List: cli.ListContainers},
+		cli.PromptChoice{Label: "image", FlagName: cli.ImageFlagName},
+	)
+	if err != nil {
+		return err
+	}
+	if err := p.PromptFlagNames(cli.InputFlagName, "Input streams", opts.Namespace, cli.ListStreamingStreams, false); err != nil {
+		return err
+	}
+	return p.PromptFlagNames(cli.OutputFlagName, "Output streams", opts.Namespace, cli.ListStreamingStreams, true)
+}
+
 func (opts *ProcessorCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
 	var err error
 	inputs, err := parseInputStreamBindings(opts.Inputs)
@@ -153,6 +192,25 @@ func (opts *ProcessorCreateOptions) Exec(ctx context.Context, c *cli.Config) err
 		}
 	}
 
+	env, err := opts.EnvFromFiles()
+	if err != nil {
+		return err
+	}
+	processor.Spec.Template.Spec.Containers[0].Env = append(processor.Spec.Template.Spec.Containers[0].Env, env...)
+	for _, name := range opts.EnvFromConfigMaps {
+		processor.Spec.Template.Spec.Containers[0].EnvFrom = append(processor.Spec.Template.Spec.Containers[0].EnvFrom, corev1.EnvFromSource{
+			ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			},
+		})
+	}
+	for _, name := range opts.EnvFromSecrets {
+		processor.Spec.Template.Spec.Containers[0].EnvFrom = append(processor.Spec.Template.Spec.Containers[0].EnvFrom, corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			},
+		})
+	}
 	for _, env := range opts.Env {
 		if processor.Spec.Template.Spec.Containers[0].Env == nil {
 			processor.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{}
@@ -170,6 +228,19 @@ func (opts *ProcessorCreateOptions) Exec(ctx context.Context, c *cli.Config) err
 		processor.Spec.Template.Spec.Containers[0].Image = opts.Image
 	}
 
+	if (opts.LimitCPU != "" || opts.LimitMemory != "") && processor.Spec.Template.Spec.Containers[0].Resources.Limits == nil {
+		processor.Spec.Template.Spec.Containers[0].Resources.Limits = corev1.ResourceList{}
+	}
+	if opts.LimitCPU != "" {
+		// parse errors are handled by the opt validation
+		processor.Spec.Template.Spec.Containers[0].Resources.Limits[corev1.ResourceCPU] = resource.MustParse(opts.LimitCPU)
+	}
+	if opts.LimitMemory != "" {
+		// parse errors are handled by the opt validation
+		processor.Spec.Template.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory] = resource.MustParse(opts.LimitMemory)
+	}
+	opts.VolumeOptions.ApplyTo(&processor.Spec.Template.Spec)
+
 	if opts.DryRun {
 		cli.DryRunResource(ctx, processor, processor.GetGroupVersionKind())
 	} else {
@@ -221,32 +292,54 @@ Creates a processor within a namespace.
 
 The processor is configured with a function or container reference and multiple
 input and/or output streams.
+
+Config maps and secrets are mounted into the workload as read only files with
+` + cli.MountConfigMapFlagName + ` and ` + cli.MountSecretFlagName + `, scratch space is added with ` + cli.EmptyDirFlagName + `.
+Mount paths must be absolute and may not overlap. The workload runs as the
+namespace's default service account unless ` + cli.ServiceAccountFlagName + ` is set.
+
+` + cli.InteractiveFlagName + ` prompts for missing values when stdin is a terminal and prints
+the equivalent command, other values are validated as usual.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s streaming processor create my-processor %s my-func %s my-input-stream", c.Name, cli.FunctionRefFlagName, cli.InputFlagName),
+			fmt.Sprintf("%s streaming processor create my-processor %s my-func %s my-input-stream %s 100m %s 128Mi", c.Name, cli.FunctionRefFlagName, cli.InputFlagName, cli.LimitCPUFlagName, cli.LimitMemoryFlagName),
 			fmt.Sprintf("%s streaming processor create my-processor %s my-func %s input:my-input-stream %s my-join-stream@earliest %s out:my-output-stream", c.Name, cli.FunctionRefFlagName, cli.InputFlagName, cli.InputFlagName, cli.OutputFlagName),
 		}, "\n"),
-		PreRunE: cli.ValidateOptions(ctx, opts),
-		RunE:    cli.ExecOptions(ctx, c, opts),
+		PreRunE: cli.Sequence(
+			cli.PromptOptions(ctx, c, &opts.Interactive, opts),
+			cli.ValidateOptions(ctx, opts),
+		),
+		RunE: cli.ExecOptions(ctx, c, opts),
 	}
 
 	cli.Args(cmd,
-		cli.NameArg(&opts.Name),
+		cli.InteractiveNameArg(&opts.Name, &opts.Interactive),
 	)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().StringVar(&opts.Image, cli.StripDash(cli.ImageFlagName), "", "container `image` to deploy")
 	cmd.Flags().StringVar(&opts.ContainerRef, cli.StripDash(cli.ContainerRefFlagName), "", "`name` of container to deploy")
-	_ = cmd.MarkFlagCustom(cli.StripDash(cli.ContainerRefFlagName), "__"+c.Name+"_list_containers")
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.ContainerRefFlagName), cli.CompleteNames(c, cli.ListContainers))
 	cmd.Flags().StringVar(&opts.FunctionRef, cli.StripDash(cli.FunctionRefFlagName), "", "`name` of function to deploy")
-	_ = cmd.MarkFlagCustom(cli.StripDash(cli.FunctionRefFlagName), "__"+c.Name+"_list_functions")
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.FunctionRefFlagName), cli.CompleteNames(c, cli.ListFunctions))
 	cmd.Flags().StringArrayVar(&opts.Inputs, cli.StripDash(cli.InputFlagName), []string{}, "`name` of stream to read messages from (or [<alias>:]<stream>[@<earliest|latest>], may be set multiple times)")
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.InputFlagName), cli.CompleteStreamRefs(c, cli.ListStreamingStreams))
 	cmd.Flags().StringArrayVar(&opts.Outputs, cli.StripDash(cli.OutputFlagName), []string{}, "`name` of stream to write messages to (or [<alias>:]<stream>, may be set multiple times)")
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.OutputFlagName), cli.CompleteStreamRefs(c, cli.ListStreamingStreams))
+	cmd.Flags().StringVar(&opts.LimitCPU, cli.StripDash(cli.LimitCPUFlagName), "", "the maximum amount of cpu allowed, in CPU `cores` (500m = .5 cores)")
+	cmd.Flags().StringVar(&opts.LimitMemory, cli.StripDash(cli.LimitMemoryFlagName), "", "the maximum amount of memory allowed, in `bytes` (500Mi = 500MiB = 500 * 1024 * 1024)")
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch processor logs")
 	cmd.Flags().StringVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), "10m", "`duration` to wait for the processor to become ready when watching logs")
+	cli.ConfigDefault(cmd, cli.WaitTimeoutFlagName, cli.WaitTimeoutConfigKey)
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+	cmd.Flags().BoolVar(&opts.Interactive, cli.StripDash(cli.InteractiveFlagName), false, "prompt for missing values when stdin is a terminal")
 	cmd.Flags().StringArrayVar(&opts.Env, cli.StripDash(cli.EnvFlagName), []string{}, fmt.Sprintf("environment `variable` defined as a key value pair separated by an equals sign, example %q (may be set multiple times)", fmt.Sprintf("%s MY_VAR=my-value", cli.EnvFlagName)))
 	cmd.Flags().StringArrayVar(&opts.EnvFrom, cli.StripDash(cli.EnvFromFlagName), []string{}, fmt.Sprintf("environment `variable` from a config map or secret, example %q, %q (may be set multiple times)", fmt.Sprintf("%s MY_SECRET_VALUE=secretKeyRef:my-secret-name:key-in-secret", cli.EnvFromFlagName), fmt.Sprintf("%s MY_CONFIG_MAP_VALUE=configMapKeyRef:my-config-map-name:key-in-config-map", cli.EnvFromFlagName)))
+	opts.EnvFileOptions.AddFlags(cmd)
+	cmd.Flags().StringArrayVar(&opts.EnvFromConfigMaps, cli.StripDash(cli.EnvFromConfigMapFlagName), []string{}, "`name` of a config map to import every key from as an environment variable (may be set multiple times)")
+	cmd.Flags().StringArrayVar(&opts.EnvFromSecrets, cli.StripDash(cli.EnvFromSecretFlagName), []string{}, "`name` of a secret to import every key from as an environment variable (may be set multiple times)")
+	opts.VolumeOptions.AddFlags(cmd)
 
 	return cmd
 }
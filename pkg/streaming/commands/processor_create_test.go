@@ -30,6 +30,7 @@ import (
 	streamingv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	cachetesting "k8s.io/client-go/tools/cache/testing"
@@ -202,6 +203,31 @@ func TestProcessorCreateOptions(t *testing.T) {
 			},
 			ShouldValidate: true,
 		},
+		{
+			Name: "with limits",
+			Options: &commands.ProcessorCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				FunctionRef:     "my-function",
+				Inputs:          []string{"input"},
+				LimitCPU:        "500m",
+				LimitMemory:     "512Mi",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "with invalid limits",
+			Options: &commands.ProcessorCreateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				FunctionRef:     "my-function",
+				Inputs:          []string{"input"},
+				LimitCPU:        "50%",
+				LimitMemory:     "NaN",
+			},
+			ExpectFieldErrors: cli.FieldErrors{}.Also(
+				cli.ErrInvalidValue("50%", cli.LimitCPUFlagName),
+				cli.ErrInvalidValue("NaN", cli.LimitMemoryFlagName),
+			),
+		},
 		{
 			Name: "dry run, tail",
 			Options: &commands.ProcessorCreateOptions{
@@ -300,6 +326,219 @@ Created processor "my-processor"
 Created processor "my-processor"
 `,
 		},
+		{
+			Name: "interactive",
+			Args: []string{processorName, cli.InteractiveFlagName},
+			GivenObjects: []runtime.Object{
+				&streamingv1alpha1.Stream{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      inputName,
+					},
+				},
+				&streamingv1alpha1.Stream{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      outputName,
+					},
+				},
+			},
+			Terminal: true,
+			Stdin:    []byte(fmt.Sprintf("\n%s\n1, %s\n2\n", functionRef, inputNameOther)),
+			ExpectCreates: []runtime.Object{
+				&streamingv1alpha1.Processor{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      processorName,
+					},
+					Spec: streamingv1alpha1.ProcessorSpec{
+						Build: &streamingv1alpha1.Build{FunctionRef: functionRef},
+						Inputs: []streamingv1alpha1.InputStreamBinding{
+							{Stream: inputName},
+							{Stream: inputNameOther},
+						},
+						Outputs: []streamingv1alpha1.OutputStreamBinding{
+							{Stream: outputName},
+						},
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Workload:
+  1) function
+  2) container
+  3) image
+Choose [function]: Function: Input streams:
+  1) input
+  2) output
+Choose, separated by commas: Output streams:
+  1) input
+  2) output
+Choose, separated by commas (optional): 
+Equivalent command:
+  create my-processor --function-ref my-func --input input --input otherinput --output output
+
+Created processor "my-processor"
+`,
+		},
+		{
+			Name:        "interactive, not a terminal",
+			Args:        []string{processorName, cli.FunctionRefFlagName, functionRef, cli.InteractiveFlagName},
+			ShouldError: true,
+			ExpectOutput: `
+Warning: stdin is not a terminal, ignoring --interactive
+`,
+		},
+		{
+			Name: "create with limits",
+			Args: []string{processorName, cli.FunctionRefFlagName, functionRef, cli.InputFlagName, inputName,
+				cli.LimitCPUFlagName, "100m", cli.LimitMemoryFlagName, "128Mi"},
+			ExpectCreates: []runtime.Object{
+				&streamingv1alpha1.Processor{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      processorName,
+					},
+					Spec: streamingv1alpha1.ProcessorSpec{
+						Build:  &streamingv1alpha1.Build{FunctionRef: functionRef},
+						Inputs: []streamingv1alpha1.InputStreamBinding{{Stream: inputName}},
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Resources: corev1.ResourceRequirements{
+											Limits: corev1.ResourceList{
+												corev1.ResourceCPU:    resource.MustParse("100m"),
+												corev1.ResourceMemory: resource.MustParse("128Mi"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created processor "my-processor"
+`,
+		},
+		{
+			Name: "create with volumes and service account",
+			Args: []string{processorName, cli.FunctionRefFlagName, functionRef, cli.InputFlagName, inputName, cli.MountConfigMapFlagName, "my-config:/etc/config", cli.MountSecretFlagName, "my-tls:/etc/tls", cli.EmptyDirFlagName, "/tmp/cache", cli.ServiceAccountFlagName, "my-service-account"},
+			ExpectCreates: []runtime.Object{
+				&streamingv1alpha1.Processor{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      processorName,
+					},
+					Spec: streamingv1alpha1.ProcessorSpec{
+						Build:  &streamingv1alpha1.Build{FunctionRef: functionRef},
+						Inputs: []streamingv1alpha1.InputStreamBinding{{Stream: inputName}},
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								ServiceAccountName: "my-service-account",
+								Volumes: []corev1.Volume{
+									{
+										Name: "configmap-0",
+										VolumeSource: corev1.VolumeSource{
+											ConfigMap: &corev1.ConfigMapVolumeSource{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+											},
+										},
+									},
+									{
+										Name: "secret-0",
+										VolumeSource: corev1.VolumeSource{
+											Secret: &corev1.SecretVolumeSource{SecretName: "my-tls"},
+										},
+									},
+									{
+										Name: "empty-dir-0",
+										VolumeSource: corev1.VolumeSource{
+											EmptyDir: &corev1.EmptyDirVolumeSource{},
+										},
+									},
+								},
+								Containers: []corev1.Container{
+									{
+										VolumeMounts: []corev1.VolumeMount{
+											{Name: "configmap-0", MountPath: "/etc/config", ReadOnly: true},
+											{Name: "secret-0", MountPath: "/etc/tls", ReadOnly: true},
+											{Name: "empty-dir-0", MountPath: "/tmp/cache"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created processor "my-processor"
+`,
+		},
+		{
+			Name:        "create with invalid mount",
+			Args:        []string{processorName, cli.FunctionRefFlagName, functionRef, cli.InputFlagName, inputName, cli.MountConfigMapFlagName, "my-config"},
+			ShouldError: true,
+		},
+		{
+			Name: "create with env file and env from config map and secret",
+			Args: []string{processorName, cli.FunctionRefFlagName, functionRef, cli.InputFlagName, inputName, cli.EnvFileFlagName, "../../parsers/testdata/app.env", cli.EnvFlagName, "MY_VAR3=value3", cli.EnvFromConfigMapFlagName, "my-configmap", cli.EnvFromSecretFlagName, "my-secret"},
+			ExpectCreates: []runtime.Object{
+				&streamingv1alpha1.Processor{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      processorName,
+					},
+					Spec: streamingv1alpha1.ProcessorSpec{
+						Build:  &streamingv1alpha1.Build{FunctionRef: functionRef},
+						Inputs: []streamingv1alpha1.InputStreamBinding{{Stream: inputName}},
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Env: []corev1.EnvVar{
+											{Name: "MY_VAR1", Value: "value1"},
+											{Name: "MY_VAR2", Value: "multi\nline"},
+											{Name: "MY_VAR3", Value: "value3"},
+										},
+										EnvFrom: []corev1.EnvFromSource{
+											{
+												ConfigMapRef: &corev1.ConfigMapEnvSource{
+													LocalObjectReference: corev1.LocalObjectReference{Name: "my-configmap"},
+												},
+											},
+											{
+												SecretRef: &corev1.SecretEnvSource{
+													LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Created processor "my-processor"
+`,
+		},
+		{
+			Name:        "create with invalid env from secret",
+			Args:        []string{processorName, cli.FunctionRefFlagName, functionRef, cli.InputFlagName, inputName, cli.EnvFromSecretFlagName, "My_Secret"},
+			ShouldError: true,
+		},
 		{
 			Name: "create with image",
 			Args: []string{processorName, cli.ImageFlagName, image, cli.InputFlagName, inputName},
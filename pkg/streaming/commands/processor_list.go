@@ -27,6 +27,7 @@ import (
 	"github.com/projectriff/cli/pkg/cli/printers"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
 	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -34,6 +35,9 @@ import (
 
 type ProcessorListOptions struct {
 	options.ListOptions
+
+	// deployments backing the listed processors, by namespace and name
+	deployments map[string]*appsv1.Deployment
 }
 
 var (
@@ -68,6 +72,15 @@ func (opts *ProcessorListOptions) Exec(ctx context.Context, c *cli.Config) error
 		h.TableHandler(columns, opts.print)
 	})
 
+	opts.deployments = map[string]*appsv1.Deployment{}
+	// replicas are informational, the processors are listed even if the deployments are not
+	if deployments, err := c.Apps().Deployments(opts.Namespace).List(metav1.ListOptions{}); err == nil {
+		for i := range deployments.Items {
+			deployment := &deployments.Items[i]
+			opts.deployments[fmt.Sprintf("%s/%s", deployment.Namespace, deployment.Name)] = deployment
+		}
+	}
+
 	processors = processors.DeepCopy()
 	cli.SortByNamespaceAndName(processors.Items)
 
@@ -83,6 +96,9 @@ func NewProcessorListCommand(ctx context.Context, c *cli.Config) *cobra.Command
 		Long: strings.TrimSpace(`
 List processors in a namespace or across all namespaces.
 
+The replicas column shows the number of ready replicas against the number of
+replicas currently desired by the autoscaler.
+
 For detail regarding the status of a single processor, run:
 
     ` + c.Name + ` processor status <processor-name>
@@ -122,6 +138,7 @@ func (opts *ProcessorListOptions) print(processor *streamv1alpha1.Processor, _ p
 		cli.FormatEmptyString(opts.functionRef(processor)),
 		cli.FormatEmptyString(strings.Join(prependInputAliases(processor.Spec.Inputs), ", ")),
 		cli.FormatEmptyString(strings.Join(prependOutputAliases(processor.Spec.Outputs), ", ")),
+		opts.replicas(processor),
 		cli.FormatConditionStatus(processor.Status.GetCondition(streamv1alpha1.ProcessorConditionReady)),
 		cli.FormatTimestampSince(processor.CreationTimestamp, now),
 	)
@@ -136,12 +153,29 @@ func (*ProcessorListOptions) functionRef(processor *streamv1alpha1.Processor) st
 	}
 }
 
+// replicas formats the ready replicas of the processor's deployment against the desired replicas
+func (opts *ProcessorListOptions) replicas(processor *streamv1alpha1.Processor) string {
+	if processor.Status.DeploymentRef == nil {
+		return cli.Swarnf("<unknown>")
+	}
+	deployment, ok := opts.deployments[fmt.Sprintf("%s/%s", processor.Namespace, processor.Status.DeploymentRef.Name)]
+	if !ok {
+		return cli.Swarnf("<unknown>")
+	}
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	return fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, desired)
+}
+
 func (opts *ProcessorListOptions) printColumns() []metav1beta1.TableColumnDefinition {
 	return []metav1beta1.TableColumnDefinition{
 		{Name: "Name", Type: "string"},
 		{Name: "Function", Type: "string"},
 		{Name: "Inputs", Type: "string"},
 		{Name: "Outputs", Type: "string"},
+		{Name: "Replicas", Type: "string"},
 		{Name: "Status", Type: "string"},
 		{Name: "Age", Type: "string"},
 	}
@@ -24,7 +24,9 @@ import (
 	"github.com/projectriff/cli/pkg/streaming/commands"
 	rifftesting "github.com/projectriff/cli/pkg/testing"
 	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
+	"github.com/projectriff/system/pkg/refs"
 	"github.com/vmware-labs/reconciler-runtime/apis"
+	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -55,6 +57,7 @@ func TestProcessorListCommand(t *testing.T) {
 	processorOtherName := "test-other-processor"
 	defaultNamespace := "default"
 	otherNamespace := "other-namespace"
+	desiredReplicas := int32(3)
 
 	table := rifftesting.CommandTable{
 		{
@@ -86,8 +89,8 @@ No processors found.
 				},
 			},
 			ExpectOutput: `
-NAME             FUNCTION   INPUTS    OUTPUTS   STATUS      AGE
-test-processor   <empty>    <empty>   <empty>   <unknown>   <unknown>
+NAME             FUNCTION   INPUTS    OUTPUTS   REPLICAS    STATUS      AGE
+test-processor   <empty>    <empty>   <empty>   <unknown>   <unknown>   <unknown>
 `,
 		},
 		{
@@ -123,9 +126,9 @@ No processors found.
 				},
 			},
 			ExpectOutput: `
-NAMESPACE         NAME                   FUNCTION   INPUTS    OUTPUTS   STATUS      AGE
-default           test-processor         <empty>    <empty>   <empty>   <unknown>   <unknown>
-other-namespace   test-other-processor   <empty>    <empty>   <empty>   <unknown>   <unknown>
+NAMESPACE         NAME                   FUNCTION   INPUTS    OUTPUTS   REPLICAS    STATUS      AGE
+default           test-processor         <empty>    <empty>   <empty>   <unknown>   <unknown>   <unknown>
+other-namespace   test-other-processor   <empty>    <empty>   <empty>   <unknown>   <unknown>   <unknown>
 `,
 		},
 		{
@@ -148,12 +151,53 @@ other-namespace   test-other-processor   <empty>    <empty>   <empty>   <unknown
 								{Type: streamv1alpha1.ProcessorConditionReady, Status: "True"},
 							},
 						},
+						DeploymentRef: &refs.TypedLocalObjectReference{
+							Kind: "Deployment",
+							Name: "square-processor",
+						},
+					},
+				},
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "square-processor",
+						Namespace: defaultNamespace,
+					},
+					Spec: appsv1.DeploymentSpec{
+						Replicas: &desiredReplicas,
+					},
+					Status: appsv1.DeploymentStatus{
+						ReadyReplicas: 2,
 					},
 				},
 			},
 			ExpectOutput: `
-NAME     FUNCTION   INPUTS                       OUTPUTS     STATUS   AGE
-square   square     n1:numbers, n2:morenumbers   s:squares   Ready    <unknown>
+NAME     FUNCTION   INPUTS                       OUTPUTS     REPLICAS   STATUS   AGE
+square   square     n1:numbers, n2:morenumbers   s:squares   2/3        Ready    <unknown>
+`,
+		},
+		{
+			Name: "deployment list error",
+			Args: []string{},
+			GivenObjects: []runtime.Object{
+				&streamv1alpha1.Processor{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      processorName,
+						Namespace: defaultNamespace,
+					},
+					Status: streamv1alpha1.ProcessorStatus{
+						DeploymentRef: &refs.TypedLocalObjectReference{
+							Kind: "Deployment",
+							Name: "test-processor-processor",
+						},
+					},
+				},
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("list", "deployments"),
+			},
+			ExpectOutput: `
+NAME             FUNCTION   INPUTS    OUTPUTS   REPLICAS    STATUS      AGE
+test-processor   <empty>    <empty>   <empty>   <unknown>   <unknown>   <unknown>
 `,
 		},
 		{
@@ -86,6 +86,7 @@ processor rollout is processed.
 	cli.Args(cmd,
 		cli.NameArg(&opts.Name),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListStreamingProcessors)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 
@@ -41,6 +41,7 @@ a Pulsar broker.
 
 	cmd.AddCommand(NewPulsarGatewayListCommand(ctx, c))
 	cmd.AddCommand(NewPulsarGatewayCreateCommand(ctx, c))
+	cmd.AddCommand(NewPulsarGatewayUpdateCommand(ctx, c))
 	cmd.AddCommand(NewPulsarGatewayDeleteCommand(ctx, c))
 	cmd.AddCommand(NewPulsarGatewayStatusCommand(ctx, c))
 
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/cli/options"
+	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PulsarGatewayUpdateOptions struct {
+	options.ResourceOptions
+
+	ServiceURL string
+}
+
+var (
+	_ cli.Validatable = (*PulsarGatewayUpdateOptions)(nil)
+	_ cli.Executable  = (*PulsarGatewayUpdateOptions)(nil)
+)
+
+func (opts *PulsarGatewayUpdateOptions) Validate(ctx context.Context) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	errs = errs.Also(opts.ResourceOptions.Validate(ctx))
+
+	if opts.ServiceURL == "" {
+		errs = errs.Also(cli.ErrMissingField(cli.ServiceURLFlagName))
+	}
+
+	return errs
+}
+
+func (opts *PulsarGatewayUpdateOptions) Exec(ctx context.Context, c *cli.Config) error {
+	gateway, err := c.StreamingRuntime().PulsarGateways(opts.Namespace).Get(opts.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			return err
+		}
+		c.Errorf("Pulsar gateway %q not found\n", fmt.Sprintf("%s/%s", opts.Namespace, opts.Name))
+		return cli.SilenceError(err)
+	}
+
+	gateway = gateway.DeepCopy()
+	gateway.Spec.ServiceURL = opts.ServiceURL
+	gateway, err = c.StreamingRuntime().PulsarGateways(opts.Namespace).Update(gateway)
+	if err != nil {
+		return err
+	}
+	c.Successf("Updated pulsar gateway %q\n", gateway.Name)
+
+	return nil
+}
+
+func NewPulsarGatewayUpdateCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &PulsarGatewayUpdateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "update the address of a pulsar gateway",
+		Long: strings.TrimSpace(`
+Updates the address of the Pulsar service for an existing gateway.
+
+The service URL is required, the gateway always needs a service to connect to.
+`),
+		Example: fmt.Sprintf("%s streaming pulsar-gateway update my-pulsar-gateway %s pulsar://pulsar.local:6650", c.Name, cli.ServiceURLFlagName),
+		PreRunE: cli.ValidateOptions(ctx, opts),
+		RunE:    cli.ExecOptions(ctx, c, opts),
+	}
+
+	cli.Args(cmd,
+		cli.NameArg(&opts.Name),
+	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListStreamingPulsarGateways)
+
+	cli.NamespaceFlag(cmd, c, &opts.Namespace)
+	cmd.Flags().StringVar(&opts.ServiceURL, cli.StripDash(cli.ServiceURLFlagName), "", "`url` of the pulsar service")
+
+	return cmd
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/projectriff/cli/pkg/cli"
+	"github.com/projectriff/cli/pkg/streaming/commands"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	streamv1alpha1 "github.com/projectriff/system/pkg/apis/streaming/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPulsarGatewayUpdateOptions(t *testing.T) {
+	table := rifftesting.OptionsTable{
+		{
+			Name: "invalid resource",
+			Options: &commands.PulsarGatewayUpdateOptions{
+				ResourceOptions: rifftesting.InvalidResourceOptions,
+			},
+			ExpectFieldErrors: rifftesting.InvalidResourceOptionsFieldError.Also(
+				cli.ErrMissingField(cli.ServiceURLFlagName),
+			),
+		},
+		{
+			Name: "valid resource",
+			Options: &commands.PulsarGatewayUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+				ServiceURL:      "pulsar://localhost:6650",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "missing service url",
+			Options: &commands.PulsarGatewayUpdateOptions{
+				ResourceOptions: rifftesting.ValidResourceOptions,
+			},
+			ExpectFieldErrors: cli.ErrMissingField(cli.ServiceURLFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestPulsarGatewayUpdateCommand(t *testing.T) {
+	defaultNamespace := "default"
+	pulsarGatewayName := "my-pulsar-gateway"
+
+	gateway := &streamv1alpha1.PulsarGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      pulsarGatewayName,
+		},
+		Spec: streamv1alpha1.PulsarGatewaySpec{
+			ServiceURL: "pulsar://localhost:6650",
+		},
+	}
+
+	table := rifftesting.CommandTable{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name:        "no service url",
+			Args:        []string{pulsarGatewayName},
+			ShouldError: true,
+		},
+		{
+			Name:        "empty service url",
+			Args:        []string{pulsarGatewayName, cli.ServiceURLFlagName, ""},
+			ShouldError: true,
+		},
+		{
+			Name: "update service url",
+			Args: []string{pulsarGatewayName, cli.ServiceURLFlagName, "pulsar://pulsar.local:6650"},
+			GivenObjects: []runtime.Object{
+				gateway,
+			},
+			ExpectUpdates: []runtime.Object{
+				&streamv1alpha1.PulsarGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      pulsarGatewayName,
+					},
+					Spec: streamv1alpha1.PulsarGatewaySpec{
+						ServiceURL: "pulsar://pulsar.local:6650",
+					},
+				},
+			},
+			ExpectOutput: `
+Updated pulsar gateway "my-pulsar-gateway"
+`,
+		},
+		{
+			Name: "not found",
+			Args: []string{pulsarGatewayName, cli.ServiceURLFlagName, "pulsar://pulsar.local:6650"},
+			ExpectOutput: `
+Pulsar gateway "default/my-pulsar-gateway" not found
+`,
+			ShouldError: true,
+		},
+		{
+			Name: "update error",
+			Args: []string{pulsarGatewayName, cli.ServiceURLFlagName, "pulsar://pulsar.local:6650"},
+			GivenObjects: []runtime.Object{
+				gateway,
+			},
+			WithReactors: []rifftesting.ReactionFunc{
+				rifftesting.InduceFailure("update", "pulsargatewaies"),
+			},
+			ExpectUpdates: []runtime.Object{
+				&streamv1alpha1.PulsarGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      pulsarGatewayName,
+					},
+					Spec: streamv1alpha1.PulsarGatewaySpec{
+						ServiceURL: "pulsar://pulsar.local:6650",
+					},
+				},
+			},
+			ShouldError: true,
+		},
+	}
+
+	table.Run(t, commands.NewPulsarGatewayUpdateCommand)
+}
@@ -144,11 +144,12 @@ function when creating a streaming processor.
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 	cmd.Flags().StringVar(&opts.Gateway, cli.StripDash(cli.GatewayFlagName), "", "`name` of stream gateway")
-	_ = cmd.MarkFlagCustom(cli.StripDash(cli.GatewayFlagName), "__"+c.Name+"_list_streaming_gateways")
+	_ = cmd.RegisterFlagCompletionFunc(cli.StripDash(cli.GatewayFlagName), cli.CompleteNames(c, cli.ListStreamingGateways))
 	cmd.Flags().StringVar(&opts.ContentType, cli.StripDash(cli.ContentTypeFlagName), "", "`MIME type` for message payloads accepted by the stream")
 	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(cli.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
 	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(cli.TailFlagName), false, "watch provisioning progress")
 	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(cli.WaitTimeoutFlagName), time.Second*10, "`duration` to wait for the stream to become ready when watching progress")
+	cli.ConfigDefault(cmd, cli.WaitTimeoutFlagName, cli.WaitTimeoutConfigKey)
 
 	return cmd
 }
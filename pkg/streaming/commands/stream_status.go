@@ -86,6 +86,7 @@ stream rollout is being processed.
 	cli.Args(cmd,
 		cli.NameArg(&opts.Name),
 	)
+	cmd.ValidArgsFunction = cli.CompleteNameArg(c, cli.ListStreamingStreams)
 
 	cli.NamespaceFlag(cmd, c, &opts.Namespace)
 
@@ -17,6 +17,7 @@
 package testing
 
 import (
+	"github.com/projectriff/cli/pkg/k8s"
 	projectriffclientset "github.com/projectriff/system/pkg/client/clientset/versioned/fake"
 	bindingsv1alpha1clientset "github.com/projectriff/system/pkg/client/clientset/versioned/typed/bindings/v1alpha1"
 	buildv1alpha1clientset "github.com/projectriff/system/pkg/client/clientset/versioned/typed/build/v1alpha1"
@@ -25,20 +26,29 @@ import (
 	streamv1alpha1clientset "github.com/projectriff/system/pkg/client/clientset/versioned/typed/streaming/v1alpha1"
 	apiextensionsv1beta1clientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/discovery"
+	dynamic "k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	kubernetes "k8s.io/client-go/kubernetes/fake"
+	appsv1clientset "k8s.io/client-go/kubernetes/typed/apps/v1"
 	authv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	corev1clientset "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 type FakeClient struct {
 	Namespace                  string
+	FakeKubeConfig             clientcmd.ClientConfig
 	FakeKubeRestConfig         *rest.Config
 	FakeKubeClientset          *kubernetes.Clientset
 	FakeRiffClientset          *projectriffclientset.Clientset
 	FakeAPIExtensionsClientset *apiextensionsv1beta1clientset.Clientset
+	FakeServingClientset       *FakeServingClientset
+	FakeDynamicClient          *dynamicfake.FakeDynamicClient
 	ActionRecorderList         ActionRecorderList
 }
 
@@ -46,6 +56,10 @@ func (c *FakeClient) DefaultNamespace() string {
 	return c.Namespace
 }
 
+func (c *FakeClient) KubeConfig() clientcmd.ClientConfig {
+	return c.FakeKubeConfig
+}
+
 func (c *FakeClient) KubeRestConfig() *rest.Config {
 	return c.FakeKubeRestConfig
 }
@@ -54,10 +68,18 @@ func (c *FakeClient) Core() corev1clientset.CoreV1Interface {
 	return c.FakeKubeClientset.CoreV1()
 }
 
+func (c *FakeClient) Apps() appsv1clientset.AppsV1Interface {
+	return c.FakeKubeClientset.AppsV1()
+}
+
 func (c *FakeClient) Discovery() discovery.DiscoveryInterface {
 	return c.FakeKubeClientset.Discovery()
 }
 
+func (c *FakeClient) Dynamic() dynamic.Interface {
+	return c.FakeDynamicClient
+}
+
 func (c *FakeClient) Auth() authv1client.AuthorizationV1Interface {
 	return c.FakeKubeClientset.AuthorizationV1()
 }
@@ -86,32 +108,61 @@ func (c *FakeClient) KnativeRuntime() knativev1alpha1clientset.KnativeV1alpha1In
 	return c.FakeRiffClientset.KnativeV1alpha1()
 }
 
+func (c *FakeClient) KnativeServing() k8s.ServingV1Interface {
+	return c.FakeServingClientset
+}
+
 func (c *FakeClient) PrependReactor(verb, resource string, reaction ReactionFunc) {
 	c.FakeKubeClientset.PrependReactor(verb, resource, reaction)
 	c.FakeAPIExtensionsClientset.PrependReactor(verb, resource, reaction)
 	c.FakeRiffClientset.PrependReactor(verb, resource, reaction)
+	c.FakeServingClientset.PrependReactor(verb, resource, reaction)
+	c.FakeDynamicClient.PrependReactor(verb, resource, reaction)
 }
 
 func NewClient(objects ...runtime.Object) *FakeClient {
-	lister := NewListers(objects)
+	// unstructured objects are only available from the dynamic client
+	typedObjects := []runtime.Object{}
+	unstructuredObjects := []runtime.Object{}
+	for _, obj := range objects {
+		if _, ok := obj.(*unstructured.Unstructured); ok {
+			unstructuredObjects = append(unstructuredObjects, obj)
+		} else {
+			typedObjects = append(typedObjects, obj)
+		}
+	}
+	lister := NewListers(typedObjects)
 
 	kubeRestConfig := &rest.Config{Host: "https://localhost:8443"}
+	rawKubeConfig := clientcmdapi.NewConfig()
+	rawKubeConfig.Clusters["fake"] = &clientcmdapi.Cluster{Server: kubeRestConfig.Host}
+	rawKubeConfig.AuthInfos["fake"] = &clientcmdapi.AuthInfo{}
+	rawKubeConfig.Contexts["fake"] = &clientcmdapi.Context{Cluster: "fake", AuthInfo: "fake"}
+	rawKubeConfig.CurrentContext = "fake"
+	kubeConfig := clientcmd.NewDefaultClientConfig(*rawKubeConfig, &clientcmd.ConfigOverrides{})
 	kubeClientset := kubernetes.NewSimpleClientset(lister.GetKubeObjects()...)
 	apiExtensionsClientset := apiextensionsv1beta1clientset.NewSimpleClientset(lister.GetAPIExtensionsObjects()...)
 	riffClientset := projectriffclientset.NewSimpleClientset(lister.GetProjectriffObjects()...)
+	servingClientset := NewServingClientset(lister.GetServingObjects()...)
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), unstructuredObjects...)
 
 	actionRecorderList := ActionRecorderList{
 		kubeClientset,
 		apiExtensionsClientset,
 		riffClientset,
+		servingClientset,
+		dynamicClient,
 	}
 
 	return &FakeClient{
 		Namespace:                  "default",
+		FakeKubeConfig:             kubeConfig,
 		FakeKubeRestConfig:         kubeRestConfig,
 		FakeKubeClientset:          kubeClientset,
 		FakeAPIExtensionsClientset: apiExtensionsClientset,
 		FakeRiffClientset:          riffClientset,
+		FakeServingClientset:       servingClientset,
+		FakeDynamicClient:          dynamicClient,
 		ActionRecorderList:         actionRecorderList,
 	}
 }
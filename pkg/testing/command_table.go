@@ -19,6 +19,7 @@ package testing
 import (
 	"bytes"
 	"context"
+	"io"
 	"os"
 	"os/exec"
 	"path"
@@ -30,8 +31,8 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/projectriff/cli/pkg/cli"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgotesting "k8s.io/client-go/testing"
 )
@@ -117,6 +118,8 @@ type CommandTableRecord struct {
 	// Stdin injects stub data to be read via os.Stdin for the command. Tests using stdin are
 	// forced to be sequential.
 	Stdin []byte
+	// Terminal reports stdin as an interactive terminal, for commands that prompt for values.
+	Terminal bool
 
 	// side effects
 
@@ -240,6 +243,9 @@ func (ctr CommandTableRecord) Run(t *testing.T, cmdFactory func(context.Context,
 		cmd.SetArgs(ctr.Args)
 
 		c.Stdin = bytes.NewBuffer(ctr.Stdin)
+		if ctr.Terminal {
+			c.Stdin = &terminal{Reader: c.Stdin}
+		}
 		output := &bytes.Buffer{}
 		cmd.SetOutput(output)
 		c.Stdout = output
@@ -374,10 +380,13 @@ func (ctr CommandTableRecord) Run(t *testing.T, cmdFactory func(context.Context,
 }
 
 func objKey(o runtime.Object) string {
-	on := o.(metav1.ObjectMetaAccessor)
+	on, err := meta.Accessor(o)
+	if err != nil {
+		panic(err)
+	}
 	// namespace + name is not unique, and the tests don't populate k8s kind
 	// information, so use GoLang's type name as part of the key.
-	return path.Join(reflect.TypeOf(o).String(), on.GetObjectMeta().GetNamespace(), on.GetObjectMeta().GetName())
+	return path.Join(reflect.TypeOf(o).String(), on.GetNamespace(), on.GetName())
 }
 
 var (
@@ -440,3 +449,12 @@ func fakeExecCommand(helper string) func(context.Context, string, ...string) *ex
 type defaultable interface {
 	Default()
 }
+
+// terminal is a reader that reports itself as an interactive terminal
+type terminal struct {
+	io.Reader
+}
+
+func (t *terminal) IsTerminal() bool {
+	return true
+}
@@ -17,6 +17,7 @@
 package testing
 
 import (
+	servingv1 "github.com/projectriff/system/pkg/apis/thirdparty/knative/serving/v1"
 	fakeprojectriffclientset "github.com/projectriff/system/pkg/client/clientset/versioned/fake"
 	fakeapiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -28,6 +29,7 @@ var clientSetSchemes = []func(*runtime.Scheme) error{
 	fakekubeclientset.AddToScheme,
 	fakeapiextensionsclientset.AddToScheme,
 	fakeprojectriffclientset.AddToScheme,
+	servingv1.AddToScheme,
 }
 
 type Listers struct {
@@ -65,3 +67,7 @@ func (l *Listers) GetAPIExtensionsObjects() []runtime.Object {
 func (l *Listers) GetProjectriffObjects() []runtime.Object {
 	return l.sorter.ObjectsForSchemeFunc(fakeprojectriffclientset.AddToScheme)
 }
+
+func (l *Listers) GetServingObjects() []runtime.Object {
+	return l.sorter.ObjectsForSchemeFunc(servingv1.AddToScheme)
+}
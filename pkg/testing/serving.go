@@ -0,0 +1,205 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testing
+
+import (
+	"github.com/projectriff/cli/pkg/k8s"
+	servingv1 "github.com/projectriff/system/pkg/apis/thirdparty/knative/serving/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	clientgotesting "k8s.io/client-go/testing"
+)
+
+var (
+	configurationsResource = servingv1.GroupVersion.WithResource("configurations")
+	configurationsKind     = servingv1.GroupVersion.WithKind("Configuration")
+	revisionsResource      = servingv1.GroupVersion.WithResource("revisions")
+	revisionsKind          = servingv1.GroupVersion.WithKind("Revision")
+	routesResource         = servingv1.GroupVersion.WithResource("routes")
+	servicesResource       = servingv1.GroupVersion.WithResource("services")
+	servicesKind           = servingv1.GroupVersion.WithKind("Service")
+)
+
+// FakeServingClientset is a fake Knative Serving client backed by an object tracker, in the
+// style of the generated fake clientsets.
+type FakeServingClientset struct {
+	clientgotesting.Fake
+}
+
+var _ k8s.ServingV1Interface = (*FakeServingClientset)(nil)
+
+func NewServingClientset(objects ...runtime.Object) *FakeServingClientset {
+	scheme := runtime.NewScheme()
+	if err := servingv1.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	codecs := serializer.NewCodecFactory(scheme)
+
+	o := clientgotesting.NewObjectTracker(scheme, codecs.UniversalDecoder())
+	for _, obj := range objects {
+		if err := o.Add(obj); err != nil {
+			panic(err)
+		}
+	}
+
+	cs := &FakeServingClientset{}
+	cs.AddReactor("*", "*", clientgotesting.ObjectReaction(o))
+	return cs
+}
+
+func (c *FakeServingClientset) Configurations(namespace string) k8s.ConfigurationInterface {
+	return &fakeConfigurations{fake: &c.Fake, ns: namespace}
+}
+
+func (c *FakeServingClientset) Revisions(namespace string) k8s.RevisionInterface {
+	return &fakeRevisions{fake: &c.Fake, ns: namespace}
+}
+
+func (c *FakeServingClientset) Routes(namespace string) k8s.RouteInterface {
+	return &fakeRoutes{fake: &c.Fake, ns: namespace}
+}
+
+func (c *FakeServingClientset) Services(namespace string) k8s.ServiceInterface {
+	return &fakeServices{fake: &c.Fake, ns: namespace}
+}
+
+type fakeConfigurations struct {
+	fake *clientgotesting.Fake
+	ns   string
+}
+
+func (c *fakeConfigurations) Get(name string, options metav1.GetOptions) (*servingv1.Configuration, error) {
+	obj, err := c.fake.Invokes(clientgotesting.NewGetAction(configurationsResource, c.ns, name), &servingv1.Configuration{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*servingv1.Configuration), err
+}
+
+func (c *fakeConfigurations) List(opts metav1.ListOptions) (*servingv1.ConfigurationList, error) {
+	obj, err := c.fake.Invokes(clientgotesting.NewListAction(configurationsResource, configurationsKind, c.ns, opts), &servingv1.ConfigurationList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := clientgotesting.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &servingv1.ConfigurationList{ListMeta: obj.(*servingv1.ConfigurationList).ListMeta}
+	for _, item := range obj.(*servingv1.ConfigurationList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+func (c *fakeConfigurations) Update(configuration *servingv1.Configuration) (*servingv1.Configuration, error) {
+	obj, err := c.fake.Invokes(clientgotesting.NewUpdateAction(configurationsResource, c.ns, configuration), &servingv1.Configuration{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*servingv1.Configuration), err
+}
+
+type fakeRevisions struct {
+	fake *clientgotesting.Fake
+	ns   string
+}
+
+func (c *fakeRevisions) Get(name string, options metav1.GetOptions) (*servingv1.Revision, error) {
+	obj, err := c.fake.Invokes(clientgotesting.NewGetAction(revisionsResource, c.ns, name), &servingv1.Revision{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*servingv1.Revision), err
+}
+
+func (c *fakeRevisions) List(opts metav1.ListOptions) (*servingv1.RevisionList, error) {
+	obj, err := c.fake.Invokes(clientgotesting.NewListAction(revisionsResource, revisionsKind, c.ns, opts), &servingv1.RevisionList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := clientgotesting.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &servingv1.RevisionList{ListMeta: obj.(*servingv1.RevisionList).ListMeta}
+	for _, item := range obj.(*servingv1.RevisionList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+type fakeRoutes struct {
+	fake *clientgotesting.Fake
+	ns   string
+}
+
+func (c *fakeRoutes) Get(name string, options metav1.GetOptions) (*servingv1.Route, error) {
+	obj, err := c.fake.Invokes(clientgotesting.NewGetAction(routesResource, c.ns, name), &servingv1.Route{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*servingv1.Route), err
+}
+
+type fakeServices struct {
+	fake *clientgotesting.Fake
+	ns   string
+}
+
+func (c *fakeServices) Get(name string, options metav1.GetOptions) (*servingv1.Service, error) {
+	obj, err := c.fake.Invokes(clientgotesting.NewGetAction(servicesResource, c.ns, name), &servingv1.Service{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*servingv1.Service), err
+}
+
+func (c *fakeServices) List(opts metav1.ListOptions) (*servingv1.ServiceList, error) {
+	obj, err := c.fake.Invokes(clientgotesting.NewListAction(servicesResource, servicesKind, c.ns, opts), &servingv1.ServiceList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := clientgotesting.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &servingv1.ServiceList{ListMeta: obj.(*servingv1.ServiceList).ListMeta}
+	for _, item := range obj.(*servingv1.ServiceList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+func (c *fakeServices) Update(service *servingv1.Service) (*servingv1.Service, error) {
+	obj, err := c.fake.Invokes(clientgotesting.NewUpdateAction(servicesResource, c.ns, service), &servingv1.Service{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*servingv1.Service), err
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/projectriff/cli/pkg/cli"
+)
+
+// ProbeHandler validates a probe action in the form "http:[<port>:]<path>", "tcp[:<port>]" or
+// "exec:<command>"
+func ProbeHandler(handler, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	parts := strings.SplitN(handler, ":", 2)
+	switch parts[0] {
+	case "http":
+		if len(parts) != 2 {
+			errs = errs.Also(cli.ErrInvalidValue(handler, field))
+			break
+		}
+		path := parts[1]
+		if !strings.HasPrefix(path, "/") {
+			portPath := strings.SplitN(path, ":", 2)
+			if len(portPath) != 2 || !isPort(portPath[0]) {
+				errs = errs.Also(cli.ErrInvalidValue(handler, field))
+				break
+			}
+			path = portPath[1]
+		}
+		if !strings.HasPrefix(path, "/") {
+			errs = errs.Also(cli.ErrInvalidValue(handler, field))
+		}
+	case "tcp":
+		if len(parts) == 2 && !isPort(parts[1]) {
+			errs = errs.Also(cli.ErrInvalidValue(handler, field))
+		}
+	case "exec":
+		if len(parts) != 2 || len(strings.Fields(parts[1])) == 0 {
+			errs = errs.Also(cli.ErrInvalidValue(handler, field))
+		}
+	default:
+		errs = errs.Also(cli.ErrInvalidValue(handler, field))
+	}
+
+	return errs
+}
+
+// WholeSeconds validates a non-negative duration with a whole number of seconds, at least min
+func WholeSeconds(value string, min time.Duration, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if d, err := time.ParseDuration(value); err != nil || d < 0 || d < min || d%time.Second != 0 {
+		errs = errs.Also(cli.ErrInvalidValue(value, field))
+	}
+
+	return errs
+}
+
+func isPort(str string) bool {
+	port, err := strconv.ParseInt(str, 10, 32)
+	return err == nil && port >= 1 && port <= 65535
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/cli"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"github.com/projectriff/cli/pkg/validation"
+)
+
+func TestProbeHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected cli.FieldErrors
+		value    string
+	}{{
+		name:     "http path",
+		expected: cli.FieldErrors{},
+		value:    "http:/healthz",
+	}, {
+		name:     "http port and path",
+		expected: cli.FieldErrors{},
+		value:    "http:8080:/healthz",
+	}, {
+		name:     "http missing path",
+		expected: cli.ErrInvalidValue("http", rifftesting.TestField),
+		value:    "http",
+	}, {
+		name:     "http relative path",
+		expected: cli.ErrInvalidValue("http:8080:healthz", rifftesting.TestField),
+		value:    "http:8080:healthz",
+	}, {
+		name:     "http invalid port",
+		expected: cli.ErrInvalidValue("http:0:/healthz", rifftesting.TestField),
+		value:    "http:0:/healthz",
+	}, {
+		name:     "tcp",
+		expected: cli.FieldErrors{},
+		value:    "tcp",
+	}, {
+		name:     "tcp port",
+		expected: cli.FieldErrors{},
+		value:    "tcp:8080",
+	}, {
+		name:     "tcp invalid port",
+		expected: cli.ErrInvalidValue("tcp:http", rifftesting.TestField),
+		value:    "tcp:http",
+	}, {
+		name:     "exec",
+		expected: cli.FieldErrors{},
+		value:    "exec:cat /tmp/healthy",
+	}, {
+		name:     "exec missing command",
+		expected: cli.ErrInvalidValue("exec: ", rifftesting.TestField),
+		value:    "exec: ",
+	}, {
+		name:     "unknown action",
+		expected: cli.ErrInvalidValue("grpc:8080", rifftesting.TestField),
+		value:    "grpc:8080",
+	}, {
+		name:     "empty",
+		expected: cli.ErrInvalidValue("", rifftesting.TestField),
+		value:    "",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.ProbeHandler(test.value, rifftesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestWholeSeconds(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected cli.FieldErrors
+		value    string
+		min      time.Duration
+	}{{
+		name:     "valid",
+		expected: cli.FieldErrors{},
+		value:    "30s",
+	}, {
+		name:     "zero",
+		expected: cli.FieldErrors{},
+		value:    "0s",
+	}, {
+		name:     "below min",
+		expected: cli.ErrInvalidValue("0s", rifftesting.TestField),
+		value:    "0s",
+		min:      time.Second,
+	}, {
+		name:     "fractional",
+		expected: cli.ErrInvalidValue("1500ms", rifftesting.TestField),
+		value:    "1500ms",
+	}, {
+		name:     "negative",
+		expected: cli.ErrInvalidValue("-1s", rifftesting.TestField),
+		value:    "-1s",
+	}, {
+		name:     "not a duration",
+		expected: cli.ErrInvalidValue("30", rifftesting.TestField),
+		value:    "30",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.WholeSeconds(test.value, test.min, rifftesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation
+
+import (
+	"path"
+	"strings"
+
+	"github.com/projectriff/cli/pkg/cli"
+)
+
+// Mount validates a mount in the form "<name>:<path>"
+func Mount(mount, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	parts := strings.SplitN(mount, ":", 2)
+	if len(parts) != 2 || len(K8sName(parts[0], field)) != 0 || len(MountPath(parts[1], field)) != 0 {
+		errs = errs.Also(cli.ErrInvalidValue(mount, field))
+	}
+
+	return errs
+}
+
+func Mounts(mounts []string, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	for i, mount := range mounts {
+		errs = errs.Also(Mount(mount, cli.CurrentField).ViaFieldIndex(field, i))
+	}
+
+	return errs
+}
+
+// MountPath validates an absolute, normalized path other than the root directory
+func MountPath(mountPath, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	if !path.IsAbs(mountPath) || path.Clean(mountPath) != mountPath || mountPath == "/" {
+		errs = errs.Also(cli.ErrInvalidValue(mountPath, field))
+	}
+
+	return errs
+}
+
+func MountPaths(mountPaths []string, field string) cli.FieldErrors {
+	errs := cli.FieldErrors{}
+
+	for i, mountPath := range mountPaths {
+		errs = errs.Also(MountPath(mountPath, cli.CurrentField).ViaFieldIndex(field, i))
+	}
+
+	return errs
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/projectriff/cli/pkg/cli"
+	rifftesting "github.com/projectriff/cli/pkg/testing"
+	"github.com/projectriff/cli/pkg/validation"
+)
+
+func TestMount(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected cli.FieldErrors
+		value    string
+	}{{
+		name:     "valid",
+		expected: cli.FieldErrors{},
+		value:    "my-config:/etc/config",
+	}, {
+		name:     "missing path",
+		expected: cli.ErrInvalidValue("my-config", rifftesting.TestField),
+		value:    "my-config",
+	}, {
+		name:     "invalid name",
+		expected: cli.ErrInvalidValue("My_Config:/etc/config", rifftesting.TestField),
+		value:    "My_Config:/etc/config",
+	}, {
+		name:     "relative path",
+		expected: cli.ErrInvalidValue("my-config:etc/config", rifftesting.TestField),
+		value:    "my-config:etc/config",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.Mount(test.value, rifftesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestMounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected cli.FieldErrors
+		values   []string
+	}{{
+		name:     "valid",
+		expected: cli.FieldErrors{},
+		values:   []string{"my-config:/etc/config", "my-other-config:/etc/other"},
+	}, {
+		name:     "empty",
+		expected: cli.FieldErrors{},
+		values:   []string{},
+	}, {
+		name:     "some invalid",
+		expected: cli.ErrInvalidValue("my-other-config", cli.CurrentField).ViaFieldIndex(rifftesting.TestField, 1),
+		values:   []string{"my-config:/etc/config", "my-other-config"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.Mounts(test.values, rifftesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestMountPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected cli.FieldErrors
+		value    string
+	}{{
+		name:     "valid",
+		expected: cli.FieldErrors{},
+		value:    "/var/cache",
+	}, {
+		name:     "empty",
+		expected: cli.ErrInvalidValue("", rifftesting.TestField),
+		value:    "",
+	}, {
+		name:     "root",
+		expected: cli.ErrInvalidValue("/", rifftesting.TestField),
+		value:    "/",
+	}, {
+		name:     "relative",
+		expected: cli.ErrInvalidValue("var/cache", rifftesting.TestField),
+		value:    "var/cache",
+	}, {
+		name:     "not normalized",
+		expected: cli.ErrInvalidValue("/var/../etc", rifftesting.TestField),
+		value:    "/var/../etc",
+	}, {
+		name:     "trailing slash",
+		expected: cli.ErrInvalidValue("/var/cache/", rifftesting.TestField),
+		value:    "/var/cache/",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.MountPath(test.value, rifftesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}